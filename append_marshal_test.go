@@ -0,0 +1,84 @@
+package json_test
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+type appendMarshalRecord struct {
+	ID    int      `json:"id"`
+	Name  string   `json:"name"`
+	Tags  []string `json:"tags"`
+	Score float64  `json:"score"`
+	Next  *appendMarshalRecord
+}
+
+func TestAppendMarshal(t *testing.T) {
+	v := &appendMarshalRecord{ID: 1, Name: "<go>", Tags: []string{"a", "b"}, Score: 1.5, Next: &appendMarshalRecord{ID: 2}}
+	t.Run("append", func(t *testing.T) {
+		expected, err := json.Marshal(v)
+		assertErr(t, err)
+		got, err := json.AppendMarshal([]byte("data: "), v)
+		assertErr(t, err)
+		assertEq(t, "append", "data: "+string(expected), string(got))
+
+		got, err = json.AppendMarshal(nil, nil)
+		assertErr(t, err)
+		assertEq(t, "nil", "null", string(got))
+	})
+	t.Run("reuse buffer", func(t *testing.T) {
+		buf := make([]byte, 0, 1024)
+		got, err := json.AppendMarshal(buf, v)
+		assertErr(t, err)
+		if &got[0] != &buf[:1][0] {
+			t.Fatal("expected the result to be written into the given buffer")
+		}
+		got, err = json.AppendMarshal(got[:0], []int{1, 2})
+		assertErr(t, err)
+		assertEq(t, "reused", "[1,2]", string(got))
+	})
+	t.Run("option", func(t *testing.T) {
+		got, err := json.AppendMarshal([]byte("["), map[string]int{"b": 1, "a": 2}, json.UnorderedMap(), json.DisableHTMLEscape())
+		assertErr(t, err)
+		if s := string(got); s != `[{"a":2,"b":1}` && s != `[{"b":1,"a":2}` {
+			t.Fatalf("unexpected result: %s", s)
+		}
+	})
+	t.Run("indent", func(t *testing.T) {
+		expected, err := json.MarshalIndent(v, ">", "  ")
+		assertErr(t, err)
+		got, err := json.AppendMarshalIndent([]byte("data: "), v, ">", "  ")
+		assertErr(t, err)
+		assertEq(t, "indent", "data: "+string(expected), string(got))
+	})
+	t.Run("error", func(t *testing.T) {
+		dst := []byte("data: ")
+		got, err := json.AppendMarshal(dst, []interface{}{1, make(chan int)})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		assertEq(t, "dst", "data: ", string(got))
+
+		got, err = json.AppendMarshalIndent(dst, []interface{}{1, make(chan int)}, "", "  ")
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		assertEq(t, "dst with indent", "data: ", string(got))
+	})
+	t.Run("allocs", func(t *testing.T) {
+		if raceEnabled {
+			t.Skip("the race detector allocates")
+		}
+		buf := make([]byte, 0, 1024)
+		// warm up the cache of the compiled encoder
+		_, err := json.AppendMarshal(buf, v)
+		assertErr(t, err)
+		allocs := testing.AllocsPerRun(100, func() {
+			buf, _ = json.AppendMarshal(buf[:0], v)
+		})
+		if allocs != 0 {
+			t.Fatalf("expected no allocations but got %v", allocs)
+		}
+	})
+}
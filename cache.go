@@ -0,0 +1,64 @@
+package json
+
+import (
+	"reflect"
+
+	"github.com/goccy/go-json/internal/decoder"
+	"github.com/goccy/go-json/internal/encoder"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// CacheStatistics is the statistics of the caches of the compiled encoders and decoders.
+type CacheStatistics struct {
+	// Encoders is the number of the cached encoders.
+	Encoders int
+	// Decoders is the number of the cached decoders.
+	Decoders int
+	// EncoderEvictions is the number of the encoders evicted by the cache limit.
+	EncoderEvictions uint64
+	// DecoderEvictions is the number of the decoders evicted by the cache limit.
+	DecoderEvictions uint64
+}
+
+// Precompile compiles the encoders and decoders of types and caches them in advance,
+// so that the first Marshal or Unmarshal of the types does not pay for the compilation.
+// The encoders of both the type and the pointer to the type are compiled,
+// and the decoder is compiled for decoding into the pointer to the type.
+// Encoders compiled with options that change the compiled code, like TagKey, are not precompiled.
+func Precompile(types ...reflect.Type) error {
+	for _, typ := range types {
+		if typ == nil {
+			continue
+		}
+		ptrType := reflect.PtrTo(typ)
+		if err := encoder.Precompile(runtime.Type2RType(typ)); err != nil {
+			return err
+		}
+		if err := encoder.Precompile(runtime.Type2RType(ptrType)); err != nil {
+			return err
+		}
+		if _, err := decoder.CompileToGetDecoder(runtime.Type2RType(ptrType), &decoder.Option{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CacheStats returns the current statistics of the caches of the compiled encoders and decoders.
+func CacheStats() CacheStatistics {
+	var stats CacheStatistics
+	stats.Encoders, stats.EncoderEvictions = encoder.CacheStats()
+	stats.Decoders, stats.DecoderEvictions = decoder.CacheStats()
+	return stats
+}
+
+// SetCacheLimit limits the number of the cached encoders and decoders of the types that are not defined in the binary,
+// like the types created by reflect.StructOf, of the encoders and decoders compiled with options like TagKey,
+// and of the encoders filtered by each FieldQuery for each type.
+// If a cache reaches the limit, arbitrary entries are evicted and compiled again on the next use.
+// The encoders filtered by FieldQuery are limited to 256 for each type even without the limit,
+// since the queries may be built from untrusted input by ParseFieldQuery.
+// Zero or a negative limit means no limit, which is the default.
+func SetCacheLimit(limit int) {
+	runtime.SetCacheLimit(limit)
+}
@@ -0,0 +1,103 @@
+package json_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+type precompiledRecord struct {
+	ID       int                  `json:"id"`
+	Children []*precompiledRecord `json:"children"`
+}
+
+func TestPrecompile(t *testing.T) {
+	before := json.CacheStats()
+	assertErr(t, json.Precompile(reflect.TypeOf(precompiledRecord{}), nil))
+	after := json.CacheStats()
+	if after.Encoders < before.Encoders+2 {
+		t.Fatalf("expected the encoders to be cached: before %d, after %d", before.Encoders, after.Encoders)
+	}
+	if after.Decoders < before.Decoders+1 {
+		t.Fatalf("expected the decoder to be cached: before %d, after %d", before.Decoders, after.Decoders)
+	}
+
+	v := &precompiledRecord{ID: 1, Children: []*precompiledRecord{{ID: 2}}}
+	b, err := json.Marshal(v)
+	assertErr(t, err)
+	assertEq(t, "marshal", `{"id":1,"children":[{"id":2,"children":null}]}`, string(b))
+	var got precompiledRecord
+	assertErr(t, json.Unmarshal(b, &got))
+	assertEq(t, "unmarshal", 2, got.Children[0].ID)
+	if stats := json.CacheStats(); stats.Encoders != after.Encoders || stats.Decoders != after.Decoders {
+		t.Fatalf("expected no compilation after Precompile: %+v, %+v", after, stats)
+	}
+
+	if err := json.Precompile(reflect.TypeOf(make(chan int))); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestCacheLimit(t *testing.T) {
+	const limit = 4
+	json.SetCacheLimit(limit)
+	defer json.SetCacheLimit(0)
+
+	types := make([]reflect.Type, 10)
+	for i := range types {
+		types[i] = reflect.StructOf([]reflect.StructField{{
+			Name: fmt.Sprintf("CacheLimitField%d", i),
+			Type: reflect.TypeOf(0),
+			Tag:  `json:"v"`,
+		}})
+	}
+	before := json.CacheStats()
+	for i := 0; i < 2; i++ {
+		for j, typ := range types {
+			v := reflect.New(typ)
+			v.Elem().Field(0).SetInt(int64(j))
+			b, err := json.Marshal(v.Interface())
+			assertErr(t, err)
+			assertEq(t, "marshal", fmt.Sprintf(`{"v":%d}`, j), string(b))
+			v.Elem().Field(0).SetInt(0)
+			assertErr(t, json.Unmarshal(b, v.Interface()))
+			assertEq(t, "unmarshal", int64(j), v.Elem().Field(0).Int())
+		}
+	}
+	after := json.CacheStats()
+	if evicted := after.EncoderEvictions - before.EncoderEvictions; evicted < uint64(len(types)-limit) {
+		t.Fatalf("expected the encoders to be evicted: %d", evicted)
+	}
+	if evicted := after.DecoderEvictions - before.DecoderEvictions; evicted < uint64(len(types)-limit) {
+		t.Fatalf("expected the decoders to be evicted: %d", evicted)
+	}
+}
+
+func TestQueryCacheLimit(t *testing.T) {
+	const limit = 4
+	json.SetCacheLimit(limit)
+	defer json.SetCacheLimit(0)
+
+	type T struct {
+		A, B, C, D, E, F, G, H int
+	}
+	v := T{1, 2, 3, 4, 5, 6, 7, 8}
+	names := []string{"A", "B", "C", "D", "E", "F", "G", "H"}
+	before := json.CacheStats()
+	for i := 0; i < 2; i++ {
+		for j, name := range names {
+			query, err := json.ParseFieldQuery(name)
+			assertErr(t, err)
+			b, err := json.MarshalContext(json.SetFieldQueryToContext(context.Background(), query), v)
+			assertErr(t, err)
+			assertEq(t, "marshal", fmt.Sprintf(`{"%s":%d}`, name, j+1), string(b))
+		}
+	}
+	after := json.CacheStats()
+	if evicted := after.EncoderEvictions - before.EncoderEvictions; evicted < uint64(len(names)-limit) {
+		t.Fatalf("expected the filtered encoders to be evicted: %d", evicted)
+	}
+}
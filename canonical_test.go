@@ -0,0 +1,83 @@
+package json_test
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{
+			name: "rfc8785 example",
+			src: `{
+  "numbers": [333333333.33333329, 1E30, 4.50, 2e-3, 0.000000000000000000000000001],
+  "string": "\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/",
+  "literals": [null, true, false]
+}`,
+			expected: `{"literals":[null,true,false],"numbers":[333333333.3333333,1e+30,4.5,0.002,1e-27],"string":"€$\u000f\nA'B\"\\\\\"/"}`,
+		},
+		{
+			name:     "utf16 key order",
+			src:      `{"\u20ac":1,"\r":2,"\ufb33":3,"1":4,"\ud83d\ude00":5,"\u0080":6,"\u00f6":7}`,
+			expected: "{\"\\r\":2,\"1\":4,\"\u0080\":6,\"ö\":7,\"€\":1,\"😀\":5,\"\ufb33\":3}",
+		},
+		{
+			name:     "numbers",
+			src:      `[0, -0, 1e21, 1e20, 1e-6, 1e-7, -1.5e-10, 123456789012345678901, 9007199254740993]`,
+			expected: `[0,0,1e+21,100000000000000000000,0.000001,1e-7,-1.5e-10,123456789012345680000,9007199254740992]`,
+		},
+		{
+			name:     "nested",
+			src:      ` { "b" : [ { "z" : 1 , "a" : { } } , [ ] ] , "a" : "<&>" } `,
+			expected: `{"a":"<&>","b":[{"a":{},"z":1},[]]}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := json.Canonicalize([]byte(test.src))
+			assertErr(t, err)
+			assertEq(t, "canonical", test.expected, string(got))
+		})
+	}
+	t.Run("invalid", func(t *testing.T) {
+		for _, src := range []string{
+			``,
+			`{"a":1,"a":2}`,
+			`"\ud800"`,
+			"\"\xff\"",
+			`[1,]`,
+			`{"a":1} x`,
+			`"abc`,
+		} {
+			if _, err := json.Canonicalize([]byte(src)); err == nil {
+				t.Errorf("expected error for %q", src)
+			}
+		}
+	})
+}
+
+func TestMarshalCanonical(t *testing.T) {
+	type child struct {
+		Z float64 `json:"z"`
+		A string  `json:"a"`
+	}
+	v := struct {
+		Name   string         `json:"name"`
+		Child  child          `json:"child"`
+		Values map[string]int `json:"values"`
+		HTML   string         `json:"html"`
+	}{
+		Name:   "go-json",
+		Child:  child{Z: 1e-7, A: "\u2028"},
+		Values: map[string]int{"b": 2, "a": 1},
+		HTML:   "<a>",
+	}
+	got, err := json.MarshalCanonical(v)
+	assertErr(t, err)
+	assertEq(t, "canonical", "{\"child\":{\"a\":\"\u2028\",\"z\":1e-7},\"html\":\"<a>\",\"name\":\"go-json\",\"values\":{\"a\":1,\"b\":2}}", string(got))
+}
@@ -0,0 +1,61 @@
+// Package fixture has the types to test the code generated by go-json-gen.
+package fixture
+
+import (
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+//go:generate go run github.com/goccy/go-json/cmd/go-json-gen -type Item,User
+
+type Base struct {
+	ID        int64  `json:"id"`
+	CreatedBy string `json:"created_by,omitempty"`
+}
+
+type Audit struct {
+	UpdatedBy string `json:"updated_by"`
+	Revision  uint32 `json:"revision,string"`
+}
+
+type Meta struct {
+	Labels map[string]string `json:"labels"`
+	Score  float32           `json:"score"`
+}
+
+type Level int8
+
+type Item struct {
+	Name  string   `json:"name"`
+	Price float64  `json:"price"`
+	Count uint16   `json:"count,string"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+type User struct {
+	Base
+	*Audit
+	Name      string             `json:"name"`
+	Email     string             `json:"email,omitempty"`
+	Age       int                `json:"age,omitzero"`
+	Level     Level              `json:"level"`
+	Admin     bool               `json:"admin,string"`
+	Ratio     float64            `json:"ratio,omitempty"`
+	Nickname  *string            `json:"nickname"`
+	Items     []Item             `json:"items"`
+	Primary   *Item              `json:"primary,omitempty"`
+	Favorites [2]Item            `json:"favorites"`
+	Scores    map[string]int     `json:"scores"`
+	Groups    map[string][]*Item `json:"groups,omitempty"`
+	Avatar    []byte             `json:"avatar"`
+	Meta      Meta               `json:"meta"`
+	Extra     interface{}        `json:"extra"`
+	Joined    time.Time          `json:"joined,omitzero"`
+	Deadline  *time.Time         `json:"deadline,omitempty"`
+	Raw       json.RawMessage    `json:"raw,omitempty"`
+	Point     struct{ X, Y int } `json:"point,omitzero"`
+	Untagged  string
+	Ignored   string `json:"-"`
+	internal  int
+}
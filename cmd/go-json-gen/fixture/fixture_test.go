@@ -0,0 +1,244 @@
+package fixture_test
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/goccy/go-json"
+	"github.com/goccy/go-json/cmd/go-json-gen/fixture"
+)
+
+// plainItem and plainUser have the same layout as the fixture types without the generated methods,
+// so that the runtime encodes them by reflection.
+type plainItem struct {
+	Name  string   `json:"name"`
+	Price float64  `json:"price"`
+	Count uint16   `json:"count,string"`
+	Tags  []string `json:"tags,omitempty"`
+}
+
+type plainUser struct {
+	fixture.Base
+	*fixture.Audit
+	Name      string                  `json:"name"`
+	Email     string                  `json:"email,omitempty"`
+	Age       int                     `json:"age,omitzero"`
+	Level     fixture.Level           `json:"level"`
+	Admin     bool                    `json:"admin,string"`
+	Ratio     float64                 `json:"ratio,omitempty"`
+	Nickname  *string                 `json:"nickname"`
+	Items     []plainItem             `json:"items"`
+	Primary   *plainItem              `json:"primary,omitempty"`
+	Favorites [2]plainItem            `json:"favorites"`
+	Scores    map[string]int          `json:"scores"`
+	Groups    map[string][]*plainItem `json:"groups,omitempty"`
+	Avatar    []byte                  `json:"avatar"`
+	Meta      fixture.Meta            `json:"meta"`
+	Extra     interface{}             `json:"extra"`
+	Joined    time.Time               `json:"joined,omitzero"`
+	Deadline  *time.Time              `json:"deadline,omitempty"`
+	Raw       json.RawMessage         `json:"raw,omitempty"`
+	Point     struct{ X, Y int }      `json:"point,omitzero"`
+	Untagged  string
+	Ignored   string `json:"-"`
+	internal  int
+}
+
+func toPlain(u *fixture.User) *plainUser {
+	return (*plainUser)(unsafe.Pointer(u))
+}
+
+// unmarshal decodes a copy of src since Unmarshal may use the spare capacity of src as the working buffer.
+func unmarshal(src []byte, v interface{}) error {
+	return json.Unmarshal(append([]byte(nil), src...), v)
+}
+
+func testUsers() map[string]*fixture.User {
+	nickname := "<go>"
+	deadline := time.Date(2030, 1, 2, 3, 4, 5, 6, time.UTC)
+	return map[string]*fixture.User{
+		"zero": {},
+		"full": {
+			Base:     fixture.Base{ID: -1 << 40, CreatedBy: "admin"},
+			Audit:    &fixture.Audit{UpdatedBy: "bot", Revision: 3},
+			Name:     "gopher   \"quoted\" & <escaped>",
+			Email:    "gopher@example.com",
+			Age:      12,
+			Level:    -3,
+			Admin:    true,
+			Ratio:    1e-7,
+			Nickname: &nickname,
+			Items: []fixture.Item{
+				{Name: "a", Price: 1.5, Count: 2, Tags: []string{"x", "y"}},
+				{Name: "b", Price: 1e21, Tags: []string{}},
+			},
+			Primary:   &fixture.Item{Name: "p", Price: -0.25},
+			Favorites: [2]fixture.Item{{Name: "f"}},
+			Scores:    map[string]int{"b": 2, "a": 1, "<": 0, "\xff": 3},
+			Groups: map[string][]*fixture.Item{
+				"g":     {{Name: "g1"}, nil},
+				"empty": {},
+				"nil":   nil,
+			},
+			Avatar:   []byte("avatar"),
+			Meta:     fixture.Meta{Labels: map[string]string{"k": "v"}, Score: 0.1},
+			Extra:    map[string]interface{}{"list": []interface{}{1, "two", nil}},
+			Joined:   time.Date(2020, 5, 6, 7, 8, 9, 0, time.UTC),
+			Deadline: &deadline,
+			Raw:      json.RawMessage(`{"raw" : [1, 2]}`),
+			Point:    struct{ X, Y int }{X: 1},
+			Untagged: "untagged",
+			Ignored:  "ignored",
+		},
+		"empty containers": {
+			Items:  []fixture.Item{},
+			Scores: map[string]int{},
+			Avatar: []byte{},
+			Groups: map[string][]*fixture.Item{},
+			Extra:  []int{},
+		},
+	}
+}
+
+func TestGeneratedEncoder(t *testing.T) {
+	for name, u := range testUsers() {
+		t.Run(name, func(t *testing.T) {
+			expected, err := json.Marshal(toPlain(u))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := u.AppendJSON(nil, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(expected) != string(got) {
+				t.Fatalf("failed to encode by AppendJSON:\nexpected: %s\n     got: %s", expected, got)
+			}
+			got, err = json.Marshal(u)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(expected) != string(got) {
+				t.Fatalf("failed to encode by MarshalJSON:\nexpected: %s\n     got: %s", expected, got)
+			}
+			expected, err = json.MarshalIndent(toPlain(u), "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err = json.MarshalIndent(u, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(expected) != string(got) {
+				t.Fatalf("failed to encode with indent:\nexpected: %s\n     got: %s", expected, got)
+			}
+		})
+	}
+	t.Run("unsupported float", func(t *testing.T) {
+		for _, f := range []float64{math.NaN(), math.Inf(1)} {
+			u := &fixture.User{Items: []fixture.Item{{Price: f}}}
+			_, expected := json.Marshal(toPlain(u))
+			_, err := u.AppendJSON(nil, nil)
+			if expected == nil || err == nil {
+				t.Fatalf("expected error for %v", f)
+			}
+			if expected.Error() != err.Error() {
+				t.Fatalf("unexpected error: expected %q but got %q", expected, err)
+			}
+		}
+	})
+}
+
+func TestGeneratedEncoderWithFieldQuery(t *testing.T) {
+	u := testUsers()["full"]
+	for _, query := range []string{
+		"name,age",
+		"id,updated_by,items(name,tags),primary(price)",
+		"*,-items,-groups,-meta",
+		"groups/name,favorites(count),meta(score)",
+		"-id,-created_by,-scores,primary(-name)",
+	} {
+		t.Run(query, func(t *testing.T) {
+			q, err := json.ParseFieldQuery(query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := json.SetFieldQueryToContext(context.Background(), q)
+			expected, err := json.MarshalContext(ctx, toPlain(u))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := u.AppendJSON(nil, q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(expected) != string(got) {
+				t.Fatalf("failed to encode with field query:\nexpected: %s\n     got: %s", expected, got)
+			}
+		})
+	}
+}
+
+func TestGeneratedDecoder(t *testing.T) {
+	for name, u := range testUsers() {
+		t.Run(name, func(t *testing.T) {
+			src, err := json.Marshal(toPlain(u))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var expected, got fixture.User
+			if err := unmarshal(src, toPlain(&expected)); err != nil {
+				t.Fatal(err)
+			}
+			if err := unmarshal(src, &got); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(expected, got) {
+				t.Fatalf("failed to decode:\nexpected: %+v\n     got: %+v", expected, got)
+			}
+		})
+	}
+	t.Run("existing values", func(t *testing.T) {
+		src := `{"id":1, "NAME":"case insensitive", "items":[{"name":"x"}], "favorites":[{"name":"y"}],
+			"primary":null, "scores":{"c":3}, "nickname":null, "avatar":null, "unknown":{"a":[1,{"b":null}]},
+			"age":null, "count":"1", "revision":"4", "admin":"false", "name" : "é😀\t"}`
+		expected, got := testUsers()["full"], testUsers()["full"]
+		if err := json.Unmarshal([]byte(src), toPlain(expected)); err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal([]byte(src), got); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(expected, got) {
+			t.Fatalf("failed to decode:\nexpected: %+v\n     got: %+v", expected, got)
+		}
+	})
+	t.Run("errors", func(t *testing.T) {
+		for _, src := range []string{
+			``,
+			`[]`,
+			`{"name":1}`,
+			`{"age":"1"}`,
+			`{"level":128}`,
+			`{"revision":1}`,
+			`{"items":[1]}`,
+			`{"items":[{"name":"a"},]}`,
+			`{"scores":{"a":1,}}`,
+			`{"name":"a"`,
+			`{"name":"a"} x`,
+			`{"name":"\x01"}`,
+			`{"meta":{"score":"x"}}`,
+		} {
+			var expected, got fixture.User
+			expectedErr := json.Unmarshal([]byte(src), toPlain(&expected))
+			err := json.Unmarshal([]byte(src), &got)
+			if expectedErr == nil || err == nil {
+				t.Fatalf("expected error for %s: runtime %v, generated %v", src, expectedErr, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,590 @@
+// Code generated by go-json-gen. DO NOT EDIT.
+
+package fixture
+
+import (
+	"strconv"
+	"time"
+
+	json "github.com/goccy/go-json"
+	"github.com/goccy/go-json/jsongen"
+)
+
+// MarshalJSON implements json.Marshaler.
+func (v Item) MarshalJSON() ([]byte, error) {
+	return jsongen.Marshal(&v)
+}
+
+// AppendJSON appends the JSON encoding of v to b, keeping the fields selected by query.
+func (v *Item) AppendJSON(b []byte, query *json.FieldQuery) ([]byte, error) {
+	if v == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if _, ok := query.Lookup("name"); ok {
+		b = append(b, "\"name\":"...)
+		b = jsongen.AppendString(b, v.Name)
+		b = append(b, ',')
+	}
+	if _, ok := query.Lookup("price"); ok {
+		b = append(b, "\"price\":"...)
+		if b, err = jsongen.AppendFloat64(b, v.Price); err != nil {
+			return b, err
+		}
+		b = append(b, ',')
+	}
+	if _, ok := query.Lookup("count"); ok {
+		b = append(b, "\"count\":"...)
+		b = append(b, '"')
+		b = strconv.AppendUint(b, uint64(v.Count), 10)
+		b = append(b, '"')
+		b = append(b, ',')
+	}
+	if len(v.Tags) != 0 {
+		if _, ok := query.Lookup("tags"); ok {
+			b = append(b, "\"tags\":"...)
+			if v.Tags == nil {
+				b = append(b, "null"...)
+			} else {
+				b = append(b, '[')
+				for i1 := range v.Tags {
+					b = jsongen.AppendString(b, v.Tags[i1])
+					b = append(b, ',')
+				}
+				b = jsongen.EndArray(b)
+			}
+			b = append(b, ',')
+		}
+	}
+	return jsongen.EndObject(b), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *Item) UnmarshalJSON(data []byte) error {
+	l := jsongen.NewLexer(data)
+	v.DecodeJSON(l)
+	l.End()
+	return l.Error()
+}
+
+// DecodeJSON decodes the JSON value read by l into v.
+func (v *Item) DecodeJSON(l *jsongen.Lexer) {
+	if l.IsNull() {
+		return
+	}
+	l.BeginObject(v)
+	for l.More() {
+		switch jsongen.FieldIndex(l.Key(), jsonFieldsOfItem) {
+		case 0:
+			if !l.IsNull() {
+				v.Name = l.String()
+			}
+		case 1:
+			if !l.IsNull() {
+				v.Price = l.Float(64)
+			}
+		case 2:
+			if !l.IsNull() {
+				q2 := l.Quoted()
+				v.Count = uint16(q2.Uint(16))
+				l.EndQuoted(q2)
+			}
+		case 3:
+			if l.IsNull() {
+				v.Tags = nil
+			} else {
+				l.BeginArray(&v.Tags)
+				if v.Tags == nil {
+					v.Tags = []string{}
+				}
+				i3 := 0
+				for ; l.More(); i3++ {
+					if i3 >= len(v.Tags) {
+						var zero4 string
+						v.Tags = append(v.Tags, zero4)
+					}
+					if !l.IsNull() {
+						v.Tags[i3] = l.String()
+					}
+					l.WantComma()
+				}
+				v.Tags = v.Tags[:i3]
+				l.EndArray()
+			}
+		default:
+			l.Skip()
+		}
+		l.WantComma()
+	}
+	l.EndObject()
+}
+
+var jsonFieldsOfItem = []string{"name", "price", "count", "tags"}
+
+// MarshalJSON implements json.Marshaler.
+func (v User) MarshalJSON() ([]byte, error) {
+	return jsongen.Marshal(&v)
+}
+
+// AppendJSON appends the JSON encoding of v to b, keeping the fields selected by query.
+func (v *User) AppendJSON(b []byte, query *json.FieldQuery) ([]byte, error) {
+	if v == nil {
+		return append(b, "null"...), nil
+	}
+	var err error
+	b = append(b, '{')
+	if _, ok := query.Lookup("id"); ok {
+		b = append(b, "\"id\":"...)
+		b = strconv.AppendInt(b, v.Base.ID, 10)
+		b = append(b, ',')
+	}
+	if v.Base.CreatedBy != "" {
+		if _, ok := query.Lookup("created_by"); ok {
+			b = append(b, "\"created_by\":"...)
+			b = jsongen.AppendString(b, v.Base.CreatedBy)
+			b = append(b, ',')
+		}
+	}
+	if v.Audit != nil {
+		if _, ok := query.Lookup("updated_by"); ok {
+			b = append(b, "\"updated_by\":"...)
+			b = jsongen.AppendString(b, v.Audit.UpdatedBy)
+			b = append(b, ',')
+		}
+	}
+	if v.Audit != nil {
+		if _, ok := query.Lookup("revision"); ok {
+			b = append(b, "\"revision\":"...)
+			b = append(b, '"')
+			b = strconv.AppendUint(b, uint64(v.Audit.Revision), 10)
+			b = append(b, '"')
+			b = append(b, ',')
+		}
+	}
+	if _, ok := query.Lookup("name"); ok {
+		b = append(b, "\"name\":"...)
+		b = jsongen.AppendString(b, v.Name)
+		b = append(b, ',')
+	}
+	if v.Email != "" {
+		if _, ok := query.Lookup("email"); ok {
+			b = append(b, "\"email\":"...)
+			b = jsongen.AppendString(b, v.Email)
+			b = append(b, ',')
+		}
+	}
+	if v.Age != 0 {
+		if _, ok := query.Lookup("age"); ok {
+			b = append(b, "\"age\":"...)
+			b = strconv.AppendInt(b, int64(v.Age), 10)
+			b = append(b, ',')
+		}
+	}
+	if _, ok := query.Lookup("level"); ok {
+		b = append(b, "\"level\":"...)
+		b = strconv.AppendInt(b, int64(v.Level), 10)
+		b = append(b, ',')
+	}
+	if _, ok := query.Lookup("admin"); ok {
+		b = append(b, "\"admin\":"...)
+		b = append(b, '"')
+		b = strconv.AppendBool(b, v.Admin)
+		b = append(b, '"')
+		b = append(b, ',')
+	}
+	if v.Ratio != 0 {
+		if _, ok := query.Lookup("ratio"); ok {
+			b = append(b, "\"ratio\":"...)
+			if b, err = jsongen.AppendFloat64(b, v.Ratio); err != nil {
+				return b, err
+			}
+			b = append(b, ',')
+		}
+	}
+	if _, ok := query.Lookup("nickname"); ok {
+		b = append(b, "\"nickname\":"...)
+		if v.Nickname == nil {
+			b = append(b, "null"...)
+		} else {
+			b = jsongen.AppendString(b, (*v.Nickname))
+		}
+		b = append(b, ',')
+	}
+	if fq, ok := query.Lookup("items"); ok {
+		b = append(b, "\"items\":"...)
+		if v.Items == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+			for i5 := range v.Items {
+				if b, err = v.Items[i5].AppendJSON(b, fq); err != nil {
+					return b, err
+				}
+				b = append(b, ',')
+			}
+			b = jsongen.EndArray(b)
+		}
+		b = append(b, ',')
+	}
+	if v.Primary != nil {
+		if fq, ok := query.Lookup("primary"); ok {
+			b = append(b, "\"primary\":"...)
+			if b, err = v.Primary.AppendJSON(b, fq); err != nil {
+				return b, err
+			}
+			b = append(b, ',')
+		}
+	}
+	if fq, ok := query.Lookup("favorites"); ok {
+		b = append(b, "\"favorites\":"...)
+		b = append(b, '[')
+		for i6 := range v.Favorites {
+			if b, err = v.Favorites[i6].AppendJSON(b, fq); err != nil {
+				return b, err
+			}
+			b = append(b, ',')
+		}
+		b = jsongen.EndArray(b)
+		b = append(b, ',')
+	}
+	if _, ok := query.Lookup("scores"); ok {
+		b = append(b, "\"scores\":"...)
+		if v.Scores == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '{')
+			keys7 := make([]string, 0, len(v.Scores))
+			for k8 := range v.Scores {
+				keys7 = append(keys7, k8)
+			}
+			for _, k8 := range jsongen.SortMapKeys(keys7) {
+				b = jsongen.AppendMapKey(b, k8)
+				mv9 := v.Scores[k8.Key]
+				b = strconv.AppendInt(b, int64(mv9), 10)
+				b = append(b, ',')
+			}
+			b = jsongen.EndObject(b)
+		}
+		b = append(b, ',')
+	}
+	if len(v.Groups) != 0 {
+		if fq, ok := query.Lookup("groups"); ok {
+			b = append(b, "\"groups\":"...)
+			if v.Groups == nil {
+				b = append(b, "null"...)
+			} else {
+				b = append(b, '{')
+				keys10 := make([]string, 0, len(v.Groups))
+				for k11 := range v.Groups {
+					keys10 = append(keys10, k11)
+				}
+				for _, k11 := range jsongen.SortMapKeys(keys10) {
+					b = jsongen.AppendMapKey(b, k11)
+					mv12 := v.Groups[k11.Key]
+					if mv12 == nil {
+						b = append(b, "null"...)
+					} else {
+						b = append(b, '[')
+						for i13 := range mv12 {
+							if b, err = mv12[i13].AppendJSON(b, fq); err != nil {
+								return b, err
+							}
+							b = append(b, ',')
+						}
+						b = jsongen.EndArray(b)
+					}
+					b = append(b, ',')
+				}
+				b = jsongen.EndObject(b)
+			}
+			b = append(b, ',')
+		}
+	}
+	if _, ok := query.Lookup("avatar"); ok {
+		b = append(b, "\"avatar\":"...)
+		b = jsongen.AppendBytes(b, v.Avatar)
+		b = append(b, ',')
+	}
+	if fq, ok := query.Lookup("meta"); ok {
+		b = append(b, "\"meta\":"...)
+		if b, err = jsongen.AppendValue(b, v.Meta, fq); err != nil {
+			return b, err
+		}
+		b = append(b, ',')
+	}
+	if fq, ok := query.Lookup("extra"); ok {
+		b = append(b, "\"extra\":"...)
+		if b, err = jsongen.AppendValue(b, v.Extra, fq); err != nil {
+			return b, err
+		}
+		b = append(b, ',')
+	}
+	if !v.Joined.IsZero() {
+		if fq, ok := query.Lookup("joined"); ok {
+			b = append(b, "\"joined\":"...)
+			if b, err = jsongen.AppendValue(b, v.Joined, fq); err != nil {
+				return b, err
+			}
+			b = append(b, ',')
+		}
+	}
+	if v.Deadline != nil {
+		if fq, ok := query.Lookup("deadline"); ok {
+			b = append(b, "\"deadline\":"...)
+			if b, err = jsongen.AppendValue(b, v.Deadline, fq); err != nil {
+				return b, err
+			}
+			b = append(b, ',')
+		}
+	}
+	if len(v.Raw) != 0 {
+		if fq, ok := query.Lookup("raw"); ok {
+			b = append(b, "\"raw\":"...)
+			if b, err = jsongen.AppendValue(b, v.Raw, fq); err != nil {
+				return b, err
+			}
+			b = append(b, ',')
+		}
+	}
+	if v.Point != (struct {
+		X int
+		Y int
+	}{}) {
+		if fq, ok := query.Lookup("point"); ok {
+			b = append(b, "\"point\":"...)
+			if b, err = jsongen.AppendValue(b, v.Point, fq); err != nil {
+				return b, err
+			}
+			b = append(b, ',')
+		}
+	}
+	if _, ok := query.Lookup("Untagged"); ok {
+		b = append(b, "\"Untagged\":"...)
+		b = jsongen.AppendString(b, v.Untagged)
+		b = append(b, ',')
+	}
+	return jsongen.EndObject(b), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *User) UnmarshalJSON(data []byte) error {
+	l := jsongen.NewLexer(data)
+	v.DecodeJSON(l)
+	l.End()
+	return l.Error()
+}
+
+// DecodeJSON decodes the JSON value read by l into v.
+func (v *User) DecodeJSON(l *jsongen.Lexer) {
+	if l.IsNull() {
+		return
+	}
+	l.BeginObject(v)
+	for l.More() {
+		switch jsongen.FieldIndex(l.Key(), jsonFieldsOfUser) {
+		case 0:
+			if !l.IsNull() {
+				v.Base.ID = l.Int(64)
+			}
+		case 1:
+			if !l.IsNull() {
+				v.Base.CreatedBy = l.String()
+			}
+		case 2:
+			if v.Audit == nil {
+				v.Audit = new(Audit)
+			}
+			if !l.IsNull() {
+				v.Audit.UpdatedBy = l.String()
+			}
+		case 3:
+			if v.Audit == nil {
+				v.Audit = new(Audit)
+			}
+			if !l.IsNull() {
+				q14 := l.Quoted()
+				v.Audit.Revision = uint32(q14.Uint(32))
+				l.EndQuoted(q14)
+			}
+		case 4:
+			if !l.IsNull() {
+				v.Name = l.String()
+			}
+		case 5:
+			if !l.IsNull() {
+				v.Email = l.String()
+			}
+		case 6:
+			if !l.IsNull() {
+				v.Age = int(l.Int(0))
+			}
+		case 7:
+			if !l.IsNull() {
+				v.Level = Level(l.Int(8))
+			}
+		case 8:
+			if !l.IsNull() {
+				q15 := l.Quoted()
+				v.Admin = q15.Bool()
+				l.EndQuoted(q15)
+			}
+		case 9:
+			if !l.IsNull() {
+				v.Ratio = l.Float(64)
+			}
+		case 10:
+			if l.IsNull() {
+				v.Nickname = nil
+			} else {
+				if v.Nickname == nil {
+					v.Nickname = new(string)
+				}
+				if !l.IsNull() {
+					(*v.Nickname) = l.String()
+				}
+			}
+		case 11:
+			if l.IsNull() {
+				v.Items = nil
+			} else {
+				l.BeginArray(&v.Items)
+				if v.Items == nil {
+					v.Items = []Item{}
+				}
+				i16 := 0
+				for ; l.More(); i16++ {
+					if i16 >= len(v.Items) {
+						var zero17 Item
+						v.Items = append(v.Items, zero17)
+					}
+					v.Items[i16].DecodeJSON(l)
+					l.WantComma()
+				}
+				v.Items = v.Items[:i16]
+				l.EndArray()
+			}
+		case 12:
+			if l.IsNull() {
+				v.Primary = nil
+			} else {
+				if v.Primary == nil {
+					v.Primary = new(Item)
+				}
+				(*v.Primary).DecodeJSON(l)
+			}
+		case 13:
+			if !l.IsNull() {
+				l.BeginArray(&v.Favorites)
+				i18 := 0
+				for ; l.More(); i18++ {
+					if i18 < len(v.Favorites) {
+						v.Favorites[i18].DecodeJSON(l)
+					} else {
+						l.Skip()
+					}
+					l.WantComma()
+				}
+				var zero19 Item
+				for ; i18 < len(v.Favorites); i18++ {
+					v.Favorites[i18] = zero19
+				}
+				l.EndArray()
+			}
+		case 14:
+			if l.IsNull() {
+				v.Scores = nil
+			} else {
+				l.BeginObject(&v.Scores)
+				if v.Scores == nil {
+					v.Scores = make(map[string]int)
+				}
+				for l.More() {
+					k20 := string(l.Key())
+					var mv21 int
+					if !l.IsNull() {
+						mv21 = int(l.Int(0))
+					}
+					v.Scores[k20] = mv21
+					l.WantComma()
+				}
+				l.EndObject()
+			}
+		case 15:
+			if l.IsNull() {
+				v.Groups = nil
+			} else {
+				l.BeginObject(&v.Groups)
+				if v.Groups == nil {
+					v.Groups = make(map[string][]*Item)
+				}
+				for l.More() {
+					k22 := string(l.Key())
+					var mv23 []*Item
+					if l.IsNull() {
+						mv23 = nil
+					} else {
+						l.BeginArray(&mv23)
+						if mv23 == nil {
+							mv23 = []*Item{}
+						}
+						i24 := 0
+						for ; l.More(); i24++ {
+							if i24 >= len(mv23) {
+								var zero25 *Item
+								mv23 = append(mv23, zero25)
+							}
+							if l.IsNull() {
+								mv23[i24] = nil
+							} else {
+								if mv23[i24] == nil {
+									mv23[i24] = new(Item)
+								}
+								(*mv23[i24]).DecodeJSON(l)
+							}
+							l.WantComma()
+						}
+						mv23 = mv23[:i24]
+						l.EndArray()
+					}
+					v.Groups[k22] = mv23
+					l.WantComma()
+				}
+				l.EndObject()
+			}
+		case 16:
+			if !l.IsNull() {
+				v.Avatar = l.Bytes()
+			}
+		case 17:
+			l.Unmarshal(&v.Meta)
+		case 18:
+			l.Unmarshal(&v.Extra)
+		case 19:
+			l.Unmarshal(&v.Joined)
+		case 20:
+			if l.IsNull() {
+				v.Deadline = nil
+			} else {
+				if v.Deadline == nil {
+					v.Deadline = new(time.Time)
+				}
+				l.Unmarshal(v.Deadline)
+			}
+		case 21:
+			l.Unmarshal(&v.Raw)
+		case 22:
+			l.Unmarshal(&v.Point)
+		case 23:
+			if !l.IsNull() {
+				v.Untagged = l.String()
+			}
+		default:
+			l.Skip()
+		}
+		l.WantComma()
+	}
+	l.EndObject()
+}
+
+var jsonFieldsOfUser = []string{"id", "created_by", "updated_by", "revision", "name", "email", "age", "level", "admin", "ratio", "nickname", "items", "primary", "favorites", "scores", "groups", "avatar", "meta", "extra", "joined", "deadline", "raw", "point", "Untagged"}
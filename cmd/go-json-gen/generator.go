@@ -0,0 +1,852 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/goccy/go-json"
+)
+
+// generate loads the package in dir and returns the source of the methods for the types named typeNames.
+// The file named outputName is excluded from the package so that the methods generated previously are ignored.
+func generate(dir string, typeNames []string, outputName string) ([]byte, error) {
+	pkg, err := loadPackage(dir, outputName)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		pkg:     pkg,
+		targets: map[*types.Named]bool{},
+		imports: map[string]string{},
+	}
+	namedTypes := make([]*types.Named, 0, len(typeNames))
+	for _, name := range typeNames {
+		named, err := lookupStructType(pkg, strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		g.targets[named] = true
+		namedTypes = append(namedTypes, named)
+	}
+	for _, named := range namedTypes {
+		if err := g.generateType(named); err != nil {
+			return nil, fmt.Errorf("%s: %w", named.Obj().Name(), err)
+		}
+	}
+	return g.source()
+}
+
+func loadPackage(dir, outputName string) (*types.Package, error) {
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	files := make([]*ast.File, 0, len(buildPkg.GoFiles))
+	for _, name := range buildPkg.GoFiles {
+		if name == outputName {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	return conf.Check(buildPkg.Name, fset, files, nil)
+}
+
+func lookupStructType(pkg *types.Package, name string) (*types.Named, error) {
+	typeName, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %s is not found in package %s", name, pkg.Name())
+	}
+	named, ok := typeName.Type().(*types.Named)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a defined type", name)
+	}
+	if _, ok := named.Underlying().(*types.Struct); !ok {
+		return nil, fmt.Errorf("%s is not a structure type", name)
+	}
+	if named.TypeParams().Len() > 0 {
+		return nil, fmt.Errorf("generic type %s is not supported", name)
+	}
+	return named, nil
+}
+
+type generator struct {
+	pkg     *types.Package
+	targets map[*types.Named]bool // the types whose methods are generated
+	imports map[string]string     // the names of the imported packages by their paths
+	out     bytes.Buffer
+	w       *bytes.Buffer // the function body being written
+
+	varID     int
+	usesErr   bool // the function body returns the error of the encoder
+	usesQuery bool // the encoding of the field value takes the field query
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(g.w, format, args...)
+}
+
+func (g *generator) newVar(name string) string {
+	g.varID++
+	return name + strconv.Itoa(g.varID)
+}
+
+func (g *generator) qualifier(pkg *types.Package) string {
+	if pkg == g.pkg {
+		return ""
+	}
+	g.imports[pkg.Path()] = pkg.Name()
+	return pkg.Name()
+}
+
+func (g *generator) typeString(typ types.Type) string {
+	return types.TypeString(typ, g.qualifier)
+}
+
+func (g *generator) source() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by go-json-gen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", g.pkg.Name())
+	g.imports["github.com/goccy/go-json"] = "json"
+	g.imports["github.com/goccy/go-json/jsongen"] = "jsongen"
+	var stdPaths, paths []string
+	for path := range g.imports {
+		if strings.Contains(strings.Split(path, "/")[0], ".") {
+			paths = append(paths, path)
+		} else {
+			stdPaths = append(stdPaths, path)
+		}
+	}
+	sort.Strings(stdPaths)
+	sort.Strings(paths)
+	buf.WriteString("import (\n")
+	for i, group := range [][]string{stdPaths, paths} {
+		if i > 0 && len(stdPaths) > 0 {
+			buf.WriteString("\n")
+		}
+		for _, path := range group {
+			if name := g.imports[path]; name != filepath.Base(path) {
+				fmt.Fprintf(&buf, "%s %q\n", name, path)
+			} else {
+				fmt.Fprintf(&buf, "%q\n", path)
+			}
+		}
+	}
+	buf.WriteString(")\n")
+	buf.Write(g.out.Bytes())
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format the generated code: %w\n%s", err, buf.Bytes())
+	}
+	return src, nil
+}
+
+// field is the structure field encoded as the member of the JSON object.
+type field struct {
+	key       string
+	path      []*types.Var // the fields from the structure to the field through the embedded structures
+	index     []int
+	typ       types.Type
+	tagged    bool
+	omitEmpty bool
+	omitZero  bool
+	asString  bool
+}
+
+func (f *field) expr(recv string) string {
+	names := make([]string, 0, len(f.path)+1)
+	names = append(names, recv)
+	for _, v := range f.path {
+		names = append(names, v.Name())
+	}
+	return strings.Join(names, ".")
+}
+
+// embeddedPointers returns the conditions that the embedded structure pointers on the path to the field are not nil.
+func (f *field) embeddedPointers(recv string) []string {
+	var exprs []string
+	expr := recv
+	for _, v := range f.path[:len(f.path)-1] {
+		expr += "." + v.Name()
+		if _, ok := v.Type().Underlying().(*types.Pointer); ok {
+			exprs = append(exprs, expr+" != nil")
+		}
+	}
+	return exprs
+}
+
+type embeddedStruct struct {
+	typ   types.Type
+	path  []*types.Var
+	index []int
+}
+
+// structFields returns the fields of named encoded by the runtime in the order of the encoding.
+// The fields of the embedded structures are promoted by the same rules as encoding/json.
+func (g *generator) structFields(named *types.Named) ([]*field, error) {
+	var (
+		fields  []*field
+		next    = []embeddedStruct{{typ: named}}
+		visited = map[types.Type]bool{}
+	)
+	for len(next) > 0 {
+		current := next
+		next = nil
+		for _, embedded := range current {
+			if visited[embedded.typ] {
+				continue
+			}
+			visited[embedded.typ] = true
+			st := embedded.typ.Underlying().(*types.Struct)
+			for i := 0; i < st.NumFields(); i++ {
+				sf := st.Field(i)
+				if sf.Embedded() {
+					t := sf.Type()
+					if ptr, ok := t.Underlying().(*types.Pointer); ok {
+						t = ptr.Elem()
+					}
+					if _, isStruct := t.Underlying().(*types.Struct); !sf.Exported() && !isStruct {
+						continue
+					}
+				} else if !sf.Exported() {
+					continue
+				}
+				tag := reflect.StructTag(st.Tag(i)).Get("json")
+				if tag == "-" {
+					continue
+				}
+				if !sf.Exported() && sf.Pkg() != g.pkg {
+					return nil, fmt.Errorf("embedded field %s of package %s cannot be accessed", sf.Name(), sf.Pkg().Path())
+				}
+				opts := strings.Split(tag, ",")
+				name := opts[0]
+				if !isValidTag(name) {
+					name = ""
+				}
+				path := append(append([]*types.Var{}, embedded.path...), sf)
+				index := append(append([]int{}, embedded.index...), i)
+				ft := types.Unalias(sf.Type())
+				if ptr, ok := ft.(*types.Pointer); ok {
+					ft = ptr.Elem()
+				}
+				if _, isStruct := ft.Underlying().(*types.Struct); name == "" && sf.Embedded() && isStruct {
+					if hasMarshalerMethod(ft) || hasUnmarshalerMethod(ft) {
+						return nil, fmt.Errorf("embedded field %s implements the marshaler interface and is promoted to the structure", sf.Name())
+					}
+					next = append(next, embeddedStruct{typ: ft, path: path, index: index})
+					continue
+				}
+				f := &field{
+					key:    name,
+					path:   path,
+					index:  index,
+					typ:    sf.Type(),
+					tagged: name != "",
+				}
+				if f.key == "" {
+					f.key = sf.Name()
+				}
+				for _, opt := range opts[1:] {
+					switch {
+					case opt == "omitempty":
+						f.omitEmpty = true
+					case opt == "omitzero":
+						f.omitZero = true
+					case opt == "string":
+						f.asString = isQuotableType(ft)
+					case opt == "inline", opt == "unknown", opt == "nonnil", opt == "redact", strings.HasPrefix(opt, "format:"):
+						return nil, fmt.Errorf("tag option %q of field %s is not supported", opt, sf.Name())
+					}
+				}
+				fields = append(fields, f)
+			}
+		}
+	}
+	return dominantFields(fields), nil
+}
+
+// dominantFields removes the fields hidden by the other fields of the same key, and sorts them in the order of the index.
+func dominantFields(fields []*field) []*field {
+	sort.Slice(fields, func(i, j int) bool {
+		x, y := fields[i], fields[j]
+		if x.key != y.key {
+			return x.key < y.key
+		}
+		if len(x.index) != len(y.index) {
+			return len(x.index) < len(y.index)
+		}
+		if x.tagged != y.tagged {
+			return x.tagged
+		}
+		return lessIndex(x.index, y.index)
+	})
+	dominant := fields[:0]
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].key == fields[i].key {
+			j++
+		}
+		group := fields[i:j]
+		if len(group) == 1 || len(group[0].index) < len(group[1].index) || group[0].tagged != group[1].tagged {
+			dominant = append(dominant, group[0])
+		}
+		i = j
+	}
+	sort.Slice(dominant, func(i, j int) bool {
+		return lessIndex(dominant[i].index, dominant[j].index)
+	})
+	return dominant
+}
+
+func lessIndex(x, y []int) bool {
+	for k := 0; k < len(x) && k < len(y); k++ {
+		if x[k] != y[k] {
+			return x[k] < y[k]
+		}
+	}
+	return len(x) < len(y)
+}
+
+func isValidTag(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, c := range s {
+		switch {
+		case strings.ContainsRune("!#$%&()*+-./:<=>?@[]^_{|}~ ", c):
+			// Backslash and quote chars are reserved, but
+			// otherwise any punctuation chars are allowed
+			// in a tag name.
+		case !unicode.IsLetter(c) && !unicode.IsDigit(c):
+			return false
+		}
+	}
+	return true
+}
+
+// isQuotableType reports whether the value of typ is encoded as a JSON string by the string option of the struct tag.
+func isQuotableType(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Info()&(types.IsBoolean|types.IsInteger|types.IsFloat|types.IsString) != 0
+}
+
+func hasMethod(typ types.Type, names ...string) bool {
+	methods := types.NewMethodSet(typ)
+	for _, name := range names {
+		for i := 0; i < methods.Len(); i++ {
+			if methods.At(i).Obj().Name() == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func hasMarshalerMethod(typ types.Type) bool {
+	return hasMethod(typ, "MarshalJSON", "MarshalText") || hasMethod(types.NewPointer(typ), "MarshalJSON", "MarshalText")
+}
+
+func hasUnmarshalerMethod(typ types.Type) bool {
+	return hasMethod(types.NewPointer(typ), "UnmarshalJSON", "UnmarshalText")
+}
+
+func (g *generator) generateType(named *types.Named) error {
+	fields, err := g.structFields(named)
+	if err != nil {
+		return err
+	}
+	name := named.Obj().Name()
+	fieldsVar := "jsonFieldsOf" + strings.ToUpper(name[:1]) + name[1:]
+	if err := g.generateEncoder(name, fields); err != nil {
+		return err
+	}
+	if err := g.generateDecoder(name, fieldsVar, fields); err != nil {
+		return err
+	}
+	fmt.Fprintf(&g.out, "\nvar %s = []string{", fieldsVar)
+	for i, f := range fields {
+		if i > 0 {
+			g.out.WriteString(", ")
+		}
+		g.out.WriteString(strconv.Quote(f.key))
+	}
+	g.out.WriteString("}\n")
+	return nil
+}
+
+func (g *generator) generateEncoder(name string, fields []*field) error {
+	g.w = &bytes.Buffer{}
+	g.usesErr = false
+	for _, f := range fields {
+		if err := g.encodeField(f); err != nil {
+			return err
+		}
+	}
+	fmt.Fprintf(&g.out, `
+// MarshalJSON implements json.Marshaler.
+func (v %[1]s) MarshalJSON() ([]byte, error) {
+	return jsongen.Marshal(&v)
+}
+
+// AppendJSON appends the JSON encoding of v to b, keeping the fields selected by query.
+func (v *%[1]s) AppendJSON(b []byte, query *json.FieldQuery) ([]byte, error) {
+	if v == nil {
+		return append(b, "null"...), nil
+	}
+`, name)
+	if g.usesErr {
+		g.out.WriteString("var err error\n")
+	}
+	g.out.WriteString("b = append(b, '{')\n")
+	g.out.Write(g.w.Bytes())
+	g.out.WriteString("return jsongen.EndObject(b), nil\n}\n")
+	return nil
+}
+
+func (g *generator) encodeField(f *field) error {
+	expr := f.expr("v")
+	conds := f.embeddedPointers("v")
+	if f.omitEmpty {
+		if cond, ok := g.nonEmptyCond(expr, f.typ); ok {
+			conds = append(conds, cond)
+		}
+	}
+	if f.omitZero {
+		conds = append(conds, g.nonZeroCond(expr, f.typ))
+	}
+	for _, cond := range conds {
+		if cond == "false" {
+			// the field is always omitted.
+			return nil
+		}
+	}
+	body := g.w
+	g.w = &bytes.Buffer{}
+	g.usesQuery = false
+	key, err := json.Marshal(f.key)
+	if err != nil {
+		return err
+	}
+	g.printf("b = append(b, %s...)\n", strconv.Quote(string(key)+":"))
+	if err := g.encodeValue(expr, f.typ, "fq", f.asString); err != nil {
+		return err
+	}
+	g.printf("b = append(b, ',')\n")
+	value := g.w
+	g.w = body
+	if len(conds) > 0 {
+		g.printf("if %s {\n", strings.Join(conds, " && "))
+	}
+	if g.usesQuery {
+		g.printf("if fq, ok := query.Lookup(%q); ok {\n", f.key)
+	} else {
+		g.printf("if _, ok := query.Lookup(%q); ok {\n", f.key)
+	}
+	g.w.Write(value.Bytes())
+	g.printf("}\n")
+	if len(conds) > 0 {
+		g.printf("}\n")
+	}
+	return nil
+}
+
+// nonEmptyCond returns the condition that expr is not empty for the omitempty option.
+// It reports false if the value of typ is never empty.
+func (g *generator) nonEmptyCond(expr string, typ types.Type) (string, bool) {
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return expr, true
+		case u.Info()&types.IsString != 0:
+			return expr + ` != ""`, true
+		case u.Info()&types.IsNumeric != 0:
+			return expr + " != 0", true
+		}
+	case *types.Pointer, *types.Interface:
+		return expr + " != nil", true
+	case *types.Slice, *types.Map:
+		return "len(" + expr + ") != 0", true
+	case *types.Array:
+		if u.Len() == 0 {
+			return "false", true
+		}
+	}
+	return "", false
+}
+
+// nonZeroCond returns the condition that expr is not the zero value for the omitzero option.
+func (g *generator) nonZeroCond(expr string, typ types.Type) string {
+	if _, isPtr := typ.Underlying().(*types.Pointer); isPtr && hasMethod(typ, "IsZero") {
+		return fmt.Sprintf("%[1]s != nil && !%[1]s.IsZero()", expr)
+	}
+	if hasMethod(types.NewPointer(typ), "IsZero") {
+		return "!" + expr + ".IsZero()"
+	}
+	if !types.Comparable(typ) {
+		return "!jsongen.IsZero(" + expr + ")"
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsBoolean != 0:
+			return expr
+		case u.Info()&types.IsString != 0:
+			return expr + ` != ""`
+		}
+		return expr + " != 0"
+	case *types.Struct, *types.Array:
+		return fmt.Sprintf("%s != (%s{})", expr, g.typeString(typ))
+	}
+	return expr + " != nil"
+}
+
+// encodeValue writes the statements that append the encoding of expr to b.
+// expr is addressable, and query is the name of the field query for the value.
+func (g *generator) encodeValue(expr string, typ types.Type, query string, asString bool) error {
+	if named, ok := types.Unalias(typ).(*types.Named); ok && g.targets[named] {
+		g.appendJSON(expr, query)
+		return nil
+	}
+	if ptr, ok := typ.Underlying().(*types.Pointer); ok {
+		if named, ok := types.Unalias(ptr.Elem()).(*types.Named); ok && g.targets[named] {
+			g.appendJSON(expr, query)
+			return nil
+		}
+	}
+	if hasMethod(typ, "MarshalJSON", "MarshalText") {
+		g.appendValue(expr, query)
+		return nil
+	}
+	if hasMethod(types.NewPointer(typ), "MarshalJSON", "MarshalText") {
+		g.appendValue("&"+expr, query)
+		return nil
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		return g.encodeBasic(expr, typ, u, asString)
+	case *types.Pointer:
+		g.printf("if %s == nil {\nb = append(b, \"null\"...)\n} else {\n", expr)
+		if err := g.encodeValue("(*"+expr+")", u.Elem(), query, asString); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case *types.Slice:
+		if elem, ok := u.Elem().Underlying().(*types.Basic); ok && elem.Kind() == types.Uint8 && !hasMarshalerMethod(u.Elem()) {
+			if _, named := types.Unalias(u.Elem()).(*types.Named); named {
+				g.appendValue(expr, query)
+				return nil
+			}
+			g.printf("b = jsongen.AppendBytes(b, %s)\n", g.convert(expr, typ, types.NewSlice(types.Typ[types.Byte])))
+			return nil
+		}
+		g.printf("if %s == nil {\nb = append(b, \"null\"...)\n} else {\n", expr)
+		if err := g.encodeElems(expr, u.Elem(), query); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case *types.Array:
+		if u.Len() == 0 {
+			g.printf("b = append(b, \"[]\"...)\n")
+			return nil
+		}
+		return g.encodeElems(expr, u.Elem(), query)
+	case *types.Map:
+		if !isStringKeyType(u.Key()) {
+			g.appendValue(expr, query)
+			return nil
+		}
+		keys, key, value := g.newVar("keys"), g.newVar("k"), g.newVar("mv")
+		g.printf("if %s == nil {\nb = append(b, \"null\"...)\n} else {\n", expr)
+		g.printf("b = append(b, '{')\n")
+		g.printf("%s := make([]string, 0, len(%s))\n", keys, expr)
+		g.printf("for %s := range %s {\n%s = append(%s, %s)\n}\n", key, expr, keys, keys, g.convert(key, u.Key(), types.Typ[types.String]))
+		g.printf("for _, %s := range jsongen.SortMapKeys(%s) {\n", key, keys)
+		g.printf("b = jsongen.AppendMapKey(b, %s)\n", key)
+		g.printf("%s := %s[%s]\n", value, expr, g.convert(key+".Key", types.Typ[types.String], u.Key()))
+		if err := g.encodeValue(value, u.Elem(), query, false); err != nil {
+			return err
+		}
+		g.printf("b = append(b, ',')\n}\n")
+		g.printf("b = jsongen.EndObject(b)\n}\n")
+	case *types.Struct, *types.Interface:
+		g.appendValue(expr, query)
+	default:
+		return fmt.Errorf("unsupported type %s", typ)
+	}
+	return nil
+}
+
+func (g *generator) encodeElems(expr string, elem types.Type, query string) error {
+	i := g.newVar("i")
+	g.printf("b = append(b, '[')\n")
+	g.printf("for %s := range %s {\n", i, expr)
+	if err := g.encodeValue(expr+"["+i+"]", elem, query, false); err != nil {
+		return err
+	}
+	g.printf("b = append(b, ',')\n}\n")
+	g.printf("b = jsongen.EndArray(b)\n")
+	return nil
+}
+
+func (g *generator) encodeBasic(expr string, typ types.Type, basic *types.Basic, asString bool) error {
+	if asString && basic.Info()&types.IsString == 0 {
+		g.printf("b = append(b, '\"')\n")
+		defer g.printf("b = append(b, '\"')\n")
+	}
+	switch {
+	case basic.Info()&types.IsBoolean != 0:
+		g.printf("b = strconv.AppendBool(b, %s)\n", g.convert(expr, typ, types.Typ[types.Bool]))
+	case basic.Info()&types.IsUnsigned != 0:
+		g.printf("b = strconv.AppendUint(b, %s, 10)\n", g.convert(expr, typ, types.Typ[types.Uint64]))
+	case basic.Info()&types.IsInteger != 0:
+		g.printf("b = strconv.AppendInt(b, %s, 10)\n", g.convert(expr, typ, types.Typ[types.Int64]))
+	case basic.Kind() == types.Float32:
+		g.usesErr = true
+		g.printf("if b, err = jsongen.AppendFloat32(b, %s); err != nil {\nreturn b, err\n}\n", g.convert(expr, typ, types.Typ[types.Float32]))
+	case basic.Kind() == types.Float64:
+		g.usesErr = true
+		g.printf("if b, err = jsongen.AppendFloat64(b, %s); err != nil {\nreturn b, err\n}\n", g.convert(expr, typ, types.Typ[types.Float64]))
+	case basic.Info()&types.IsString != 0:
+		if asString {
+			g.printf("b = jsongen.AppendQuotedString(b, %s)\n", g.convert(expr, typ, types.Typ[types.String]))
+		} else {
+			g.printf("b = jsongen.AppendString(b, %s)\n", g.convert(expr, typ, types.Typ[types.String]))
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported type %s", typ)
+	}
+	if basic.Info()&(types.IsBoolean|types.IsInteger) != 0 {
+		g.imports["strconv"] = "strconv"
+	}
+	return nil
+}
+
+func (g *generator) appendJSON(expr, query string) {
+	g.usesErr = true
+	g.usesQuery = true
+	g.printf("if b, err = %s.AppendJSON(b, %s); err != nil {\nreturn b, err\n}\n", expr, query)
+}
+
+func (g *generator) appendValue(expr, query string) {
+	g.usesErr = true
+	g.usesQuery = true
+	g.printf("if b, err = jsongen.AppendValue(b, %s, %s); err != nil {\nreturn b, err\n}\n", expr, query)
+}
+
+// convert returns the conversion of expr of typ to the type to if they are not identical.
+func (g *generator) convert(expr string, typ, to types.Type) string {
+	if types.Identical(typ, to) {
+		return expr
+	}
+	return g.typeString(to) + "(" + expr + ")"
+}
+
+func isStringKeyType(typ types.Type) bool {
+	basic, ok := typ.Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.String && !hasMarshalerMethod(typ) && !hasUnmarshalerMethod(typ)
+}
+
+func (g *generator) generateDecoder(name, fieldsVar string, fields []*field) error {
+	fmt.Fprintf(&g.out, `
+// UnmarshalJSON implements json.Unmarshaler.
+func (v *%[1]s) UnmarshalJSON(data []byte) error {
+	l := jsongen.NewLexer(data)
+	v.DecodeJSON(l)
+	l.End()
+	return l.Error()
+}
+
+// DecodeJSON decodes the JSON value read by l into v.
+func (v *%[1]s) DecodeJSON(l *jsongen.Lexer) {
+	if l.IsNull() {
+		return
+	}
+	l.BeginObject(v)
+	for l.More() {
+		switch jsongen.FieldIndex(l.Key(), %[2]s) {
+`, name, fieldsVar)
+	g.w = &g.out
+	for i, f := range fields {
+		g.printf("case %d:\n", i)
+		expr := "v"
+		for _, v := range f.path[:len(f.path)-1] {
+			expr += "." + v.Name()
+			if ptr, ok := v.Type().Underlying().(*types.Pointer); ok {
+				g.printf("if %s == nil {\n%s = new(%s)\n}\n", expr, expr, g.typeString(ptr.Elem()))
+			}
+		}
+		if err := g.decodeValue(f.expr("v"), f.typ, f.asString); err != nil {
+			return err
+		}
+	}
+	g.out.WriteString(`default:
+			l.Skip()
+		}
+		l.WantComma()
+	}
+	l.EndObject()
+}
+`)
+	return nil
+}
+
+// addr returns the address of expr, which is addressable.
+func addr(expr string) string {
+	if strings.HasPrefix(expr, "(*") && strings.HasSuffix(expr, ")") {
+		return expr[2 : len(expr)-1]
+	}
+	return "&" + expr
+}
+
+// decodeValue writes the statements that decode the value read by l into expr, which is addressable.
+func (g *generator) decodeValue(expr string, typ types.Type, asString bool) error {
+	if named, ok := types.Unalias(typ).(*types.Named); ok && g.targets[named] {
+		g.printf("%s.DecodeJSON(l)\n", expr)
+		return nil
+	}
+	if _, isPtr := typ.Underlying().(*types.Pointer); !isPtr && hasUnmarshalerMethod(typ) {
+		g.printf("l.Unmarshal(%s)\n", addr(expr))
+		return nil
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		return g.decodeBasic(expr, typ, u, asString)
+	case *types.Pointer:
+		g.printf("if l.IsNull() {\n%s = nil\n} else {\n", expr)
+		g.printf("if %s == nil {\n%s = new(%s)\n}\n", expr, expr, g.typeString(u.Elem()))
+		if err := g.decodeValue("(*"+expr+")", u.Elem(), asString); err != nil {
+			return err
+		}
+		g.printf("}\n")
+	case *types.Slice:
+		if elem, ok := u.Elem().Underlying().(*types.Basic); ok && elem.Kind() == types.Uint8 && !hasUnmarshalerMethod(u.Elem()) {
+			if _, named := types.Unalias(u.Elem()).(*types.Named); named {
+				g.printf("l.Unmarshal(%s)\n", addr(expr))
+				return nil
+			}
+			// as well as the runtime decoder, null keeps the byte slice.
+			g.printf("if !l.IsNull() {\n")
+			g.printf("%s = %s\n}\n", expr, g.convert("l.Bytes()", types.NewSlice(types.Typ[types.Byte]), typ))
+			return nil
+		}
+		// as well as the runtime decoder, the existing elements are reused and the appended elements are zeroed.
+		i, zero := g.newVar("i"), g.newVar("zero")
+		g.printf("if l.IsNull() {\n%s = nil\n} else {\n", expr)
+		g.printf("l.BeginArray(%s)\n", addr(expr))
+		g.printf("if %s == nil {\n%s = %s{}\n}\n", expr, expr, g.typeString(typ))
+		g.printf("%s := 0\n", i)
+		g.printf("for ; l.More(); %s++ {\n", i)
+		g.printf("if %s >= len(%s) {\nvar %s %s\n%s = append(%s, %s)\n}\n", i, expr, zero, g.typeString(u.Elem()), expr, expr, zero)
+		if err := g.decodeValue(expr+"["+i+"]", u.Elem(), false); err != nil {
+			return err
+		}
+		g.printf("l.WantComma()\n}\n")
+		g.printf("%s = %s[:%s]\n", expr, expr, i)
+		g.printf("l.EndArray()\n}\n")
+	case *types.Array:
+		i, zero := g.newVar("i"), g.newVar("zero")
+		g.printf("if !l.IsNull() {\n")
+		g.printf("l.BeginArray(%s)\n", addr(expr))
+		g.printf("%s := 0\n", i)
+		g.printf("for ; l.More(); %s++ {\n", i)
+		g.printf("if %s < len(%s) {\n", i, expr)
+		if err := g.decodeValue(expr+"["+i+"]", u.Elem(), false); err != nil {
+			return err
+		}
+		g.printf("} else {\nl.Skip()\n}\n")
+		g.printf("l.WantComma()\n}\n")
+		g.printf("var %s %s\n", zero, g.typeString(u.Elem()))
+		g.printf("for ; %s < len(%s); %s++ {\n%s[%s] = %s\n}\n", i, expr, i, expr, i, zero)
+		g.printf("l.EndArray()\n}\n")
+	case *types.Map:
+		if !isStringKeyType(u.Key()) {
+			g.printf("l.Unmarshal(%s)\n", addr(expr))
+			return nil
+		}
+		key, value := g.newVar("k"), g.newVar("mv")
+		g.printf("if l.IsNull() {\n%s = nil\n} else {\n", expr)
+		g.printf("l.BeginObject(%s)\n", addr(expr))
+		g.printf("if %s == nil {\n%s = make(%s)\n}\n", expr, expr, g.typeString(typ))
+		g.printf("for l.More() {\n")
+		g.printf("%s := %s\n", key, g.convert("string(l.Key())", types.Typ[types.String], u.Key()))
+		g.printf("var %s %s\n", value, g.typeString(u.Elem()))
+		if err := g.decodeValue(value, u.Elem(), false); err != nil {
+			return err
+		}
+		g.printf("%s[%s] = %s\n", expr, key, value)
+		g.printf("l.WantComma()\n}\n")
+		g.printf("l.EndObject()\n}\n")
+	case *types.Struct, *types.Interface:
+		g.printf("l.Unmarshal(%s)\n", addr(expr))
+	default:
+		return fmt.Errorf("unsupported type %s", typ)
+	}
+	return nil
+}
+
+func (g *generator) decodeBasic(expr string, typ types.Type, basic *types.Basic, asString bool) error {
+	var value string
+	lexer := "l"
+	if asString {
+		lexer = g.newVar("q")
+	}
+	switch {
+	case basic.Info()&types.IsBoolean != 0:
+		value = g.convert(lexer+".Bool()", types.Typ[types.Bool], typ)
+	case basic.Info()&types.IsString != 0:
+		value = g.convert(lexer+".String()", types.Typ[types.String], typ)
+	case basic.Info()&types.IsUnsigned != 0:
+		value = g.convert(fmt.Sprintf("%s.Uint(%d)", lexer, bitSize(basic)), types.Typ[types.Uint64], typ)
+	case basic.Info()&types.IsInteger != 0:
+		value = g.convert(fmt.Sprintf("%s.Int(%d)", lexer, bitSize(basic)), types.Typ[types.Int64], typ)
+	case basic.Info()&types.IsFloat != 0 && basic.Info()&types.IsComplex == 0:
+		value = g.convert(fmt.Sprintf("%s.Float(%d)", lexer, bitSize(basic)), types.Typ[types.Float64], typ)
+	default:
+		return fmt.Errorf("unsupported type %s", typ)
+	}
+	g.printf("if !l.IsNull() {\n")
+	if asString {
+		g.printf("%s := l.Quoted()\n", lexer)
+		g.printf("%s = %s\n", expr, value)
+		g.printf("l.EndQuoted(%s)\n", lexer)
+	} else {
+		g.printf("%s = %s\n", expr, value)
+	}
+	g.printf("}\n")
+	return nil
+}
+
+// bitSize returns the size of the integer or floating-point type, or 0 for the types whose size depends on the platform.
+func bitSize(basic *types.Basic) int {
+	switch basic.Kind() {
+	case types.Int8, types.Uint8:
+		return 8
+	case types.Int16, types.Uint16:
+		return 16
+	case types.Int32, types.Uint32, types.Float32:
+		return 32
+	case types.Int64, types.Uint64, types.Float64:
+		return 64
+	}
+	return 0
+}
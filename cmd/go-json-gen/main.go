@@ -0,0 +1,65 @@
+// go-json-gen generates the methods that encode and decode the structure types without reflection.
+//
+// Usage:
+//
+//	go-json-gen -type User,Item [-output file] [directory]
+//
+// For each type, it generates the following methods in the package of the directory.
+//
+//	func (v T) MarshalJSON() ([]byte, error)
+//	func (v *T) AppendJSON(b []byte, query *json.FieldQuery) ([]byte, error)
+//	func (v *T) UnmarshalJSON(data []byte) error
+//	func (v *T) DecodeJSON(l *jsongen.Lexer)
+//
+// The generated code produces the same output as Marshal of go-json for the type without the methods,
+// including the omitempty, omitzero and string options of the struct tag.
+// AppendJSON keeps only the fields selected by the FieldQuery in the same way as MarshalContext.
+// The values whose encoding cannot be generated, such as interfaces and the types implementing json.Marshaler,
+// are encoded and decoded by the runtime of go-json.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		typeNames = flag.String("type", "", "comma-separated list of the structure type names; must be set")
+		output    = flag.String("output", "", "output file name; default <directory>/gojson_gen.go")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: go-json-gen -type T1,T2 [-output file] [directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *typeNames == "" || flag.NArg() > 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+	dir := "."
+	if flag.NArg() == 1 {
+		dir = flag.Arg(0)
+	}
+	outputPath := *output
+	if outputPath == "" {
+		outputPath = filepath.Join(dir, defaultOutputName)
+	}
+	if err := run(dir, strings.Split(*typeNames, ","), outputPath); err != nil {
+		fmt.Fprintf(os.Stderr, "go-json-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+const defaultOutputName = "gojson_gen.go"
+
+func run(dir string, typeNames []string, outputPath string) error {
+	src, err := generate(dir, typeNames, filepath.Base(outputPath))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outputPath, src, 0o644)
+}
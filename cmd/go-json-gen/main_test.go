@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	got, err := generate("fixture", []string{"Item", "User"}, defaultOutputName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := os.ReadFile(filepath.Join("fixture", defaultOutputName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(expected) != string(got) {
+		t.Fatalf("%s is not up to date. run go generate ./cmd/go-json-gen/fixture", defaultOutputName)
+	}
+}
+
+func TestGenerateError(t *testing.T) {
+	dir := filepath.Join("testdata", "unsupported")
+	for _, test := range []struct {
+		typeName string
+		err      string
+	}{
+		{typeName: "Unknown", err: "type Unknown is not found"},
+		{typeName: "Level", err: "Level is not a structure type"},
+		{typeName: "Inline", err: `tag option "inline" of field Extra is not supported`},
+		{typeName: "Format", err: `tag option "format:unix" of field CreatedAt is not supported`},
+		{typeName: "Redact", err: `tag option "redact" of field SSN is not supported`},
+		{typeName: "Complex", err: "unsupported type complex128"},
+		{typeName: "Channel", err: "unsupported type chan int"},
+		{typeName: "Embedded", err: "embedded field Time implements the marshaler interface"},
+	} {
+		t.Run(test.typeName, func(t *testing.T) {
+			_, err := generate(dir, []string{test.typeName}, defaultOutputName)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if !strings.Contains(err.Error(), test.err) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package unsupported
+
+import "time"
+
+type Level int
+
+type Inline struct {
+	Extra map[string]interface{} `json:",inline"`
+}
+
+type Format struct {
+	CreatedAt time.Time `json:"created_at,format:unix"`
+}
+
+type Redact struct {
+	SSN string `json:"ssn,redact"`
+}
+
+type Complex struct {
+	Value complex128 `json:"value"`
+}
+
+type Channel struct {
+	Values chan int `json:"values"`
+}
+
+type Embedded struct {
+	time.Time
+}
@@ -1,6 +1,7 @@
 package json
 
 import (
+	"bytes"
 	"fmt"
 
 	"github.com/goccy/go-json/internal/encoder"
@@ -15,6 +16,14 @@ const escape = "\x1b"
 
 type colorAttr int
 
+//nolint:deadcode,varcheck
+const (
+	boldAttr colorAttr = iota + 1
+	faintAttr
+	italicAttr
+	underlineAttr
+)
+
 //nolint:deadcode,varcheck
 const (
 	fgBlackColor colorAttr = iota + 30
@@ -54,6 +63,23 @@ func resetColor() string {
 	return wrapColor(colorAttr(0))
 }
 
+// NewColorFormat256 creates the format of the foreground color in the 256-color palette of the terminal.
+// 0-15 are the basic and high intensity colors, 16-231 are the 6x6x6 color cube and 232-255 are the grayscale.
+func NewColorFormat256(code uint8) ColorFormat {
+	return ColorFormat{
+		Header: fmt.Sprintf("%s[38;5;%dm", escape, code),
+		Footer: resetColor(),
+	}
+}
+
+// NewTrueColorFormat creates the format of the 24-bit foreground color for the terminals supporting truecolor.
+func NewTrueColorFormat(r, g, b uint8) ColorFormat {
+	return ColorFormat{
+		Header: fmt.Sprintf("%s[38;2;%d;%d;%dm", escape, r, g, b),
+		Footer: resetColor(),
+	}
+}
+
 var (
 	DefaultColorScheme = &ColorScheme{
 		Int:       createColorFormat(fgHiMagentaColor),
@@ -65,4 +91,69 @@ var (
 		ObjectKey: createColorFormat(fgHiCyanColor),
 		Null:      createColorFormat(fgBlueColor),
 	}
+
+	// DarkColorScheme is the 256-color scheme for the terminals with a dark background.
+	DarkColorScheme = &ColorScheme{
+		Int:       NewColorFormat256(215),
+		Uint:      NewColorFormat256(215),
+		Float:     NewColorFormat256(215),
+		Bool:      NewColorFormat256(141),
+		String:    NewColorFormat256(114),
+		Binary:    NewColorFormat256(168),
+		ObjectKey: NewColorFormat256(81),
+		Null:      NewColorFormat256(244),
+		Brace:     NewColorFormat256(252),
+		Bracket:   NewColorFormat256(252),
+		Colon:     NewColorFormat256(244),
+		Comma:     NewColorFormat256(244),
+	}
+
+	// LightColorScheme is the 256-color scheme for the terminals with a light background.
+	LightColorScheme = &ColorScheme{
+		Int:       NewColorFormat256(130),
+		Uint:      NewColorFormat256(130),
+		Float:     NewColorFormat256(130),
+		Bool:      NewColorFormat256(91),
+		String:    NewColorFormat256(28),
+		Binary:    NewColorFormat256(125),
+		ObjectKey: NewColorFormat256(25),
+		Null:      NewColorFormat256(245),
+		Brace:     NewColorFormat256(238),
+		Bracket:   NewColorFormat256(238),
+		Colon:     NewColorFormat256(245),
+		Comma:     NewColorFormat256(245),
+	}
+
+	// MonochromeColorScheme decorates the output only with the text attributes like bold,
+	// for the terminals and the logs where colors are not available or wanted.
+	MonochromeColorScheme = &ColorScheme{
+		Binary:    createColorFormat(underlineAttr),
+		ObjectKey: createColorFormat(boldAttr),
+		Null:      createColorFormat(italicAttr),
+		Brace:     createColorFormat(faintAttr),
+		Bracket:   createColorFormat(faintAttr),
+		Colon:     createColorFormat(faintAttr),
+		Comma:     createColorFormat(faintAttr),
+	}
 )
+
+// IndentColor appends to dst the indented form of the JSON-encoded src in the same way as Indent,
+// coloring it by scheme in the same way as MarshalIndentWithOption with Colorize.
+// Since the Go types of the values are unknown, the integers are colored by the Int format,
+// the other numbers by the Float format and all the strings by the String format.
+// If scheme is nil, DefaultColorScheme is used.
+func IndentColor(dst *bytes.Buffer, src []byte, prefix, indent string, scheme *ColorScheme) error {
+	if scheme == nil {
+		scheme = DefaultColorScheme
+	}
+	return encoder.IndentColor(dst, src, prefix, indent, scheme)
+}
+
+// CompactColor appends to dst the JSON-encoded src with insignificant space characters elided in the same way as Compact,
+// coloring it by scheme in the same way as IndentColor.
+func CompactColor(dst *bytes.Buffer, src []byte, scheme *ColorScheme) error {
+	if scheme == nil {
+		scheme = DefaultColorScheme
+	}
+	return encoder.CompactColor(dst, src, scheme)
+}
@@ -1,6 +1,10 @@
 package json_test
 
 import (
+	"bytes"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/goccy/go-json"
@@ -47,3 +51,259 @@ func TestColorize(t *testing.T) {
 		t.Log("\n" + string(b))
 	})
 }
+
+type colorNode struct {
+	Name     string                 `json:"name"`
+	Children []*colorNode           `json:"children,omitempty"`
+	Attrs    map[string]interface{} `json:",inline"`
+	Empty    struct{}               `json:"empty"`
+}
+
+type colorBase struct {
+	Name  string                 `json:"name"`
+	Attrs map[string]interface{} `json:",inline"`
+}
+
+type colorEmbedded struct {
+	colorBase
+	Node  *colorNode      `json:"node"`
+	ID    uint64          `json:"id,string"`
+	Ptr   *int            `json:"ptr"`
+	Items [2]interface{}  `json:"items"`
+	Map   map[int]float64 `json:"map"`
+	Last  int             `json:"last,omitempty"`
+}
+
+var ansiEscapePattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// assertColorizedDelimiters checks that the punctuation of the JSON is decorated by the format of the scheme.
+func assertColorizedDelimiters(t *testing.T, scheme *json.ColorScheme, s string) {
+	t.Helper()
+	expected := map[byte]string{
+		'{': scheme.Brace.Header,
+		'}': scheme.Brace.Header,
+		'[': scheme.Bracket.Header,
+		']': scheme.Bracket.Header,
+		':': scheme.Colon.Header,
+		',': scheme.Comma.Header,
+	}
+	var (
+		attr     string
+		inString bool
+	)
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '\x1b':
+			seq := ansiEscapePattern.FindString(s[i:])
+			attr = seq
+			i += len(seq) - 1
+		case inString:
+			if c == '\\' {
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		default:
+			if header, exists := expected[c]; exists && attr != header {
+				t.Fatalf("%q at %d is not colored by %q: %q", c, i, header, s)
+			}
+		}
+	}
+}
+
+func TestColorizeDelimiters(t *testing.T) {
+	scheme := &json.ColorScheme{
+		Int:       json.NewColorFormat256(1),
+		Uint:      json.NewColorFormat256(2),
+		Float:     json.NewColorFormat256(3),
+		Bool:      json.NewColorFormat256(4),
+		String:    json.NewTrueColorFormat(0, 128, 255),
+		Binary:    json.NewColorFormat256(5),
+		ObjectKey: json.NewColorFormat256(6),
+		Null:      json.NewColorFormat256(7),
+		Brace:     json.NewColorFormat256(8),
+		Bracket:   json.NewColorFormat256(9),
+		Colon:     json.NewColorFormat256(10),
+		Comma:     json.NewColorFormat256(11),
+	}
+	n := 10
+	values := map[string]interface{}{
+		"struct": &colorEmbedded{
+			colorBase: colorBase{
+				Name:  "base",
+				Attrs: map[string]interface{}{"z": []interface{}{1, "s,:{}[]", nil, true}, "w": "\"quoted\""},
+			},
+			Node: &colorNode{
+				Name: "root",
+				Children: []*colorNode{
+					{Name: "a", Attrs: map[string]interface{}{"x": []int{}, "y": map[string]int{}}},
+					nil,
+					{Name: "b", Children: []*colorNode{{Name: "c"}}},
+				},
+			},
+			ID:    1,
+			Ptr:   &n,
+			Items: [2]interface{}{struct{}{}, []byte("bin")},
+			Map:   map[int]float64{2: 1.5, 1: -1},
+		},
+		"empty":   colorNode{},
+		"slice":   [][]map[string]bool{{{"a": true, "b": false}}, {}, nil},
+		"literal": "string",
+	}
+	for name, v := range values {
+		expected, err := json.Marshal(v)
+		assertErr(t, err)
+		expectedIndent, err := json.MarshalIndent(v, ">", "  ")
+		assertErr(t, err)
+		// decode the copy because Unmarshal unescapes the strings in place.
+		var expectedValue interface{}
+		assertErr(t, json.Unmarshal(append([]byte{}, expected...), &expectedValue))
+		for _, test := range []struct {
+			name     string
+			expected []byte
+			marshal  func(...json.EncodeOptionFunc) ([]byte, error)
+		}{
+			{"compact", expected, func(opts ...json.EncodeOptionFunc) ([]byte, error) {
+				return json.MarshalWithOption(v, opts...)
+			}},
+			{"unordered map", nil, func(opts ...json.EncodeOptionFunc) ([]byte, error) {
+				return json.MarshalWithOption(v, append(opts, json.UnorderedMap())...)
+			}},
+			{"indent", expectedIndent, func(opts ...json.EncodeOptionFunc) ([]byte, error) {
+				return json.MarshalIndentWithOption(v, ">", "  ", opts...)
+			}},
+			{"unordered map indent", nil, func(opts ...json.EncodeOptionFunc) ([]byte, error) {
+				return json.MarshalIndentWithOption(v, ">", "  ", append(opts, json.UnorderedMap())...)
+			}},
+		} {
+			t.Run(name+"/"+test.name, func(t *testing.T) {
+				got, err := test.marshal(json.Colorize(scheme))
+				assertErr(t, err)
+				assertColorizedDelimiters(t, scheme, string(got))
+				stripped := ansiEscapePattern.ReplaceAllString(string(got), "")
+				if test.expected != nil {
+					assertEq(t, "stripped", string(test.expected), stripped)
+					return
+				}
+				// the order of the map entries is not stable without sorting.
+				var gotValue interface{}
+				assertErr(t, json.Unmarshal([]byte(strings.ReplaceAll(stripped, "\n>", "\n")), &gotValue))
+				if !reflect.DeepEqual(expectedValue, gotValue) {
+					t.Fatalf("failed to encode with unordered map:\nexpected: %s\n     got: %s", expected, stripped)
+				}
+			})
+		}
+	}
+}
+
+func TestColorSchemes(t *testing.T) {
+	assertEq(t, "256 color", json.ColorFormat{Header: "\x1b[38;5;208m", Footer: "\x1b[0m"}, json.NewColorFormat256(208))
+	assertEq(t, "truecolor", json.ColorFormat{Header: "\x1b[38;2;1;2;255m", Footer: "\x1b[0m"}, json.NewTrueColorFormat(1, 2, 255))
+
+	v := map[string]interface{}{"a": []interface{}{1, 2.5, "s", true, nil, []byte("b")}, "b": map[string]int{}}
+	expected, err := json.MarshalIndent(v, "", "  ")
+	assertErr(t, err)
+	for name, scheme := range map[string]*json.ColorScheme{
+		"default":    json.DefaultColorScheme,
+		"dark":       json.DarkColorScheme,
+		"light":      json.LightColorScheme,
+		"monochrome": json.MonochromeColorScheme,
+	} {
+		t.Run(name, func(t *testing.T) {
+			got, err := json.MarshalIndentWithOption(v, "", "  ", json.Colorize(scheme))
+			assertErr(t, err)
+			assertEq(t, "stripped", string(expected), ansiEscapePattern.ReplaceAllString(string(got), ""))
+			if scheme.Comma.Header != "" {
+				assertColorizedDelimiters(t, scheme, string(got))
+			}
+		})
+	}
+}
+
+func TestIndentColor(t *testing.T) {
+	scheme := &json.ColorScheme{
+		Int:       json.NewColorFormat256(1),
+		Float:     json.NewColorFormat256(3),
+		Bool:      json.NewColorFormat256(4),
+		String:    json.NewColorFormat256(5),
+		ObjectKey: json.NewColorFormat256(6),
+		Null:      json.NewColorFormat256(7),
+		Brace:     json.NewColorFormat256(8),
+		Bracket:   json.NewColorFormat256(9),
+		Colon:     json.NewColorFormat256(10),
+		Comma:     json.NewColorFormat256(11),
+	}
+	type leaf struct {
+		G []interface{} `json:"g"`
+	}
+	type child struct {
+		C struct{}    `json:"c"`
+		D []int       `json:"d"`
+		E map[int]int `json:"e"`
+		F *leaf       `json:"f"`
+	}
+	// the struct and map keys are colored by the ObjectKey format and the numbers of int and float64 keep their formats.
+	for _, v := range []interface{}{
+		map[string]interface{}{
+			"b": map[int]string{2: "x", 1: "y"},
+			"a": []interface{}{map[string]int{"n": 1}, map[string]int{}},
+		},
+		struct {
+			A []interface{} `json:"a"`
+			B child         `json:"b"`
+		}{
+			A: []interface{}{-1, 2.5, 1e+21, "s,:{}[]\"", true, false, nil},
+			B: child{D: []int{}, F: &leaf{G: []interface{}{[]interface{}{}, leaf{}}}},
+		},
+		[]interface{}{[]interface{}{}, []interface{}{[]int{1}}},
+		"string",
+		10,
+	} {
+		src, err := json.Marshal(v)
+		assertErr(t, err)
+		t.Run(string(src), func(t *testing.T) {
+			expected, err := json.MarshalIndentWithOption(v, ">", "\t", json.Colorize(scheme))
+			assertErr(t, err)
+			var got bytes.Buffer
+			assertErr(t, json.IndentColor(&got, src, ">", "\t", scheme))
+			assertEq(t, "indent", string(expected), got.String())
+
+			expected, err = json.MarshalWithOption(v, json.Colorize(scheme))
+			assertErr(t, err)
+			got.Reset()
+			assertErr(t, json.CompactColor(&got, src, scheme))
+			assertEq(t, "compact", string(expected), got.String())
+		})
+	}
+	t.Run("whitespace", func(t *testing.T) {
+		src := " {\n\t\"a\" : [ 1 , { } ] ,\"b\":null }\n"
+		var got bytes.Buffer
+		assertErr(t, json.CompactColor(&got, []byte(src), nil))
+		expected, err := json.MarshalWithOption(struct {
+			A []interface{} `json:"a"`
+			B *int          `json:"b"`
+		}{A: []interface{}{1, struct{}{}}}, json.Colorize(json.DefaultColorScheme))
+		assertErr(t, err)
+		assertEq(t, "compact", string(expected), got.String())
+
+		var plain bytes.Buffer
+		assertErr(t, json.Indent(&plain, []byte(src), "", "  "))
+		got.Reset()
+		assertErr(t, json.IndentColor(&got, []byte(src), "", "  ", json.DarkColorScheme))
+		assertEq(t, "indent", strings.TrimSpace(plain.String()), ansiEscapePattern.ReplaceAllString(got.String(), ""))
+	})
+	t.Run("error", func(t *testing.T) {
+		for _, src := range []string{``, `{"a":1,}`, `[1 2]`, `{"a" 1}`, `tru`, `{} {}`} {
+			var got bytes.Buffer
+			if err := json.IndentColor(&got, []byte(src), "", "  ", scheme); err == nil {
+				t.Fatalf("expected error for %q", src)
+			}
+			if err := json.CompactColor(&got, []byte(src), scheme); err == nil {
+				t.Fatalf("expected error for %q", src)
+			}
+		}
+	})
+}
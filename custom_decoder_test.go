@@ -0,0 +1,135 @@
+package json_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+type customDecoderUUID [4]byte
+
+func (u *customDecoderUUID) UnmarshalJSON([]byte) error {
+	return errors.New("UnmarshalJSON must not be called")
+}
+
+type customDecoderTags []string
+
+func init() {
+	json.RegisterDecoder(func(b []byte, v *customDecoderUUID) error {
+		if string(b) == "null" {
+			return nil
+		}
+		if len(b) < 2 || b[0] != '"' {
+			return errors.New("uuid must be a string")
+		}
+		_, err := hex.Decode(v[:], b[1:len(b)-1])
+		return err
+	})
+	json.RegisterStreamDecoder(func(dec *json.Decoder, v *customDecoderTags) error {
+		var s string
+		if err := dec.Decode(&s); err != nil {
+			return err
+		}
+		*v = strings.Split(s, ",")
+		return nil
+	})
+}
+
+type customDecoderRecord struct {
+	ID    customDecoderUUID            `json:"id"`
+	Ref   *customDecoderUUID           `json:"ref"`
+	Items []customDecoderUUID          `json:"items"`
+	ByKey map[string]customDecoderUUID `json:"by_key"`
+	Tags  customDecoderTags            `json:"tags"`
+	Name  string                       `json:"name"`
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	src := `{"id":"deadbeef","ref":"01020304","items":["0a0b0c0d"],"by_key":{"a":"ffffffff"},"tags":"x,y","name":"n"}`
+	expected := customDecoderRecord{
+		ID:    customDecoderUUID{0xde, 0xad, 0xbe, 0xef},
+		Ref:   &customDecoderUUID{1, 2, 3, 4},
+		Items: []customDecoderUUID{{0x0a, 0x0b, 0x0c, 0x0d}},
+		ByKey: map[string]customDecoderUUID{"a": {0xff, 0xff, 0xff, 0xff}},
+		Tags:  customDecoderTags{"x", "y"},
+		Name:  "n",
+	}
+
+	t.Run("Unmarshal", func(t *testing.T) {
+		var v customDecoderRecord
+		assertErr(t, json.Unmarshal([]byte(src), &v))
+		if !reflect.DeepEqual(expected, v) {
+			t.Fatalf("failed to decode. exp=[%+v] but act=[%+v]", expected, v)
+		}
+	})
+	t.Run("Decoder", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader(src + "\n" + `{"id":"00000001","tags":"z"}`))
+		var v customDecoderRecord
+		assertErr(t, dec.Decode(&v))
+		if !reflect.DeepEqual(expected, v) {
+			t.Fatalf("failed to decode. exp=[%+v] but act=[%+v]", expected, v)
+		}
+		var v2 customDecoderRecord
+		assertErr(t, dec.Decode(&v2))
+		assertEq(t, "second id", customDecoderUUID{0, 0, 0, 1}, v2.ID)
+		assertEq(t, "second tags", "[z]", fmt.Sprint(v2.Tags))
+	})
+	t.Run("top level", func(t *testing.T) {
+		var id customDecoderUUID
+		assertErr(t, json.Unmarshal([]byte(`"cafebabe"`), &id))
+		assertEq(t, "id", customDecoderUUID{0xca, 0xfe, 0xba, 0xbe}, id)
+
+		var tags customDecoderTags
+		assertErr(t, json.NewDecoder(bytes.NewBufferString(`"a,b"`)).Decode(&tags))
+		assertEq(t, "tags", "[a b]", fmt.Sprint(tags))
+	})
+	t.Run("Path.Unmarshal", func(t *testing.T) {
+		path, err := json.CreatePath("$.items[*]")
+		assertErr(t, err)
+		var ids []customDecoderUUID
+		assertErr(t, path.Unmarshal([]byte(src), &ids))
+		assertEq(t, "items", 1, len(ids))
+		assertEq(t, "item", customDecoderUUID{0x0a, 0x0b, 0x0c, 0x0d}, ids[0])
+
+		path, err = json.CreatePath("$.items")
+		assertErr(t, err)
+		ids = nil
+		assertErr(t, path.Unmarshal([]byte(`{"items":["0a0b0c0d","01020304"]}`), &ids))
+		assertEq(t, "array", 2, len(ids))
+		assertEq(t, "first", customDecoderUUID{0x0a, 0x0b, 0x0c, 0x0d}, ids[0])
+		assertEq(t, "second", customDecoderUUID{1, 2, 3, 4}, ids[1])
+
+		path, err = json.CreatePath("$.id")
+		assertErr(t, err)
+		var id customDecoderUUID
+		assertErr(t, path.Unmarshal([]byte(src), &id))
+		assertEq(t, "id", expected.ID, id)
+
+		path, err = json.CreatePath("$.records[*]")
+		assertErr(t, err)
+		var records []customDecoderRecord
+		assertErr(t, path.Unmarshal([]byte(`{"records":[`+src+`]}`), &records))
+		assertEq(t, "records", 1, len(records))
+		if !reflect.DeepEqual(expected, records[0]) {
+			t.Fatalf("failed to decode nested fields: %+v", records[0])
+		}
+
+		path, err = json.CreatePath("$.by_key")
+		assertErr(t, err)
+		var byKey map[string]customDecoderUUID
+		assertErr(t, path.Unmarshal([]byte(src), &byKey))
+		assertEq(t, "by key", expected.ByKey["a"], byKey["a"])
+	})
+	t.Run("error", func(t *testing.T) {
+		var v customDecoderRecord
+		if err := json.Unmarshal([]byte(`{"id":1}`), &v); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package json_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+type customEncoderUUID [4]byte
+
+type customEncoderDecimal struct {
+	unscaled int64
+	scale    int
+}
+
+func (d customEncoderDecimal) MarshalJSON() ([]byte, error) {
+	return []byte(`"marshal json"`), nil
+}
+
+type customEncoderFailure struct{}
+
+func init() {
+	json.RegisterEncoder(func(b []byte, v customEncoderUUID) ([]byte, error) {
+		b = append(b, '"')
+		b = append(b, hex.EncodeToString(v[:])...)
+		return append(b, '"'), nil
+	})
+	json.RegisterEncoder(func(b []byte, v customEncoderDecimal) ([]byte, error) {
+		s := strconv.FormatInt(v.unscaled, 10)
+		if v.scale > 0 && v.scale < len(s) {
+			s = s[:len(s)-v.scale] + "." + s[len(s)-v.scale:]
+		}
+		return append(b, s...), nil
+	})
+	json.RegisterEncoder(func(b []byte, v customEncoderFailure) ([]byte, error) {
+		return nil, errors.New("failure")
+	})
+}
+
+type customEncoderOrder struct {
+	ID       customEncoderUUID               `json:"id"`
+	Price    customEncoderDecimal            `json:"price"`
+	Discount *customEncoderDecimal           `json:"discount,omitempty"`
+	Items    []customEncoderUUID             `json:"items"`
+	Prices   map[string]customEncoderDecimal `json:"prices"`
+	Any      interface{}                     `json:"any"`
+}
+
+func TestRegisterEncoder(t *testing.T) {
+	id := customEncoderUUID{0xde, 0xad, 0xbe, 0xef}
+	price := customEncoderDecimal{unscaled: 1250, scale: 2}
+
+	t.Run("top level", func(t *testing.T) {
+		got, err := json.Marshal(id)
+		assertErr(t, err)
+		assertEq(t, "value", `"deadbeef"`, string(got))
+
+		got, err = json.Marshal(&id)
+		assertErr(t, err)
+		assertEq(t, "pointer", `"deadbeef"`, string(got))
+
+		var nilID *customEncoderUUID
+		got, err = json.Marshal(nilID)
+		assertErr(t, err)
+		assertEq(t, "nil pointer", `null`, string(got))
+	})
+	t.Run("precedence over MarshalJSON", func(t *testing.T) {
+		got, err := json.Marshal(price)
+		assertErr(t, err)
+		assertEq(t, "decimal", `12.50`, string(got))
+	})
+	t.Run("struct fields", func(t *testing.T) {
+		discount := customEncoderDecimal{unscaled: 5}
+		v := customEncoderOrder{
+			ID:       id,
+			Price:    price,
+			Discount: &discount,
+			Items:    []customEncoderUUID{{1, 2, 3, 4}},
+			Prices:   map[string]customEncoderDecimal{"a": price},
+			Any:      id,
+		}
+		got, err := json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "order", `{"id":"deadbeef","price":12.50,"discount":5,"items":["01020304"],"prices":{"a":12.50},"any":"deadbeef"}`, string(got))
+
+		got, err = json.Marshal(&customEncoderOrder{})
+		assertErr(t, err)
+		assertEq(t, "empty order", `{"id":"00000000","price":0,"items":null,"prices":null,"any":null}`, string(got))
+	})
+	t.Run("indent", func(t *testing.T) {
+		got, err := json.MarshalIndent(map[string]interface{}{"id": id}, "", "  ")
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n  \"id\": \"deadbeef\"\n}", string(got))
+	})
+	t.Run("error", func(t *testing.T) {
+		_, err := json.Marshal(struct{ F customEncoderFailure }{})
+		var marshalerErr *json.MarshalerError
+		if !errors.As(err, &marshalerErr) {
+			t.Fatalf("expected MarshalerError but got %v", err)
+		}
+	})
+}
+
+func TestCustomEncoderOption(t *testing.T) {
+	upper := json.CustomEncoder(func(b []byte, v customEncoderUUID) ([]byte, error) {
+		b = append(b, '"')
+		b = append(b, bytes.ToUpper([]byte(hex.EncodeToString(v[:])))...)
+		return append(b, '"'), nil
+	})
+	v := struct {
+		ID customEncoderUUID `json:"id"`
+	}{ID: customEncoderUUID{0xab, 0xcd, 0xef, 0x01}}
+
+	got, err := json.MarshalWithOption(v, upper)
+	assertErr(t, err)
+	assertEq(t, "per call", `{"id":"ABCDEF01"}`, string(got))
+
+	got, err = json.Marshal(v)
+	assertErr(t, err)
+	assertEq(t, "registered", `{"id":"abcdef01"}`, string(got))
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	assertErr(t, enc.EncodeWithOption(v, upper, json.TagKey("json", "api")))
+	assertEq(t, "encoder", "{\"id\":\"ABCDEF01\"}\n", buf.String())
+
+	for _, prefix := range []string{"a", "b", "c"} {
+		prefixed := json.CustomEncoder(func(b []byte, v customEncoderUUID) ([]byte, error) {
+			return append(b, `"`+prefix+hex.EncodeToString(v[:])+`"`...), nil
+		})
+		got, err := json.MarshalIndentWithOption(v, "", "", prefixed)
+		assertErr(t, err)
+		assertEq(t, "created for each call", "{\n\"id\": \""+prefix+"abcdef01\"\n}", string(got))
+	}
+}
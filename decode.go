@@ -13,7 +13,9 @@ import (
 )
 
 type Decoder struct {
-	s *decoder.Stream
+	s        *decoder.Stream
+	seq      bool
+	seqIndex int // number of the elements of the JSON text sequence read so far
 }
 
 const (
@@ -40,16 +42,26 @@ func unmarshal(data []byte, v interface{}, optFuncs ...DecodeOptionFunc) error {
 	if err := validateType(header.typ, uintptr(header.ptr)); err != nil {
 		return err
 	}
-	dec, err := decoder.CompileToGetDecoder(header.typ)
-	if err != nil {
-		return err
-	}
 	ctx := decoder.TakeRuntimeContext()
 	ctx.Buf = src
 	ctx.Option.Flags = 0
 	for _, optFunc := range optFuncs {
 		optFunc(ctx.Option)
 	}
+	if ctx.Option.Flags&decoder.JSON5Option != 0 {
+		translated, err := translateJSON5(data)
+		if err != nil {
+			decoder.ReleaseRuntimeContext(ctx)
+			return err
+		}
+		src = translated
+		ctx.Buf = src
+	}
+	dec, err := decoder.CompileToGetDecoder(header.typ, ctx.Option)
+	if err != nil {
+		decoder.ReleaseRuntimeContext(ctx)
+		return err
+	}
 	cursor, err := dec.Decode(ctx, 0, 0, header.ptr)
 	if err != nil {
 		decoder.ReleaseRuntimeContext(ctx)
@@ -74,10 +86,6 @@ func unmarshalContext(ctx context.Context, data []byte, v interface{}, optFuncs
 	if err := validateType(header.typ, uintptr(header.ptr)); err != nil {
 		return err
 	}
-	dec, err := decoder.CompileToGetDecoder(header.typ)
-	if err != nil {
-		return err
-	}
 	rctx := decoder.TakeRuntimeContext()
 	rctx.Buf = src
 	rctx.Option.Flags = 0
@@ -86,6 +94,20 @@ func unmarshalContext(ctx context.Context, data []byte, v interface{}, optFuncs
 	for _, optFunc := range optFuncs {
 		optFunc(rctx.Option)
 	}
+	if rctx.Option.Flags&decoder.JSON5Option != 0 {
+		translated, err := translateJSON5(data)
+		if err != nil {
+			decoder.ReleaseRuntimeContext(rctx)
+			return err
+		}
+		src = translated
+		rctx.Buf = src
+	}
+	dec, err := decoder.CompileToGetDecoder(header.typ, rctx.Option)
+	if err != nil {
+		decoder.ReleaseRuntimeContext(rctx)
+		return err
+	}
 	cursor, err := dec.Decode(rctx, 0, 0, header.ptr)
 	if err != nil {
 		decoder.ReleaseRuntimeContext(rctx)
@@ -120,6 +142,15 @@ func extractFromPath(path *Path, data []byte, optFuncs ...DecodeOptionFunc) ([][
 	for _, optFunc := range optFuncs {
 		optFunc(ctx.Option)
 	}
+	if ctx.Option.Flags&decoder.JSON5Option != 0 {
+		translated, err := translateJSON5(data)
+		if err != nil {
+			decoder.ReleaseRuntimeContext(ctx)
+			return nil, err
+		}
+		src = translated
+		ctx.Buf = src
+	}
 	paths, cursor, err := pathDecoder.DecodePath(ctx, 0, 0)
 	if err != nil {
 		decoder.ReleaseRuntimeContext(ctx)
@@ -141,10 +172,6 @@ func unmarshalNoEscape(data []byte, v interface{}, optFuncs ...DecodeOptionFunc)
 	if err := validateType(header.typ, uintptr(header.ptr)); err != nil {
 		return err
 	}
-	dec, err := decoder.CompileToGetDecoder(header.typ)
-	if err != nil {
-		return err
-	}
 
 	ctx := decoder.TakeRuntimeContext()
 	ctx.Buf = src
@@ -152,6 +179,20 @@ func unmarshalNoEscape(data []byte, v interface{}, optFuncs ...DecodeOptionFunc)
 	for _, optFunc := range optFuncs {
 		optFunc(ctx.Option)
 	}
+	if ctx.Option.Flags&decoder.JSON5Option != 0 {
+		translated, err := translateJSON5(data)
+		if err != nil {
+			decoder.ReleaseRuntimeContext(ctx)
+			return err
+		}
+		src = translated
+		ctx.Buf = src
+	}
+	dec, err := decoder.CompileToGetDecoder(header.typ, ctx.Option)
+	if err != nil {
+		decoder.ReleaseRuntimeContext(ctx)
+		return err
+	}
 	cursor, err := dec.Decode(ctx, 0, 0, noescape(header.ptr))
 	if err != nil {
 		decoder.ReleaseRuntimeContext(ctx)
@@ -161,6 +202,15 @@ func unmarshalNoEscape(data []byte, v interface{}, optFuncs ...DecodeOptionFunc)
 	return validateEndBuf(src, cursor)
 }
 
+// translateJSON5 translates the JSON5 data into JSON terminated by the nul character.
+func translateJSON5(data []byte) ([]byte, error) {
+	src, err := decoder.TranslateJSON5(data)
+	if err != nil {
+		return nil, err
+	}
+	return append(src, nul), nil
+}
+
 func validateEndBuf(src []byte, cursor int64) error {
 	for cursor < int64(len(src)) {
 		switch src[cursor] {
@@ -227,6 +277,9 @@ func (d *Decoder) DecodeContext(ctx context.Context, v interface{}) error {
 }
 
 func (d *Decoder) DecodeWithOption(v interface{}, optFuncs ...DecodeOptionFunc) error {
+	if d.seq {
+		return d.decodeSeq(v, optFuncs...)
+	}
 	header := (*emptyInterface)(unsafe.Pointer(&v))
 	typ := header.typ
 	ptr := uintptr(header.ptr)
@@ -238,28 +291,72 @@ func (d *Decoder) DecodeWithOption(v interface{}, optFuncs ...DecodeOptionFunc)
 		return err
 	}
 
-	dec, err := decoder.CompileToGetDecoder(typ)
-	if err != nil {
-		return err
-	}
-	if err := d.s.PrepareForDecode(); err != nil {
-		return err
-	}
 	s := d.s
+	if len(optFuncs) > 0 {
+		// the options apply only to this call.
+		savedOption := *s.Option
+		defer func() {
+			*s.Option = savedOption
+			if s.JSON5Enabled() {
+				// the rest of the input is translated, so it may contain the non-finite numbers of JSON5.
+				s.Option.Flags |= decoder.JSON5Option
+			}
+		}()
+	}
 	for _, optFunc := range optFuncs {
 		optFunc(s.Option)
 	}
-	if err := dec.DecodeStream(s, 0, header.ptr); err != nil {
+	if s.Option.Flags&decoder.JSON5Option != 0 {
+		s.EnableJSON5()
+	}
+	dec, err := decoder.CompileToGetDecoder(typ, s.Option)
+	if err != nil {
 		return err
 	}
+	if err := s.PrepareForDecode(); err != nil {
+		return s.JSON5Error(err)
+	}
+	if err := dec.DecodeStream(s, 0, header.ptr); err != nil {
+		return s.JSON5Error(err)
+	}
 	s.Reset()
 	return nil
 }
 
+// RegisterDecoder registers fn as the decoder for values of type T.
+// fn receives the raw bytes of a JSON value, including null, and stores the result in v.
+// b is only valid during the call, so fn must copy it to retain it.
+// Registered decoders take precedence over UnmarshalJSON and UnmarshalText.
+//
+// RegisterDecoder is meant to be called during initialization, before T is first decoded,
+// because the compiled decoder of a type is cached.
+func RegisterDecoder[T any](fn func(b []byte, v *T) error) {
+	decoder.RegisterCustomDecoder(&decoder.CustomDecoder{
+		Type: runtime.Type2RType(reflect.TypeOf((*T)(nil)).Elem()),
+		DecodeBytes: func(b []byte, p unsafe.Pointer) error {
+			return fn(b, (*T)(p))
+		},
+	})
+}
+
+// RegisterStreamDecoder registers fn as the decoder for values of type T like RegisterDecoder.
+// fn reads exactly one JSON value from dec, by Token or Decode, and stores the result in v.
+// When decoding from a byte slice, dec reads from the bytes of the value.
+func RegisterStreamDecoder[T any](fn func(dec *Decoder, v *T) error) {
+	decoder.RegisterCustomDecoder(&decoder.CustomDecoder{
+		Type: runtime.Type2RType(reflect.TypeOf((*T)(nil)).Elem()),
+		DecodeStream: func(s *decoder.Stream, p unsafe.Pointer) error {
+			return fn(&Decoder{s: s}, (*T)(p))
+		},
+	})
+}
+
 func (d *Decoder) More() bool {
 	return d.s.More()
 }
 
+// Token returns the next JSON token in the input stream.
+// It does not accept the JSON5 syntax of AllowJSON5 in the input which has not been translated by DecodeWithOption.
 func (d *Decoder) Token() (Token, error) {
 	return d.s.Token()
 }
@@ -0,0 +1,41 @@
+package json
+
+import (
+	"io"
+	"reflect"
+
+	"github.com/goccy/go-json/internal/decoder"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// DumpDecoder writes the tree of the decoders compiled for decoding into the pointer to typ as text.
+// Each line shows the implementation of the decoder and its details,
+// like the keys and offsets of the struct fields and the key decoder chosen for the struct,
+// which is the bitmap of the key characters for structs with few short keys or the map of the field names otherwise.
+// optFuncs are applied in the same way as UnmarshalWithOption, so that the decoders compiled with options like DecodeTagKey can be shown.
+func DumpDecoder(typ reflect.Type, w io.Writer, optFuncs ...DecodeOptionFunc) error {
+	dec, err := compileDecoderForDump(typ, optFuncs)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, decoder.Dump(dec)+"\n")
+	return err
+}
+
+// DumpDecoderDOT writes the graph of the decoders shown by DumpDecoder in the DOT language of Graphviz.
+func DumpDecoderDOT(typ reflect.Type, w io.Writer, optFuncs ...DecodeOptionFunc) error {
+	dec, err := compileDecoderForDump(typ, optFuncs)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, decoder.DumpDOT(dec))
+	return err
+}
+
+func compileDecoderForDump(typ reflect.Type, optFuncs []DecodeOptionFunc) (decoder.Decoder, error) {
+	opt := &decoder.Option{}
+	for _, optFunc := range optFuncs {
+		optFunc(opt)
+	}
+	return decoder.CompileToGetDecoder(runtime.Type2RType(reflect.PtrTo(typ)), opt)
+}
@@ -0,0 +1,108 @@
+package json_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+type dumpNode struct {
+	Name     string            `json:"name"`
+	Count    int64             `json:"count,string"`
+	Children []*dumpNode       `json:"children"`
+	Attrs    map[string]string `json:"attrs"`
+	Extra    interface{}
+}
+
+type dumpEmbedded struct {
+	*dumpNode
+	ID uint8 `api:"identifier" json:"id"`
+}
+
+type dumpManyKeys struct {
+	F0, F1, F2, F3, F4, F5, F6, F7, F8 int
+}
+
+type dumpTooManyKeys struct {
+	F0, F1, F2, F3, F4, F5, F6, F7, F8, F9, F10, F11, F12, F13, F14, F15, F16 int
+}
+
+func TestDumpDecoder(t *testing.T) {
+	t.Run("text", func(t *testing.T) {
+		var b bytes.Buffer
+		assertErr(t, json.DumpDecoder(reflect.TypeOf(dumpNode{}), &b))
+		expected := `
+[000] structDecoder (keyDecoder: bitmap uint8)
+  "name" offset:0 tagged: [001] stringDecoder
+  "count" offset:16 tagged: [002] wrappedStringDecoder (type: int64)
+    value: [003] intDecoder (type: int64)
+  "children" offset:24 tagged: [004] sliceDecoder (elem: *json_test.dumpNode, size: 8)
+    elem: [005] ptrDecoder (type: *json_test.dumpNode)
+      elem: [000] structDecoder (see above)
+  "attrs" offset:48 tagged: [006] mapDecoder (type: map[string]string, mapassign_faststr)
+    key: [007] stringDecoder
+    value: [008] stringDecoder
+  "Extra" offset:56: [009] interfaceDecoder (type: interface {})
+`
+		assertEq(t, "dump", expected[1:], b.String())
+	})
+	t.Run("embedded pointer", func(t *testing.T) {
+		var b bytes.Buffer
+		assertErr(t, json.DumpDecoder(reflect.TypeOf(dumpEmbedded{}), &b, json.DecodeTagKey("api", "json")))
+		dump := b.String()
+		for _, expected := range []string{
+			`"name" offset:0 tagged unsettable: [`,
+			`] anonymousFieldDecoder (type: *json_test.dumpNode, offset: 16)`,
+			`"identifier" offset:8 tagged: [`,
+			`] uintDecoder (type: uint8)`,
+		} {
+			if !strings.Contains(dump, expected) {
+				t.Fatalf("expected %q in dump:\n%s", expected, dump)
+			}
+		}
+	})
+	t.Run("key decoders", func(t *testing.T) {
+		for _, test := range []struct {
+			typ      reflect.Type
+			expected string
+		}{
+			{reflect.TypeOf(dumpManyKeys{}), "bitmap uint16"},
+			{reflect.TypeOf(dumpTooManyKeys{}), "field map"},
+		} {
+			var b bytes.Buffer
+			assertErr(t, json.DumpDecoder(test.typ, &b))
+			if !strings.HasPrefix(b.String(), "[000] structDecoder (keyDecoder: "+test.expected+")\n") {
+				t.Fatalf("unexpected dump of %s:\n%s", test.typ, b.String())
+			}
+		}
+	})
+	t.Run("dot", func(t *testing.T) {
+		var b bytes.Buffer
+		assertErr(t, json.DumpDecoderDOT(reflect.TypeOf(dumpNode{}), &b))
+		dot := b.String()
+		for _, expected := range []string{
+			"digraph \"decoder\" {\n",
+			`"0" [label="structDecoder (keyDecoder: bitmap uint8)"];`,
+			`"0" -> "4" [label="\"children\" offset:24 tagged"];`,
+			`"5" -> "0" [label="elem"];`,
+		} {
+			if !strings.Contains(dot, expected) {
+				t.Fatalf("expected %q in dot:\n%s", expected, dot)
+			}
+		}
+		if !strings.HasSuffix(dot, "}") {
+			t.Fatalf("unexpected end of dot:\n%s", dot)
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		type inlineString struct {
+			S string `json:",inline"`
+		}
+		if err := json.DumpDecoder(reflect.TypeOf(inlineString{}), &bytes.Buffer{}); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 	"os"
+	"reflect"
 	"unsafe"
 
 	"github.com/goccy/go-json/internal/encoder"
@@ -11,6 +12,7 @@ import (
 	"github.com/goccy/go-json/internal/encoder/vm_color"
 	"github.com/goccy/go-json/internal/encoder/vm_color_indent"
 	"github.com/goccy/go-json/internal/encoder/vm_indent"
+	"github.com/goccy/go-json/internal/runtime"
 )
 
 const (
@@ -22,8 +24,12 @@ type Encoder struct {
 	w                 io.Writer
 	enabledIndent     bool
 	enabledHTMLEscape bool
+	enabledJSONSeq    bool
 	prefix            string
 	indentStr         string
+	flushThreshold    int
+	tokenScopes       []tokenScope
+	tokenOffset       int64
 }
 
 // NewEncoder returns a new encoder that writes to w.
@@ -63,6 +69,40 @@ func (e *Encoder) EncodeContext(ctx context.Context, v interface{}, optFuncs ...
 }
 
 func (e *Encoder) encodeWithOption(ctx *encoder.RuntimeContext, v interface{}, optFuncs ...EncodeOptionFunc) error {
+	if len(e.tokenScopes) > 0 {
+		return e.tokenError("cannot encode a value while writing tokens")
+	}
+	writeSeparator := e.enabledJSONSeq
+	if e.flushThreshold > 0 {
+		if writeSeparator {
+			// the record separator precedes the bytes flushed while encoding.
+			if _, err := e.w.Write(recordSeparator); err != nil {
+				return err
+			}
+			writeSeparator = false
+		}
+		ctx.Writer = e.w
+		ctx.FlushThreshold = e.flushThreshold
+	}
+	buf, err := e.encodeValue(ctx, v, e.prefix, optFuncs...)
+	if err != nil {
+		return err
+	}
+	buf = append(buf, '\n')
+	if writeSeparator {
+		if _, err := e.w.Write(recordSeparator); err != nil {
+			return err
+		}
+	}
+	if _, err := e.w.Write(buf); err != nil {
+		return err
+	}
+	return nil
+}
+
+// encodeValue encodes v with the options of e and returns it without the trailing separator.
+// prefix is used instead of e.prefix when indenting.
+func (e *Encoder) encodeValue(ctx *encoder.RuntimeContext, v interface{}, prefix string, optFuncs ...EncodeOptionFunc) ([]byte, error) {
 	if e.enabledHTMLEscape {
 		ctx.Option.Flag |= encoder.HTMLEscapeOption
 	}
@@ -71,28 +111,21 @@ func (e *Encoder) encodeWithOption(ctx *encoder.RuntimeContext, v interface{}, o
 	for _, optFunc := range optFuncs {
 		optFunc(ctx.Option)
 	}
-	var (
-		buf []byte
-		err error
-	)
-	if e.enabledIndent {
-		buf, err = encodeIndent(ctx, v, e.prefix, e.indentStr)
-	} else {
-		buf, err = encode(ctx, v)
-	}
-	if err != nil {
-		return err
+	if (ctx.Option.Flag & encoder.HTMLOption) != 0 {
+		return marshalHTML(ctx, v, prefix, e.indentStr, e.enabledIndent)
 	}
 	if e.enabledIndent {
-		buf = buf[:len(buf)-2]
-	} else {
-		buf = buf[:len(buf)-1]
+		buf, err := encodeIndent(ctx, v, prefix, e.indentStr)
+		if err != nil {
+			return nil, err
+		}
+		return buf[:len(buf)-2], nil
 	}
-	buf = append(buf, '\n')
-	if _, err := e.w.Write(buf); err != nil {
-		return err
+	buf, err := encode(ctx, v)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	return buf[:len(buf)-1], nil
 }
 
 // SetEscapeHTML specifies whether problematic HTML characters should be escaped inside JSON quoted strings.
@@ -115,6 +148,47 @@ func (e *Encoder) SetIndent(prefix, indent string) {
 	e.enabledIndent = true
 }
 
+// SetFlushThreshold instructs the encoder to write the encoded bytes to the stream
+// whenever they exceed size bytes while encoding the elements of slices, arrays and maps,
+// so that the memory used by the encoder stays bounded regardless of the size of the value.
+// Calling SetFlushThreshold with zero or a negative size disables flushing, which is the default.
+//
+// Maps are the exception unless UnorderedMap is specified: the entries of a map are sorted in the buffer
+// after all of them are encoded, so nothing is flushed while a map is encoded, including the slices
+// and other values nested in the map, and the buffer grows to the size of the encoded map.
+//
+// If encoding fails after flushing, the stream contains the incomplete JSON value written so far.
+func (e *Encoder) SetFlushThreshold(size int) {
+	e.flushThreshold = size
+}
+
+// RegisterEncoder registers fn as the encoder for values of type T.
+// fn must append a valid JSON value for v to b and return the extended buffer.
+// The output is used as is, so it is neither compacted nor HTML escaped except when indenting.
+// Registered encoders take precedence over MarshalJSON and MarshalText.
+//
+// RegisterEncoder is meant to be called during initialization, before T is first encoded,
+// because the compiled encoding of a type is cached.
+func RegisterEncoder[T any](fn func(b []byte, v T) ([]byte, error)) {
+	encoder.RegisterCustomEncoder(newCustomEncoder(fn))
+}
+
+func newCustomEncoder[T any](fn func(b []byte, v T) ([]byte, error)) *encoder.CustomEncoder {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	return encoder.NewCustomEncoder(runtime.Type2RType(typ), func(b []byte, v interface{}) ([]byte, error) {
+		switch vv := v.(type) {
+		case T:
+			return fn(b, vv)
+		case *T:
+			if vv == nil {
+				return append(b, "null"...), nil
+			}
+			return fn(b, *vv)
+		}
+		return append(b, "null"...), nil
+	})
+}
+
 func marshalContext(ctx context.Context, v interface{}, optFuncs ...EncodeOptionFunc) ([]byte, error) {
 	rctx := encoder.TakeRuntimeContext()
 	rctx.Option.Flag = 0
@@ -123,6 +197,11 @@ func marshalContext(ctx context.Context, v interface{}, optFuncs ...EncodeOption
 	for _, optFunc := range optFuncs {
 		optFunc(rctx.Option)
 	}
+	if (rctx.Option.Flag & encoder.HTMLOption) != 0 {
+		result, err := marshalHTML(rctx, v, "", "", false)
+		encoder.ReleaseRuntimeContext(rctx)
+		return result, err
+	}
 
 	buf, err := encode(rctx, v)
 	if err != nil {
@@ -153,6 +232,11 @@ func marshal(v interface{}, optFuncs ...EncodeOptionFunc) ([]byte, error) {
 	for _, optFunc := range optFuncs {
 		optFunc(ctx.Option)
 	}
+	if (ctx.Option.Flag & encoder.HTMLOption) != 0 {
+		result, err := marshalHTML(ctx, v, "", "", false)
+		encoder.ReleaseRuntimeContext(ctx)
+		return result, err
+	}
 
 	buf, err := encode(ctx, v)
 	if err != nil {
@@ -210,6 +294,11 @@ func marshalIndent(v interface{}, prefix, indent string, optFuncs ...EncodeOptio
 	for _, optFunc := range optFuncs {
 		optFunc(ctx.Option)
 	}
+	if (ctx.Option.Flag & encoder.HTMLOption) != 0 {
+		result, err := marshalHTML(ctx, v, prefix, indent, true)
+		encoder.ReleaseRuntimeContext(ctx)
+		return result, err
+	}
 
 	buf, err := encodeIndent(ctx, v, prefix, indent)
 	if err != nil {
@@ -232,8 +321,68 @@ func marshalIndent(v interface{}, prefix, indent string, optFuncs ...EncodeOptio
 	return result, nil
 }
 
+func appendMarshal(dst []byte, v interface{}, optFuncs ...EncodeOptionFunc) ([]byte, error) {
+	ctx := encoder.TakeRuntimeContext()
+
+	ctx.Option.Flag = 0
+	ctx.Option.Flag |= (encoder.HTMLEscapeOption | encoder.NormalizeUTF8Option)
+	for _, optFunc := range optFuncs {
+		optFunc(ctx.Option)
+	}
+	if (ctx.Option.Flag & encoder.HTMLOption) != 0 {
+		result, err := marshalHTML(ctx, v, "", "", false)
+		encoder.ReleaseRuntimeContext(ctx)
+		if err != nil {
+			return dst, err
+		}
+		return append(dst, result...), nil
+	}
+
+	buf, err := appendEncode(ctx, dst, v)
+	encoder.ReleaseRuntimeContext(ctx)
+	if err != nil {
+		return dst, err
+	}
+	return buf[:len(buf)-1], nil
+}
+
+func appendMarshalIndent(dst []byte, v interface{}, prefix, indent string, optFuncs ...EncodeOptionFunc) ([]byte, error) {
+	ctx := encoder.TakeRuntimeContext()
+
+	ctx.Option.Flag = 0
+	ctx.Option.Flag |= (encoder.HTMLEscapeOption | encoder.NormalizeUTF8Option | encoder.IndentOption)
+	for _, optFunc := range optFuncs {
+		optFunc(ctx.Option)
+	}
+	if (ctx.Option.Flag & encoder.HTMLOption) != 0 {
+		result, err := marshalHTML(ctx, v, prefix, indent, true)
+		encoder.ReleaseRuntimeContext(ctx)
+		if err != nil {
+			return dst, err
+		}
+		return append(dst, result...), nil
+	}
+
+	buf, err := appendEncodeIndent(ctx, dst, v, prefix, indent)
+	encoder.ReleaseRuntimeContext(ctx)
+	if err != nil {
+		return dst, err
+	}
+	return buf[:len(buf)-2], nil
+}
+
 func encode(ctx *encoder.RuntimeContext, v interface{}) ([]byte, error) {
-	b := ctx.Buf[:0]
+	buf, err := appendEncode(ctx, ctx.Buf[:0], v)
+	if err != nil {
+		return nil, err
+	}
+	ctx.Buf = buf
+	return buf, nil
+}
+
+// appendEncode appends the encoding of v followed by the comma to b.
+// Unlike encode, it does not hold the result as ctx.Buf since b may be owned by the caller.
+func appendEncode(ctx *encoder.RuntimeContext, b []byte, v interface{}) ([]byte, error) {
 	if v == nil {
 		b = encoder.AppendNull(ctx, b)
 		b = encoder.AppendComma(ctx, b)
@@ -252,12 +401,7 @@ func encode(ctx *encoder.RuntimeContext, v interface{}) ([]byte, error) {
 	ctx.Init(p, codeSet.CodeLength)
 	ctx.KeepRefs = append(ctx.KeepRefs, header.ptr)
 
-	buf, err := encodeRunCode(ctx, b, codeSet)
-	if err != nil {
-		return nil, err
-	}
-	ctx.Buf = buf
-	return buf, nil
+	return encodeRunCode(ctx, b, codeSet)
 }
 
 func encodeNoEscape(ctx *encoder.RuntimeContext, v interface{}) ([]byte, error) {
@@ -288,7 +432,16 @@ func encodeNoEscape(ctx *encoder.RuntimeContext, v interface{}) ([]byte, error)
 }
 
 func encodeIndent(ctx *encoder.RuntimeContext, v interface{}, prefix, indent string) ([]byte, error) {
-	b := ctx.Buf[:0]
+	buf, err := appendEncodeIndent(ctx, ctx.Buf[:0], v, prefix, indent)
+	if err != nil {
+		return nil, err
+	}
+	ctx.Buf = buf
+	return buf, nil
+}
+
+// appendEncodeIndent is like appendEncode but indents the encoding of v.
+func appendEncodeIndent(ctx *encoder.RuntimeContext, b []byte, v interface{}, prefix, indent string) ([]byte, error) {
 	if v == nil {
 		b = encoder.AppendNull(ctx, b)
 		b = encoder.AppendCommaIndent(ctx, b)
@@ -312,8 +465,6 @@ func encodeIndent(ctx *encoder.RuntimeContext, v interface{}, prefix, indent str
 	if err != nil {
 		return nil, err
 	}
-
-	ctx.Buf = buf
 	return buf, nil
 }
 
@@ -0,0 +1,168 @@
+package json_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+type flushRecorder struct {
+	bytes.Buffer
+	writes   int
+	maxWrite int
+}
+
+func (w *flushRecorder) Write(b []byte) (int, error) {
+	w.writes++
+	if len(b) > w.maxWrite {
+		w.maxWrite = len(b)
+	}
+	return w.Buffer.Write(b)
+}
+
+type flushElem struct {
+	ID    int               `json:"id"`
+	Name  string            `json:"name"`
+	Tags  []string          `json:"tags,omitempty"`
+	Attrs map[string]int    `json:"attrs"`
+	Child *flushElem        `json:"child,omitempty"`
+	Any   interface{}       `json:"any"`
+	Raw   map[string]string `json:",inline"`
+}
+
+func TestEncoderFlushThreshold(t *testing.T) {
+	elems := make([]flushElem, 1000)
+	for i := range elems {
+		elems[i] = flushElem{
+			ID:    i,
+			Name:  strings.Repeat("x", i%10),
+			Attrs: map[string]int{"b": i, "a": -i},
+			Any:   []interface{}{i, "s", nil},
+		}
+		if i%3 == 0 {
+			elems[i].Tags = []string{"t"}
+			elems[i].Child = &flushElem{ID: -i}
+			elems[i].Raw = map[string]string{"z": "v"}
+		}
+	}
+	unordered := map[string][]int{}
+	for i := 0; i < 100; i++ {
+		unordered[strings.Repeat("k", i+1)] = []int{i, i}
+	}
+	tests := []struct {
+		name string
+		v    interface{}
+		opts []json.EncodeOptionFunc
+	}{
+		{name: "slice", v: elems},
+		{name: "array", v: [3][]flushElem{elems[:10], nil, elems[10:300]}},
+		{name: "nested slice", v: [][]int{make([]int, 2000), {}, make([]int, 2000)}},
+		{name: "sorted map in slice", v: []map[string][]flushElem{{"b": elems[:100], "a": elems[100:200]}, {"c": elems[:100]}}},
+		{name: "colorize", v: elems[:200], opts: []json.EncodeOptionFunc{json.Colorize(json.DefaultColorScheme)}},
+	}
+	for _, test := range tests {
+		for _, indent := range []bool{false, true} {
+			name := test.name
+			if indent {
+				name += " with indent"
+			}
+			t.Run(name, func(t *testing.T) {
+				var expected bytes.Buffer
+				expectedEnc := json.NewEncoder(&expected)
+				var got flushRecorder
+				enc := json.NewEncoder(&got)
+				enc.SetFlushThreshold(512)
+				if indent {
+					expectedEnc.SetIndent("", "  ")
+					enc.SetIndent("", "  ")
+				}
+				assertErr(t, expectedEnc.EncodeWithOption(test.v, test.opts...))
+				assertErr(t, enc.EncodeWithOption(test.v, test.opts...))
+				if expected.String() != got.String() {
+					t.Fatalf("failed to encode with flushing:\nexpected: %q\ngot:      %q", expected.String(), got.String())
+				}
+				if got.writes < 2 {
+					t.Fatalf("expected the buffer to be flushed: %d writes", got.writes)
+				}
+			})
+		}
+	}
+	t.Run("unordered map", func(t *testing.T) {
+		var w flushRecorder
+		enc := json.NewEncoder(&w)
+		enc.SetFlushThreshold(256)
+		assertErr(t, enc.EncodeWithOption(unordered, json.UnorderedMap()))
+		if w.writes < 2 {
+			t.Fatalf("expected the buffer to be flushed: %d writes", w.writes)
+		}
+		var got map[string][]int
+		assertErr(t, json.Unmarshal(w.Bytes(), &got))
+		if !reflect.DeepEqual(unordered, got) {
+			t.Fatalf("failed to encode unordered map with flushing: %s", w.String())
+		}
+	})
+	t.Run("sorted map", func(t *testing.T) {
+		var w flushRecorder
+		enc := json.NewEncoder(&w)
+		enc.SetFlushThreshold(256)
+		assertErr(t, enc.Encode(unordered))
+		expected, err := json.Marshal(unordered)
+		assertErr(t, err)
+		assertEq(t, "sorted map", string(expected)+"\n", w.String())
+		assertEq(t, "writes", 1, w.writes)
+	})
+	t.Run("bounded", func(t *testing.T) {
+		var w flushRecorder
+		enc := json.NewEncoder(&w)
+		enc.SetFlushThreshold(1024)
+		assertErr(t, enc.Encode(make([]int, 1000000)))
+		if w.maxWrite > 2048 {
+			t.Fatalf("the buffer is not bounded: max write size is %d", w.maxWrite)
+		}
+		assertEq(t, "length", 2*1000000+2, w.Len())
+	})
+	t.Run("disabled", func(t *testing.T) {
+		var w flushRecorder
+		enc := json.NewEncoder(&w)
+		enc.SetFlushThreshold(1024)
+		enc.SetFlushThreshold(0)
+		assertErr(t, enc.Encode(make([]int, 10000)))
+		assertEq(t, "writes", 1, w.writes)
+	})
+	t.Run("error", func(t *testing.T) {
+		var w flushRecorder
+		enc := json.NewEncoder(&w)
+		enc.SetFlushThreshold(64)
+		v := make([]interface{}, 100)
+		for i := range v {
+			v[i] = i
+		}
+		v[99] = make(chan int)
+		if err := enc.Encode(v); err == nil {
+			t.Fatal("expected error")
+		}
+		if !strings.HasPrefix(w.String(), "[0,1,2,") {
+			t.Fatalf("unexpected output: %q", w.String())
+		}
+	})
+	t.Run("write error", func(t *testing.T) {
+		errWrite := errors.New("write error")
+		enc := json.NewEncoder(failWriter{err: errWrite})
+		enc.SetFlushThreshold(64)
+		if err := enc.Encode(make([]int, 1000)); err != errWrite {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+type failWriter struct {
+	err error
+}
+
+func (w failWriter) Write([]byte) (int, error) {
+	return 0, w.err
+}
@@ -0,0 +1,200 @@
+package json
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-json/internal/decoder"
+	"github.com/goccy/go-json/internal/encoder"
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// tokenScope is the array or object opened by the token-level methods of Encoder.
+type tokenScope struct {
+	delim  Delim
+	count  int  // number of the written elements or members
+	hasKey bool // the key of the member is written and the value is expected
+}
+
+// WriteToken writes the JSON token t to the stream.
+// Delim opens or closes an array or object, a string is written as the key of the member if a key is expected,
+// and the other tokens are written as values by WriteValue.
+//
+// Separators are inserted between elements and members, and they are indented as configured by SetIndent.
+// After a top-level value is completed, a newline character is written in the same way as Encode.
+func (e *Encoder) WriteToken(t Token) error {
+	switch tt := t.(type) {
+	case Delim:
+		switch tt {
+		case '[', '{':
+			return e.writeOpenDelim(tt)
+		case ']', '}':
+			return e.writeCloseDelim(tt)
+		}
+		return e.tokenError("invalid delimiter '" + string(tt) + "'")
+	case string:
+		if e.isKeyExpected() {
+			return e.WriteKey(tt)
+		}
+	}
+	return e.WriteValue(t)
+}
+
+// WriteKey writes key as the key of the next member of the current object.
+func (e *Encoder) WriteKey(key string) error {
+	if !e.isKeyExpected() {
+		return e.tokenError("unexpected object key " + strconv.Quote(key))
+	}
+	scope := &e.tokenScopes[len(e.tokenScopes)-1]
+	buf := e.appendSeparator(make([]byte, 0, len(key)+len(e.prefix)+len(e.indentStr)*len(e.tokenScopes)+8), scope)
+	ctx := encoder.TakeRuntimeContext()
+	ctx.Option.Flag = encoder.NormalizeUTF8Option
+	if e.enabledHTMLEscape {
+		ctx.Option.Flag |= encoder.HTMLEscapeOption
+	}
+	buf = encoder.AppendString(ctx, buf, key)
+	encoder.ReleaseRuntimeContext(ctx)
+	buf = append(buf, ':')
+	if e.enabledIndent {
+		buf = append(buf, ' ')
+	}
+	scope.count++
+	scope.hasKey = true
+	return e.writeToken(buf)
+}
+
+// WriteValue writes the JSON encoding of v as the next value of the current array or object,
+// or as a top-level value. v is encoded in the same way as EncodeWithOption.
+func (e *Encoder) WriteValue(v interface{}, optFuncs ...EncodeOptionFunc) error {
+	if err := e.validateValue(); err != nil {
+		return err
+	}
+	prefix := e.prefix
+	if e.enabledIndent {
+		prefix += strings.Repeat(e.indentStr, len(e.tokenScopes))
+	}
+	ctx := encoder.TakeRuntimeContext()
+	ctx.Option.Flag = 0
+	b, err := e.encodeValue(ctx, v, prefix, optFuncs...)
+	if err != nil {
+		encoder.ReleaseRuntimeContext(ctx)
+		return err
+	}
+	buf := e.beginValue(make([]byte, 0, len(b)+len(prefix)+2))
+	buf = append(buf, b...)
+	encoder.ReleaseRuntimeContext(ctx)
+	return e.endValue(buf)
+}
+
+// FinishTokens reports whether the tokens written by WriteToken, WriteKey and WriteValue form complete values.
+// It returns a *SyntaxError if an array or object is not closed, and nil otherwise.
+// Call it after the last token is written to detect a truncated stream.
+func (e *Encoder) FinishTokens() error {
+	if len(e.tokenScopes) == 0 {
+		return nil
+	}
+	scope := e.tokenScopes[len(e.tokenScopes)-1]
+	if scope.hasKey {
+		return e.tokenError("missing value of object member before end of tokens")
+	}
+	return e.tokenError("unexpected end of tokens: " + strconv.Itoa(len(e.tokenScopes)) + " unclosed '" + string(scope.delim) + "'")
+}
+
+func (e *Encoder) writeOpenDelim(delim Delim) error {
+	if err := e.validateValue(); err != nil {
+		return err
+	}
+	buf := append(e.beginValue(nil), byte(delim))
+	e.tokenScopes = append(e.tokenScopes, tokenScope{delim: delim})
+	return e.writeToken(buf)
+}
+
+func (e *Encoder) writeCloseDelim(delim Delim) error {
+	if len(e.tokenScopes) == 0 {
+		return e.tokenError("unexpected delimiter '" + string(delim) + "'")
+	}
+	scope := e.tokenScopes[len(e.tokenScopes)-1]
+	if (scope.delim == '[' && delim != ']') || (scope.delim == '{' && delim != '}') {
+		return e.tokenError("unexpected delimiter '" + string(delim) + "' to close '" + string(scope.delim) + "'")
+	}
+	if scope.hasKey {
+		return e.tokenError("missing value of object member before '" + string(delim) + "'")
+	}
+	e.tokenScopes = e.tokenScopes[:len(e.tokenScopes)-1]
+	var buf []byte
+	if e.enabledIndent && scope.count > 0 {
+		buf = e.appendTokenIndent(buf)
+	}
+	return e.endValue(append(buf, byte(delim)))
+}
+
+func (e *Encoder) isKeyExpected() bool {
+	if len(e.tokenScopes) == 0 {
+		return false
+	}
+	scope := e.tokenScopes[len(e.tokenScopes)-1]
+	return scope.delim == '{' && !scope.hasKey
+}
+
+func (e *Encoder) validateValue() error {
+	if e.isKeyExpected() {
+		return e.tokenError("expected object key but got value")
+	}
+	return nil
+}
+
+// beginValue appends the separator before the value to buf.
+func (e *Encoder) beginValue(buf []byte) []byte {
+	if len(e.tokenScopes) == 0 {
+		if e.enabledJSONSeq {
+			buf = append(buf, decoder.RecordSeparator)
+		}
+		return buf
+	}
+	scope := &e.tokenScopes[len(e.tokenScopes)-1]
+	if scope.delim == '{' {
+		// the separator is written with the key.
+		scope.hasKey = false
+		return buf
+	}
+	buf = e.appendSeparator(buf, scope)
+	scope.count++
+	return buf
+}
+
+// endValue writes buf, followed by a newline character if it completes a top-level value.
+func (e *Encoder) endValue(buf []byte) error {
+	if len(e.tokenScopes) == 0 {
+		buf = append(buf, '\n')
+	}
+	return e.writeToken(buf)
+}
+
+func (e *Encoder) appendSeparator(buf []byte, scope *tokenScope) []byte {
+	if scope.count > 0 {
+		buf = append(buf, ',')
+	}
+	if e.enabledIndent {
+		buf = e.appendTokenIndent(buf)
+	}
+	return buf
+}
+
+func (e *Encoder) appendTokenIndent(buf []byte) []byte {
+	buf = append(buf, '\n')
+	buf = append(buf, e.prefix...)
+	for i := 0; i < len(e.tokenScopes); i++ {
+		buf = append(buf, e.indentStr...)
+	}
+	return buf
+}
+
+func (e *Encoder) writeToken(buf []byte) error {
+	n, err := e.w.Write(buf)
+	e.tokenOffset += int64(n)
+	return err
+}
+
+func (e *Encoder) tokenError(msg string) error {
+	return errors.ErrSyntax(msg, e.tokenOffset)
+}
@@ -0,0 +1,193 @@
+package json_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+func TestEncoderWriteToken(t *testing.T) {
+	t.Run("compact", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		assertErr(t, enc.WriteToken(json.Delim('{')))
+		assertErr(t, enc.WriteKey("id"))
+		assertErr(t, enc.WriteValue(1))
+		assertErr(t, enc.WriteToken("items"))
+		assertErr(t, enc.WriteToken(json.Delim('[')))
+		assertErr(t, enc.WriteValue(map[string]int{"a": 1}))
+		assertErr(t, enc.WriteToken("<b>"))
+		assertErr(t, enc.WriteToken(nil))
+		assertErr(t, enc.WriteToken(json.Delim('[')))
+		assertErr(t, enc.WriteToken(json.Delim(']')))
+		assertErr(t, enc.WriteToken(json.Delim(']')))
+		assertErr(t, enc.WriteKey("empty"))
+		assertErr(t, enc.WriteToken(json.Delim('{')))
+		assertErr(t, enc.WriteToken(json.Delim('}')))
+		assertErr(t, enc.WriteToken(json.Delim('}')))
+		assertErr(t, enc.WriteValue(true))
+		assertEq(t, "tokens", `{"id":1,"items":[{"a":1},"\u003cb\u003e",null,[]],"empty":{}}`+"\n"+"true\n", buf.String())
+	})
+	t.Run("indent", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent(">", "  ")
+		assertErr(t, enc.WriteToken(json.Delim('{')))
+		assertErr(t, enc.WriteKey("id"))
+		assertErr(t, enc.WriteValue(1))
+		assertErr(t, enc.WriteKey("items"))
+		assertErr(t, enc.WriteToken(json.Delim('[')))
+		assertErr(t, enc.WriteValue(map[string][]int{"a": {1, 2}}))
+		assertErr(t, enc.WriteValue([]int{}))
+		assertErr(t, enc.WriteToken(json.Delim(']')))
+		assertErr(t, enc.WriteKey("empty"))
+		assertErr(t, enc.WriteToken(json.Delim('[')))
+		assertErr(t, enc.WriteToken(json.Delim(']')))
+		assertErr(t, enc.WriteToken(json.Delim('}')))
+
+		var expected bytes.Buffer
+		expectedEnc := json.NewEncoder(&expected)
+		expectedEnc.SetIndent(">", "  ")
+		assertErr(t, expectedEnc.Encode(struct {
+			ID    int           `json:"id"`
+			Items []interface{} `json:"items"`
+			Empty []int         `json:"empty"`
+		}{
+			ID:    1,
+			Items: []interface{}{map[string][]int{"a": {1, 2}}, []int{}},
+			Empty: []int{},
+		}))
+		assertEq(t, "indent", expected.String(), buf.String())
+	})
+	t.Run("option", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		assertErr(t, enc.WriteToken(json.Delim('[')))
+		assertErr(t, enc.WriteValue("<", json.UnorderedMap()))
+		assertErr(t, enc.WriteToken(json.Delim(']')))
+		enc.SetEscapeHTML(false)
+		assertErr(t, enc.WriteToken(json.Delim('{')))
+		assertErr(t, enc.WriteKey("<"))
+		assertErr(t, enc.WriteValue(">"))
+		assertErr(t, enc.WriteToken(json.Delim('}')))
+		assertEq(t, "option", "[\"\\u003c\"]\n{\"<\":\">\"}\n", buf.String())
+	})
+	t.Run("error", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			write func(*json.Encoder) error
+		}{
+			{
+				name: "value instead of key",
+				write: func(enc *json.Encoder) error {
+					assertErr(t, enc.WriteToken(json.Delim('{')))
+					return enc.WriteValue(1)
+				},
+			},
+			{
+				name: "key in array",
+				write: func(enc *json.Encoder) error {
+					assertErr(t, enc.WriteToken(json.Delim('[')))
+					return enc.WriteKey("a")
+				},
+			},
+			{
+				name: "key twice",
+				write: func(enc *json.Encoder) error {
+					assertErr(t, enc.WriteToken(json.Delim('{')))
+					assertErr(t, enc.WriteKey("a"))
+					return enc.WriteKey("b")
+				},
+			},
+			{
+				name: "top-level key",
+				write: func(enc *json.Encoder) error {
+					return enc.WriteKey("a")
+				},
+			},
+			{
+				name: "mismatched delimiter",
+				write: func(enc *json.Encoder) error {
+					assertErr(t, enc.WriteToken(json.Delim('[')))
+					return enc.WriteToken(json.Delim('}'))
+				},
+			},
+			{
+				name: "missing value",
+				write: func(enc *json.Encoder) error {
+					assertErr(t, enc.WriteToken(json.Delim('{')))
+					assertErr(t, enc.WriteKey("a"))
+					return enc.WriteToken(json.Delim('}'))
+				},
+			},
+			{
+				name: "unopened delimiter",
+				write: func(enc *json.Encoder) error {
+					return enc.WriteToken(json.Delim(']'))
+				},
+			},
+			{
+				name: "invalid delimiter",
+				write: func(enc *json.Encoder) error {
+					return enc.WriteToken(json.Delim('('))
+				},
+			},
+			{
+				name: "encode while writing tokens",
+				write: func(enc *json.Encoder) error {
+					assertErr(t, enc.WriteToken(json.Delim('[')))
+					return enc.Encode(1)
+				},
+			},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				var buf bytes.Buffer
+				err := test.write(json.NewEncoder(&buf))
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				if _, ok := err.(*json.SyntaxError); !ok {
+					t.Fatalf("unexpected error type: %T", err)
+				}
+				if strings.HasPrefix(err.Error(), "json: ") {
+					t.Fatalf("unexpected prefix: %s", err)
+				}
+			})
+		}
+	})
+	t.Run("finish", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		assertErr(t, enc.FinishTokens())
+		assertErr(t, enc.WriteToken(json.Delim('{')))
+		assertErr(t, enc.WriteKey("a"))
+		err := enc.FinishTokens()
+		if _, ok := err.(*json.SyntaxError); !ok {
+			t.Fatalf("expected SyntaxError but got %v", err)
+		}
+		assertErr(t, enc.WriteToken(json.Delim('[')))
+		err = enc.FinishTokens()
+		if err == nil {
+			t.Fatal("expected error")
+		}
+		assertEq(t, "message", "unexpected end of tokens: 2 unclosed '['", err.Error())
+		assertErr(t, enc.WriteToken(json.Delim(']')))
+		assertErr(t, enc.WriteToken(json.Delim('}')))
+		assertErr(t, enc.FinishTokens())
+		assertEq(t, "finish", "{\"a\":[]}\n", buf.String())
+	})
+	t.Run("state is kept on error", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		assertErr(t, enc.WriteToken(json.Delim('[')))
+		if err := enc.WriteValue(make(chan int)); err == nil {
+			t.Fatal("expected error")
+		}
+		assertErr(t, enc.WriteValue(1))
+		assertErr(t, enc.WriteToken(json.Delim(']')))
+		assertEq(t, "recovered", "[1]\n", buf.String())
+	})
+}
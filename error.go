@@ -39,3 +39,12 @@ type UnsupportedTypeError = errors.UnsupportedTypeError
 type UnsupportedValueError = errors.UnsupportedValueError
 
 type PathError = errors.PathError
+
+// A FieldQueryError describes the fields of a field query that do not exist in the type to encode.
+type FieldQueryError = errors.FieldQueryError
+
+// A LineError describes the error of a record of JSON Lines returned by LineDecoder and LineEncoder.
+type LineError = errors.LineError
+
+// A SeqError describes the error of an element of the JSON text sequence read by the Decoder with UseJSONSeq.
+type SeqError = errors.SeqError
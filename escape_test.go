@@ -0,0 +1,80 @@
+package json_test
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+type escapeMarshaler struct{}
+
+func (escapeMarshaler) MarshalJSON() ([]byte, error) {
+	return []byte(`{"path":"/é","escaped":"\u00e9\/"}`), nil
+}
+
+type escapeRecord struct {
+	Name  string          `json:"名前"`
+	Path  string          `json:"path/to"`
+	Value escapeMarshaler `json:"value"`
+}
+
+func TestASCIIOnly(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		got, err := json.MarshalWithOption("héllo 😀 <\u2028>", json.ASCIIOnly())
+		assertErr(t, err)
+		assertEq(t, "escaped", `"h\u00e9llo \ud83d\ude00 \u003c\u2028\u003e"`, string(got))
+
+		var s string
+		assertErr(t, json.Unmarshal(got, &s))
+		assertEq(t, "decoded", "héllo 😀 <\u2028>", s)
+	})
+	t.Run("record", func(t *testing.T) {
+		v := escapeRecord{Name: "日本", Path: "/a"}
+		got, err := json.MarshalWithOption(v, json.ASCIIOnly())
+		assertErr(t, err)
+		assertEq(t, "escaped", `{"\u540d\u524d":"\u65e5\u672c","path/to":"/a","value":{"path":"/\u00e9","escaped":"\u00e9\/"}}`, string(got))
+
+		got, err = json.MarshalIndentWithOption(map[string]string{"é": "ü"}, "", "  ", json.ASCIIOnly())
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n  \"\\u00e9\": \"\\u00fc\"\n}", string(got))
+
+		got, err = json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "without option", `{"名前":"日本","path/to":"/a","value":{"path":"/é","escaped":"\u00e9\/"}}`, string(got))
+	})
+	t.Run("invalid utf8", func(t *testing.T) {
+		got, err := json.MarshalWithOption("a\xffb", json.ASCIIOnly(), json.DisableNormalizeUTF8())
+		assertErr(t, err)
+		assertEq(t, "escaped", `"a\ufffdb"`, string(got))
+	})
+}
+
+func TestEscapeChars(t *testing.T) {
+	t.Run("string", func(t *testing.T) {
+		got, err := json.MarshalWithOption("</script> 'x'", json.EscapeChars("/'"))
+		assertErr(t, err)
+		assertEq(t, "escaped", `"\u003c\/script\u003e \u0027x\u0027"`, string(got))
+
+		var s string
+		assertErr(t, json.Unmarshal(got, &s))
+		assertEq(t, "decoded", "</script> 'x'", s)
+
+		got, err = json.MarshalWithOption("a/é", json.EscapeChars("/é"), json.DisableHTMLEscape())
+		assertErr(t, err)
+		assertEq(t, "non-ASCII chars", `"a\/é"`, string(got))
+	})
+	t.Run("record", func(t *testing.T) {
+		v := escapeRecord{Name: "a/b", Path: "/a"}
+		got, err := json.MarshalWithOption(v, json.EscapeChars("/"))
+		assertErr(t, err)
+		assertEq(t, "escaped", `{"名前":"a\/b","path\/to":"\/a","value":{"path":"\/é","escaped":"\u00e9\/"}}`, string(got))
+
+		got, err = json.MarshalWithOption(v, json.EscapeChars("a"))
+		assertErr(t, err)
+		assertEq(t, "other chars", `{"名前":"\u0061/b","p\u0061th/to":"/\u0061","v\u0061lue":{"p\u0061th":"/é","esc\u0061ped":"\u00e9\/"}}`, string(got))
+
+		got, err = json.MarshalWithOption(v, json.EscapeChars("/"), json.ASCIIOnly())
+		assertErr(t, err)
+		assertEq(t, "with ASCIIOnly", `{"\u540d\u524d":"a\/b","path\/to":"\/a","value":{"path":"\/\u00e9","escaped":"\u00e9\/"}}`, string(got))
+	})
+}
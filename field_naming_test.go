@@ -0,0 +1,125 @@
+package json_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+type fieldNamingUser struct {
+	UserID     int64
+	FirstName  string
+	HTTPServer string
+	Email      string `json:"mail"`
+	Ignored    string `json:"-"`
+	Note       string `json:",omitempty"`
+}
+
+type fieldNamingEmbedded struct {
+	CreatedAt string
+}
+
+type fieldNamingGroup struct {
+	fieldNamingEmbedded
+	GroupName string
+	Owner     *fieldNamingUser
+}
+
+func TestFieldNaming(t *testing.T) {
+	user := fieldNamingUser{UserID: 1, FirstName: "alice", HTTPServer: "srv", Email: "a@example.com", Ignored: "x"}
+
+	t.Run("encode", func(t *testing.T) {
+		for _, test := range []struct {
+			name   string
+			naming *json.FieldNamingStrategy
+			expect string
+		}{
+			{"snake_case", json.SnakeCase, `{"user_id":1,"first_name":"alice","http_server":"srv","mail":"a@example.com"}`},
+			{"camelCase", json.CamelCase, `{"userID":1,"firstName":"alice","httpServer":"srv","mail":"a@example.com"}`},
+			{"kebab-case", json.KebabCase, `{"user-id":1,"first-name":"alice","http-server":"srv","mail":"a@example.com"}`},
+			{"custom", json.NewFieldNamingStrategy("upper", strings.ToUpper), `{"USERID":1,"FIRSTNAME":"alice","HTTPSERVER":"srv","mail":"a@example.com"}`},
+		} {
+			got, err := json.MarshalWithOption(user, json.FieldNaming(test.naming))
+			assertErr(t, err)
+			assertEq(t, test.name, test.expect, string(got))
+		}
+		got, err := json.Marshal(user)
+		assertErr(t, err)
+		assertEq(t, "default", `{"UserID":1,"FirstName":"alice","HTTPServer":"srv","mail":"a@example.com"}`, string(got))
+	})
+	t.Run("encode with strategies created for each call", func(t *testing.T) {
+		prefixed := func(prefix string) func(string) string {
+			return func(name string) string {
+				return prefix + name
+			}
+		}
+		for i := 0; i < 3; i++ {
+			for _, prefix := range []string{"a_", "b_"} {
+				naming := json.NewFieldNamingStrategy("prefix", prefixed(prefix))
+				got, err := json.MarshalWithOption(struct{ ID int }{ID: i}, json.FieldNaming(naming))
+				assertErr(t, err)
+				assertEq(t, "prefixed", `{"`+prefix+`ID":`+string(rune('0'+i))+`}`, string(got))
+
+				var decoded struct{ ID int }
+				assertErr(t, json.UnmarshalWithOption(got, &decoded, json.DecodeFieldNaming(naming)))
+				assertEq(t, "decoded", i, decoded.ID)
+			}
+		}
+		got, err := json.MarshalWithOption(struct{ ID int }{}, json.FieldNaming(json.NewFieldNamingStrategy("case", strings.ToUpper)))
+		assertErr(t, err)
+		assertEq(t, "upper", `{"ID":0}`, string(got))
+		got, err = json.MarshalWithOption(struct{ ID int }{}, json.FieldNaming(json.NewFieldNamingStrategy("case", strings.ToLower)))
+		assertErr(t, err)
+		assertEq(t, "same name", `{"id":0}`, string(got))
+	})
+	t.Run("encode nested and embedded", func(t *testing.T) {
+		group := fieldNamingGroup{
+			fieldNamingEmbedded: fieldNamingEmbedded{CreatedAt: "now"},
+			GroupName:           "g",
+			Owner:               &fieldNamingUser{UserID: 2},
+		}
+		got, err := json.MarshalWithOption(group, json.FieldNaming(json.SnakeCase))
+		assertErr(t, err)
+		assertEq(t, "nested", `{"created_at":"now","group_name":"g","owner":{"user_id":2,"first_name":"","http_server":"","mail":""}}`, string(got))
+	})
+	t.Run("encode with tag key", func(t *testing.T) {
+		type T struct {
+			UserID int64 `api:"id"`
+			Name   string
+		}
+		got, err := json.MarshalWithOption(T{UserID: 1, Name: "a"}, json.TagKey("api"), json.FieldNaming(json.KebabCase))
+		assertErr(t, err)
+		assertEq(t, "tag key", `{"id":1,"name":"a"}`, string(got))
+	})
+	t.Run("decode", func(t *testing.T) {
+		var v fieldNamingUser
+		src := []byte(`{"user_id":3,"first_name":"bob","http_server":"s","mail":"b@example.com","UserID":99}`)
+		assertErr(t, json.UnmarshalWithOption(src, &v, json.DecodeFieldNaming(json.SnakeCase)))
+		assertEq(t, "user id", int64(3), v.UserID)
+		assertEq(t, "first name", "bob", v.FirstName)
+		assertEq(t, "http server", "s", v.HTTPServer)
+		assertEq(t, "email", "b@example.com", v.Email)
+
+		var def fieldNamingUser
+		assertErr(t, json.Unmarshal(src, &def))
+		assertEq(t, "default user id", int64(99), def.UserID)
+		assertEq(t, "default first name", "", def.FirstName)
+	})
+	t.Run("decode nested and embedded", func(t *testing.T) {
+		var v fieldNamingGroup
+		src := []byte(`{"created-at":"now","group-name":"g","owner":{"user-id":4}}`)
+		assertErr(t, json.UnmarshalWithOption(src, &v, json.DecodeFieldNaming(json.KebabCase)))
+		assertEq(t, "created at", "now", v.CreatedAt)
+		assertEq(t, "group name", "g", v.GroupName)
+		assertEq(t, "owner", int64(4), v.Owner.UserID)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		b, err := json.MarshalWithOption(user, json.FieldNaming(json.CamelCase))
+		assertErr(t, err)
+		var v fieldNamingUser
+		assertErr(t, json.UnmarshalWithOption(b, &v, json.DecodeFieldNaming(json.CamelCase)))
+		user.Ignored = ""
+		assertEq(t, "round trip", user, v)
+	})
+}
@@ -0,0 +1,180 @@
+package json_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+type formatEvent struct {
+	Unix      time.Time     `json:"unix,format:unix"`
+	UnixMilli time.Time     `json:"unix_milli,format:unixmilli"`
+	RFC3339   time.Time     `json:"rfc3339,format:RFC3339Nano"`
+	DateOnly  *time.Time    `json:"date,format:DateOnly"`
+	Layout    time.Time     `json:"layout,format:2006/01/02"`
+	Timeout   time.Duration `json:"timeout,format:units"`
+	Interval  time.Duration `json:"interval,format:sec"`
+	Hex       []byte        `json:"hex,format:hex"`
+	Base64URL []byte        `json:"b64url,format:base64url"`
+	Array     []byte        `json:"array,format:array"`
+}
+
+func TestFormatTagOption(t *testing.T) {
+	ts := time.Date(2024, 5, 6, 7, 8, 9, 500000000, time.UTC)
+	date := time.Date(2024, 5, 6, 0, 0, 0, 0, time.UTC)
+	v := formatEvent{
+		Unix:      ts,
+		UnixMilli: ts,
+		RFC3339:   ts,
+		DateOnly:  &date,
+		Layout:    date,
+		Timeout:   90 * time.Second,
+		Interval:  1500 * time.Millisecond,
+		Hex:       []byte{0xde, 0xad},
+		Base64URL: []byte{0xfb, 0xff},
+		Array:     []byte{1, 2},
+	}
+	expected := `{"unix":1714979289.5,"unix_milli":1714979289500,"rfc3339":"2024-05-06T07:08:09.5Z","date":"2024-05-06","layout":"2024/05/06","timeout":"1m30s","interval":1.5,"hex":"dead","b64url":"-_8=","array":[1,2]}`
+
+	t.Run("encode", func(t *testing.T) {
+		got, err := json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "event", expected, string(got))
+
+		got, err = json.Marshal(&formatEvent{})
+		assertErr(t, err)
+		assertEq(t, "zero", `{"unix":-62135596800,"unix_milli":-62135596800000,"rfc3339":"0001-01-01T00:00:00Z","date":null,"layout":"0001/01/01","timeout":"0s","interval":0,"hex":null,"b64url":null,"array":null}`, string(got))
+	})
+	t.Run("encode indent", func(t *testing.T) {
+		got, err := json.MarshalIndent(struct {
+			Array []byte `json:"array,format:array"`
+		}{Array: []byte{1, 2}}, "", "  ")
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n  \"array\": [\n    1,\n    2\n  ]\n}", string(got))
+	})
+	t.Run("omitempty", func(t *testing.T) {
+		got, err := json.Marshal(struct {
+			Timeout time.Duration `json:"timeout,omitempty,format:units"`
+			Hex     []byte        `json:"hex,omitempty,format:hex"`
+			Date    *time.Time    `json:"date,omitempty,format:DateOnly"`
+			Name    string        `json:"name"`
+		}{Name: "a"})
+		assertErr(t, err)
+		assertEq(t, "omitempty", `{"name":"a"}`, string(got))
+	})
+	t.Run("decode", func(t *testing.T) {
+		var got formatEvent
+		assertErr(t, json.Unmarshal([]byte(expected), &got))
+		assertEq(t, "unix", true, got.Unix.Equal(ts))
+		assertEq(t, "unix milli", true, got.UnixMilli.Equal(ts))
+		assertEq(t, "rfc3339", true, got.RFC3339.Equal(ts))
+		assertEq(t, "date", true, got.DateOnly.Equal(date))
+		assertEq(t, "layout", true, got.Layout.Equal(date))
+		assertEq(t, "timeout", v.Timeout, got.Timeout)
+		assertEq(t, "interval", v.Interval, got.Interval)
+		assertEq(t, "hex", true, bytes.Equal(v.Hex, got.Hex))
+		assertEq(t, "base64url", true, bytes.Equal(v.Base64URL, got.Base64URL))
+		assertEq(t, "array", true, bytes.Equal(v.Array, got.Array))
+	})
+	t.Run("decode stream", func(t *testing.T) {
+		var got formatEvent
+		assertErr(t, json.NewDecoder(strings.NewReader(expected)).Decode(&got))
+		assertEq(t, "unix", true, got.Unix.Equal(ts))
+		assertEq(t, "timeout", v.Timeout, got.Timeout)
+	})
+	t.Run("decode negative unix", func(t *testing.T) {
+		var got struct {
+			T time.Time `json:"t,format:unix"`
+		}
+		assertErr(t, json.Unmarshal([]byte(`{"t":-1.5}`), &got))
+		assertEq(t, "negative", true, got.T.Equal(time.Unix(-1, -500000000)))
+	})
+	t.Run("encode negative unix", func(t *testing.T) {
+		for _, tc := range []struct {
+			t        time.Time
+			expected string
+		}{
+			{time.Unix(-1, -500000000), `{"t":-1.5}`},
+			{time.Unix(0, -250000000), `{"t":-0.25}`},
+			{time.Unix(-2, 0), `{"t":-2}`},
+		} {
+			got, err := json.Marshal(struct {
+				T time.Time `json:"t,format:unix"`
+			}{T: tc.t})
+			assertErr(t, err)
+			assertEq(t, "negative", tc.expected, string(got))
+
+			var decoded struct {
+				T time.Time `json:"t,format:unix"`
+			}
+			assertErr(t, json.Unmarshal(got, &decoded))
+			assertEq(t, "round trip", true, decoded.T.Equal(tc.t))
+		}
+	})
+	t.Run("decode array", func(t *testing.T) {
+		var got struct {
+			Array []byte `json:"array,format:array"`
+		}
+		assertErr(t, json.Unmarshal([]byte(`{"array":[ 0, 255 ,7 ]}`), &got))
+		assertEq(t, "array", true, bytes.Equal([]byte{0, 255, 7}, got.Array))
+		assertErr(t, json.Unmarshal([]byte(`{"array":[]}`), &got))
+		assertEq(t, "empty", 0, len(got.Array))
+		for _, src := range []string{`{"array":[256]}`, `{"array":[-1]}`, `{"array":["a"]}`, `{"array":[1,]}`} {
+			if err := json.Unmarshal([]byte(src), &got); err == nil {
+				t.Errorf("expected error for %s", src)
+			}
+		}
+	})
+	t.Run("decode type error", func(t *testing.T) {
+		var got formatEvent
+		err := json.Unmarshal([]byte(`{"timeout":10}`), &got)
+		if _, ok := err.(*json.UnmarshalTypeError); !ok {
+			t.Fatalf("expected UnmarshalTypeError but got %v", err)
+		}
+	})
+	t.Run("decode exponent", func(t *testing.T) {
+		var got struct {
+			T  time.Time     `json:"t,format:unix"`
+			TM time.Time     `json:"tm,format:unixmilli"`
+			D  time.Duration `json:"d,format:milli"`
+			DN time.Duration `json:"dn,format:nano"`
+		}
+		assertErr(t, json.Unmarshal([]byte(`{"t":1.5e3,"tm":-1.5E3,"d":2.5e-3,"dn":1e9}`), &got))
+		assertEq(t, "unix", true, got.T.Equal(time.Unix(1500, 0)))
+		assertEq(t, "unixmilli", true, got.TM.Equal(time.Unix(-1, -500000000)))
+		assertEq(t, "milli", 2500*time.Nanosecond, got.D)
+		assertEq(t, "nano", time.Second, got.DN)
+	})
+	t.Run("decode out of range", func(t *testing.T) {
+		for _, src := range []string{`{"t":1e20}`, `{"t":-9.99e18}`, `{"t":1e999999999999}`, `{"d":1e10}`} {
+			var got struct {
+				T time.Time     `json:"t,format:unix"`
+				D time.Duration `json:"d,format:sec"`
+			}
+			err := json.Unmarshal([]byte(src), &got)
+			typeErr, ok := err.(*json.UnmarshalTypeError)
+			if !ok {
+				t.Fatalf("expected UnmarshalTypeError for %s but got %v", src, err)
+			}
+			if typeErr.Field == "" {
+				t.Fatalf("expected field in %v", typeErr)
+			}
+		}
+	})
+	t.Run("unsupported format", func(t *testing.T) {
+		if _, err := json.Marshal(struct {
+			S string `json:"s,format:hex"`
+		}{}); err == nil {
+			t.Fatal("expected error")
+		}
+		var v struct {
+			D time.Duration `json:"d,format:unknown"`
+		}
+		if err := json.Unmarshal([]byte(`{}`), &v); err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
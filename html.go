@@ -0,0 +1,80 @@
+package json
+
+import (
+	"bytes"
+
+	"github.com/goccy/go-json/internal/encoder"
+)
+
+type HTMLScheme = encoder.HTMLScheme
+
+// DefaultHTMLScheme is the HTMLScheme with the CSS classes prefixed by "json-".
+var DefaultHTMLScheme = &HTMLScheme{
+	Number:       "json-number",
+	String:       "json-string",
+	Bool:         "json-bool",
+	Null:         "json-null",
+	Key:          "json-key",
+	Object:       "json-object",
+	Array:        "json-array",
+	Members:      "json-members",
+	Punctuation:  "json-punctuation",
+	AnchorPrefix: "json-",
+}
+
+// IndentHTML appends to dst the indented form of the JSON-encoded src in the same way as Indent, rendered as HTML by scheme.
+// The output is meant to be embedded in the <pre> element.
+//
+// Each value is the <span> element with the class for its kind and the data-path attribute of its JSONPath,
+// such as `$.items[0]['first name']`. The members of the objects and the elements of the arrays are wrapped in the
+// <span> element with the Members class, so that the containers can be collapsed by hiding it.
+// Each object key is the <a> element with the Key class, whose id is AnchorPrefix followed by the JSONPath of the member
+// and which links to itself. The space characters, the non-ASCII characters and the characters such as '%', '#', '&', '<' and '>'
+// in the id are percent-encoded ( e.g. json-$['first%20name'] ). The text of the strings is escaped for HTML.
+// If scheme is nil, DefaultHTMLScheme is used.
+//
+// For example, {"a":[1]} is rendered with DefaultHTMLScheme and indent "  " as follows.
+//
+//	<span class="json-object" data-path="$"><span class="json-punctuation">{</span><span class="json-members">
+//	  <a class="json-key" id="json-$.a" href="#json-$.a">"a"</a><span class="json-punctuation">:</span> <span class="json-array" data-path="$.a"><span class="json-punctuation">[</span><span class="json-members">
+//	    <span class="json-number" data-path="$.a[0]">1</span>
+//	  </span><span class="json-punctuation">]</span></span>
+//	</span><span class="json-punctuation">}</span></span>
+func IndentHTML(dst *bytes.Buffer, src []byte, prefix, indent string, scheme *HTMLScheme) error {
+	if scheme == nil {
+		scheme = DefaultHTMLScheme
+	}
+	return encoder.IndentHTML(dst, src, prefix, indent, scheme)
+}
+
+// CompactHTML appends to dst the JSON-encoded src with insignificant space characters elided in the same way as Compact,
+// rendered as HTML by scheme in the same way as IndentHTML.
+func CompactHTML(dst *bytes.Buffer, src []byte, scheme *HTMLScheme) error {
+	if scheme == nil {
+		scheme = DefaultHTMLScheme
+	}
+	return encoder.CompactHTML(dst, src, scheme)
+}
+
+// marshalHTML encodes v and renders it as HTML by the scheme of the HTML option.
+// The JSON is not flushed to the writer of ctx since it is rendered as a whole.
+func marshalHTML(ctx *encoder.RuntimeContext, v interface{}, prefix, indent string, indented bool) ([]byte, error) {
+	ctx.Option.Flag &= ^(encoder.IndentOption | encoder.ColorizeOption)
+	ctx.Writer = nil
+	buf, err := encode(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+	buf = buf[:len(buf)-1]
+
+	var dst bytes.Buffer
+	if indented {
+		err = encoder.IndentHTML(&dst, buf, prefix, indent, ctx.Option.HTMLScheme)
+	} else {
+		err = encoder.CompactHTML(&dst, buf, ctx.Option.HTMLScheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return dst.Bytes(), nil
+}
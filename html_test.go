@@ -0,0 +1,146 @@
+package json_test
+
+import (
+	"bytes"
+	"context"
+	"html"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+func TestIndentHTML(t *testing.T) {
+	t.Run("markup", func(t *testing.T) {
+		var got bytes.Buffer
+		assertErr(t, json.IndentHTML(&got, []byte(` {"a" : [1]} `), "", "  ", nil))
+		expected := `
+<span class="json-object" data-path="$"><span class="json-punctuation">{</span><span class="json-members">
+  <a class="json-key" id="json-$.a" href="#json-$.a">"a"</a><span class="json-punctuation">:</span> <span class="json-array" data-path="$.a"><span class="json-punctuation">[</span><span class="json-members">
+    <span class="json-number" data-path="$.a[0]">1</span>
+  </span><span class="json-punctuation">]</span></span>
+</span><span class="json-punctuation">}</span></span>`
+		assertEq(t, "html", expected[1:], got.String())
+	})
+	t.Run("compact", func(t *testing.T) {
+		var got bytes.Buffer
+		assertErr(t, json.CompactHTML(&got, []byte(`[ {}, [], "s", true, null ]`), &json.HTMLScheme{Null: "n", Punctuation: "p"}))
+		expected := `<span data-path="$"><span class="p">[</span><span>` +
+			`<span data-path="$[0]"><span class="p">{}</span></span><span class="p">,</span>` +
+			`<span data-path="$[1]"><span class="p">[]</span></span><span class="p">,</span>` +
+			`<span data-path="$[2]">"s"</span><span class="p">,</span>` +
+			`<span data-path="$[3]">true</span><span class="p">,</span>` +
+			`<span class="n" data-path="$[4]">null</span>` +
+			`</span><span class="p">]</span></span>`
+		assertEq(t, "html", expected, got.String())
+	})
+	t.Run("escape", func(t *testing.T) {
+		var got bytes.Buffer
+		assertErr(t, json.IndentHTML(&got, []byte(`{"<a>":"</span>&amp;","x'y":{"A":1},"sp aceé&%":0}`), "<", "\t", nil))
+		out := got.String()
+		for _, expected := range []string{
+			`<a class="json-key" id="json-$.%3Ca%3E" href="#json-$.%3Ca%3E">"&lt;a&gt;"</a>`,
+			`<span class="json-string" data-path="$.&lt;a&gt;">"&lt;/span&gt;&amp;amp;"</span>`,
+			`<span class="json-number" data-path="$[&#39;x\&#39;y&#39;].A">1</span>`,
+			"\n&lt;\t\t<a",
+			`<a class="json-key" id="json-$['sp%20ace%C3%A9%26%25']" href="#json-$['sp%20ace%C3%A9%26%25']">"sp aceé&amp;%"</a>`,
+		} {
+			if !strings.Contains(out, expected) {
+				t.Fatalf("expected %q in html:\n%s", expected, out)
+			}
+		}
+	})
+	t.Run("error", func(t *testing.T) {
+		for _, src := range []string{``, `{"a":1,}`, `[1 2]`, `{"a" 1}`, `nul`, `[] []`} {
+			if err := json.IndentHTML(&bytes.Buffer{}, []byte(src), "", "  ", nil); err == nil {
+				t.Fatalf("expected error for %q", src)
+			}
+			if err := json.CompactHTML(&bytes.Buffer{}, []byte(src), nil); err == nil {
+				t.Fatalf("expected error for %q", src)
+			}
+		}
+	})
+}
+
+func TestMarshalHTML(t *testing.T) {
+	v := struct {
+		Name  string            `json:"name"`
+		Items []int             `json:"items"`
+		Attrs map[string]string `json:"attrs"`
+	}{
+		Name:  "<b>",
+		Items: []int{1, 2},
+		Attrs: map[string]string{"first name": "a&b"},
+	}
+	src, err := json.MarshalWithOption(v, json.DisableHTMLEscape())
+	assertErr(t, err)
+
+	var expected bytes.Buffer
+	assertErr(t, json.IndentHTML(&expected, src, ">", "  ", nil))
+	got, err := json.MarshalIndentWithOption(v, ">", "  ", json.Colorize(json.DefaultColorScheme), json.HTML(nil))
+	assertErr(t, err)
+	assertEq(t, "indent", expected.String(), string(got))
+
+	expected.Reset()
+	assertErr(t, json.CompactHTML(&expected, src, json.DefaultHTMLScheme))
+	got, err = json.MarshalWithOption(v, json.HTML(json.DefaultHTMLScheme))
+	assertErr(t, err)
+	assertEq(t, "compact", expected.String(), string(got))
+
+	// the paths of the values can be used with CreatePath.
+	paths := regexp.MustCompile(`data-path="([^"]+)">([^<]+)</span>`).FindAllStringSubmatch(string(got), -1)
+	assertEq(t, "number of scalars", 4, len(paths))
+	for _, match := range paths {
+		path, err := json.CreatePath(html.UnescapeString(match[1]))
+		assertErr(t, err)
+		values, err := path.Extract(src)
+		assertErr(t, err)
+		assertEq(t, "value of "+match[1], html.UnescapeString(match[2]), string(bytes.Join(values, nil)))
+	}
+}
+
+func TestHTMLEntryPoints(t *testing.T) {
+	v := map[string]interface{}{"a": []int{1}}
+	expected, err := json.MarshalWithOption(v, json.HTML(nil))
+	assertErr(t, err)
+	expectedIndent, err := json.MarshalIndentWithOption(v, "", "  ", json.HTML(nil))
+	assertErr(t, err)
+
+	got, err := json.MarshalContext(context.Background(), v, json.HTML(nil))
+	assertErr(t, err)
+	assertEq(t, "context", string(expected), string(got))
+
+	got, err = json.AppendMarshal([]byte("x"), v, json.HTML(nil))
+	assertErr(t, err)
+	assertEq(t, "append", "x"+string(expected), string(got))
+
+	got, err = json.AppendMarshalIndent([]byte("x"), v, "", "  ", json.HTML(nil))
+	assertErr(t, err)
+	assertEq(t, "append indent", "x"+string(expectedIndent), string(got))
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetFlushThreshold(1)
+	assertErr(t, enc.EncodeWithOption(v, json.HTML(nil)))
+	enc.SetIndent("", "  ")
+	assertErr(t, enc.EncodeWithOption(v, json.HTML(nil)))
+	assertEq(t, "encoder", string(expected)+"\n"+string(expectedIndent)+"\n", buf.String())
+}
+
+func TestHTMLPathEscape(t *testing.T) {
+	src := []byte(`{"it's":{"a\\b":1}}`)
+	var dst bytes.Buffer
+	assertErr(t, json.CompactHTML(&dst, src, nil))
+	match := regexp.MustCompile(`data-path="([^"]+)">1</span>`).FindStringSubmatch(dst.String())
+	if match == nil {
+		t.Fatalf("path not found in %s", dst.String())
+	}
+	pathText := html.UnescapeString(match[1])
+	assertEq(t, "path", `$['it\'s']['a\\b']`, pathText)
+	path, err := json.CreatePath(pathText)
+	assertErr(t, err)
+	values, err := path.Extract(src)
+	assertErr(t, err)
+	assertEq(t, "value", "1", string(bytes.Join(values, nil)))
+}
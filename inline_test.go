@@ -0,0 +1,253 @@
+package json_test
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+type inlineRecord struct {
+	ID    int                    `json:"id"`
+	Extra map[string]interface{} `json:",inline"`
+	Name  string                 `json:"name"`
+}
+
+type unknownRecord struct {
+	ID      int                        `json:"id"`
+	Unknown map[string]json.RawMessage `json:",unknown"`
+}
+
+type embeddedInlineRecord struct {
+	inlineRecord
+	Age int `json:"age"`
+}
+
+type InlinePtrRecord struct {
+	ID    int                    `json:"id"`
+	Extra map[string]interface{} `json:",inline"`
+}
+
+type ptrEmbeddedInlineRecord struct {
+	*InlinePtrRecord
+	Age int `json:"age"`
+}
+
+func TestInlineFieldEncode(t *testing.T) {
+	t.Run("flatten", func(t *testing.T) {
+		v := inlineRecord{
+			ID:    1,
+			Name:  "go",
+			Extra: map[string]interface{}{"b": 2, "a": []int{1}},
+		}
+		got, err := json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "record", `{"id":1,"a":[1],"b":2,"name":"go"}`, string(got))
+
+		got, err = json.Marshal(&v)
+		assertErr(t, err)
+		assertEq(t, "record pointer", `{"id":1,"a":[1],"b":2,"name":"go"}`, string(got))
+
+		got, err = json.MarshalIndent(v, "", "  ")
+		assertErr(t, err)
+		expected := `{
+  "id": 1,
+  "a": [
+    1
+  ],
+  "b": 2,
+  "name": "go"
+}`
+		assertEq(t, "indent", expected, string(got))
+	})
+	t.Run("empty", func(t *testing.T) {
+		got, err := json.Marshal(inlineRecord{ID: 1})
+		assertErr(t, err)
+		assertEq(t, "nil map", `{"id":1,"name":""}`, string(got))
+
+		got, err = json.Marshal(unknownRecord{Unknown: map[string]json.RawMessage{}})
+		assertErr(t, err)
+		assertEq(t, "empty map at the end", `{"id":0}`, string(got))
+
+		got, err = json.Marshal(struct {
+			Extra map[string]int `json:",inline"`
+		}{})
+		assertErr(t, err)
+		assertEq(t, "only inline field", `{}`, string(got))
+
+		got, err = json.MarshalIndent(struct {
+			Extra map[string]int `json:",inline"`
+		}{}, "", "  ")
+		assertErr(t, err)
+		assertEq(t, "only inline field with indent", `{}`, string(got))
+	})
+	t.Run("last field", func(t *testing.T) {
+		got, err := json.Marshal(unknownRecord{
+			ID:      1,
+			Unknown: map[string]json.RawMessage{"x": json.RawMessage(`{"y":true}`)},
+		})
+		assertErr(t, err)
+		assertEq(t, "raw message", `{"id":1,"x":{"y":true}}`, string(got))
+	})
+	t.Run("unordered map", func(t *testing.T) {
+		got, err := json.MarshalWithOption(unknownRecord{
+			ID:      1,
+			Unknown: map[string]json.RawMessage{"x": json.RawMessage(`1`)},
+		}, json.UnorderedMap())
+		assertErr(t, err)
+		assertEq(t, "unordered", `{"id":1,"x":1}`, string(got))
+	})
+	t.Run("embedded", func(t *testing.T) {
+		got, err := json.Marshal(embeddedInlineRecord{
+			inlineRecord: inlineRecord{ID: 1, Extra: map[string]interface{}{"x": true}},
+			Age:          2,
+		})
+		assertErr(t, err)
+		assertEq(t, "embedded", `{"id":1,"x":true,"name":"","age":2}`, string(got))
+	})
+	t.Run("duplicate keys", func(t *testing.T) {
+		v := embeddedInlineRecord{
+			inlineRecord: inlineRecord{
+				ID:    1,
+				Name:  "n",
+				Extra: map[string]interface{}{"name": "dup", "z": 2, "age": 4, "id": 5},
+			},
+			Age: 3,
+		}
+		got, err := json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "declared fields win", `{"id":1,"z":2,"name":"n","age":3}`, string(got))
+
+		got, err = json.MarshalWithOption(v, json.UnorderedMap())
+		assertErr(t, err)
+		assertEq(t, "unordered", `{"id":1,"z":2,"name":"n","age":3}`, string(got))
+
+		v.Extra = map[string]interface{}{"name": "dup", "age": 4}
+		got, err = json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "only duplicate keys", `{"id":1,"name":"n","age":3}`, string(got))
+
+		got, err = json.MarshalWithOption(v, json.UnorderedMap())
+		assertErr(t, err)
+		assertEq(t, "only duplicate keys unordered", `{"id":1,"name":"n","age":3}`, string(got))
+
+		got, err = json.MarshalIndent(unknownRecord{
+			ID:      1,
+			Unknown: map[string]json.RawMessage{"id": json.RawMessage(`2`), "x": json.RawMessage(`3`)},
+		}, "", "  ")
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n  \"id\": 1,\n  \"x\": 3\n}", string(got))
+	})
+	t.Run("field query", func(t *testing.T) {
+		v := embeddedInlineRecord{
+			inlineRecord: inlineRecord{
+				ID:    1,
+				Name:  "n",
+				Extra: map[string]interface{}{"x": true, "y": 2, "name": "dup"},
+			},
+			Age: 3,
+		}
+		for _, tc := range []struct {
+			name     string
+			fields   []json.FieldQueryString
+			expected string
+		}{
+			{"inline keys", []json.FieldQueryString{"id", "x"}, `{"id":1,"x":true}`},
+			{"duplicate key", []json.FieldQueryString{"name"}, `{"name":"n"}`},
+			{"whole map", []json.FieldQueryString{"Extra", "age"}, `{"x":true,"y":2,"age":3}`},
+		} {
+			query, err := json.BuildFieldQuery(tc.fields...)
+			assertErr(t, err)
+			got, err := json.MarshalContext(json.SetFieldQueryToContext(context.Background(), query), v)
+			assertErr(t, err)
+			assertEq(t, tc.name, tc.expected, string(got))
+		}
+	})
+	t.Run("unsupported type", func(t *testing.T) {
+		_, err := json.Marshal(struct {
+			Extra []int `json:",inline"`
+		}{})
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
+
+func TestInlineFieldDecode(t *testing.T) {
+	src := []byte(`{"id":1,"name":"go","x":{"y":[1,2]},"Name2":null,"esc\u0061ped":"v"}`)
+	expected := inlineRecord{
+		ID:   1,
+		Name: "go",
+		Extra: map[string]interface{}{
+			"x":       map[string]interface{}{"y": []interface{}{1.0, 2.0}},
+			"Name2":   nil,
+			"escaped": "v",
+		},
+	}
+	t.Run("unmarshal", func(t *testing.T) {
+		var v inlineRecord
+		assertErr(t, json.Unmarshal(src, &v))
+		if !reflect.DeepEqual(expected, v) {
+			t.Fatalf("failed to decode inline field: %+v", v)
+		}
+	})
+	t.Run("stream", func(t *testing.T) {
+		var v inlineRecord
+		assertErr(t, json.NewDecoder(bytes.NewReader(src)).Decode(&v))
+		if !reflect.DeepEqual(expected, v) {
+			t.Fatalf("failed to decode inline field: %+v", v)
+		}
+	})
+	t.Run("case insensitive field", func(t *testing.T) {
+		var v inlineRecord
+		assertErr(t, json.Unmarshal([]byte(`{"ID":1,"other":2}`), &v))
+		assertEq(t, "id", 1, v.ID)
+		assertEq(t, "extra", 1, len(v.Extra))
+	})
+	t.Run("raw message", func(t *testing.T) {
+		var v unknownRecord
+		assertErr(t, json.Unmarshal(src, &v))
+		assertEq(t, "id", 1, v.ID)
+		assertEq(t, "name", `"go"`, string(v.Unknown["name"]))
+		assertEq(t, "x", `{"y":[1,2]}`, string(v.Unknown["x"]))
+	})
+	t.Run("disallow unknown fields", func(t *testing.T) {
+		var v unknownRecord
+		dec := json.NewDecoder(bytes.NewReader(src))
+		dec.DisallowUnknownFields()
+		assertErr(t, dec.Decode(&v))
+		assertEq(t, "unknown", 4, len(v.Unknown))
+	})
+	t.Run("first win", func(t *testing.T) {
+		var v inlineRecord
+		assertErr(t, json.UnmarshalWithOption([]byte(`{"id":1,"name":"a","id":2,"x":3}`), &v, json.DecodeFieldPriorityFirstWin()))
+		assertEq(t, "id", 1, v.ID)
+		assertEq(t, "x", 3.0, v.Extra["x"])
+	})
+	t.Run("embedded", func(t *testing.T) {
+		var v embeddedInlineRecord
+		assertErr(t, json.Unmarshal([]byte(`{"id":1,"age":2,"x":3}`), &v))
+		assertEq(t, "age", 2, v.Age)
+		assertEq(t, "x", 3.0, v.Extra["x"])
+	})
+	t.Run("embedded pointer", func(t *testing.T) {
+		var v ptrEmbeddedInlineRecord
+		assertErr(t, json.Unmarshal([]byte(`{"id":1,"age":2,"x":3}`), &v))
+		assertEq(t, "age", 2, v.Age)
+		assertEq(t, "id", 1, v.ID)
+		assertEq(t, "x", 3.0, v.Extra["x"])
+
+		var streamed ptrEmbeddedInlineRecord
+		assertErr(t, json.NewDecoder(bytes.NewReader([]byte(`{"x":3}`))).Decode(&streamed))
+		assertEq(t, "stream", 3.0, streamed.Extra["x"])
+	})
+	t.Run("roundtrip", func(t *testing.T) {
+		var v unknownRecord
+		assertErr(t, json.Unmarshal([]byte(`{"id":1,"b":[true],"a":"s"}`), &v))
+		got, err := json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "roundtrip", `{"id":1,"a":"s","b":[true]}`, string(got))
+	})
+}
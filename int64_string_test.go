@@ -0,0 +1,107 @@
+package json_test
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+type int64StringRecord struct {
+	ID       int64            `json:"id"`
+	Count    uint64           `json:"count"`
+	Small    int32            `json:"small"`
+	Ptr      *int64           `json:"ptr"`
+	Nil      *uint64          `json:"nil"`
+	Empty    int64            `json:"empty,omitempty"`
+	Tagged   int64            `json:"tagged,string"`
+	Children []int64          `json:"children"`
+	Scores   map[string]int64 `json:"scores"`
+}
+
+func TestInt64String(t *testing.T) {
+	id := int64(-42)
+	v := int64StringRecord{
+		ID:       math.MaxInt64,
+		Count:    7,
+		Small:    3,
+		Ptr:      &id,
+		Tagged:   5,
+		Children: []int64{1, 1 << 53},
+		Scores:   map[string]int64{"a": -(1 << 53)},
+	}
+
+	t.Run("always", func(t *testing.T) {
+		got, err := json.MarshalWithOption(v, json.Int64String())
+		assertErr(t, err)
+		assertEq(t, "record", `{"id":"9223372036854775807","count":"7","small":3,"ptr":"-42","nil":null,"tagged":"5","children":["1","9007199254740992"],"scores":{"a":"-9007199254740992"}}`, string(got))
+
+		got, err = json.MarshalWithOption(&id, json.Int64String())
+		assertErr(t, err)
+		assertEq(t, "pointer", `"-42"`, string(got))
+
+		got, err = json.MarshalWithOption(map[int64]uint64{1: 2}, json.Int64String())
+		assertErr(t, err)
+		assertEq(t, "map", `{"1":"2"}`, string(got))
+	})
+	t.Run("unsafe only", func(t *testing.T) {
+		got, err := json.MarshalWithOption(v, json.UnsafeInt64String())
+		assertErr(t, err)
+		assertEq(t, "record", `{"id":"9223372036854775807","count":7,"small":3,"ptr":-42,"nil":null,"tagged":"5","children":[1,"9007199254740992"],"scores":{"a":"-9007199254740992"}}`, string(got))
+
+		got, err = json.MarshalWithOption([]interface{}{uint64(1<<53 - 1), int64(-(1<<53 - 1)), uint64(math.MaxUint64)}, json.UnsafeInt64String())
+		assertErr(t, err)
+		assertEq(t, "boundary", `[9007199254740991,-9007199254740991,"18446744073709551615"]`, string(got))
+	})
+	t.Run("indent", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		assertErr(t, enc.EncodeWithOption(struct {
+			A int64 `json:"a"`
+			B int64 `json:"b"`
+		}{A: 1, B: math.MinInt64}, json.UnsafeInt64String()))
+		assertEq(t, "indent", "{\n  \"a\": 1,\n  \"b\": \"-9223372036854775808\"\n}\n", buf.String())
+	})
+	t.Run("disabled", func(t *testing.T) {
+		got, err := json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "record", `{"id":9223372036854775807,"count":7,"small":3,"ptr":-42,"nil":null,"tagged":"5","children":[1,9007199254740992],"scores":{"a":-9007199254740992}}`, string(got))
+	})
+	t.Run("decode", func(t *testing.T) {
+		src := `{"id":"9223372036854775807","count":7,"ptr":"-42","children":[1,"9007199254740992"],"scores":{"a":"-9007199254740992"}}`
+		var got int64StringRecord
+		assertErr(t, json.UnmarshalWithOption([]byte(src), &got, json.DecodeInt64String()))
+		assertEq(t, "id", int64(math.MaxInt64), got.ID)
+		assertEq(t, "count", uint64(7), got.Count)
+		assertEq(t, "ptr", int64(-42), *got.Ptr)
+		assertEq(t, "children", int64(1<<53), got.Children[1])
+		assertEq(t, "scores", int64(-(1 << 53)), got.Scores["a"])
+
+		var stream int64StringRecord
+		dec := json.NewDecoder(strings.NewReader(src))
+		assertErr(t, dec.DecodeWithOption(&stream, json.DecodeInt64String()))
+		assertEq(t, "stream id", int64(math.MaxInt64), stream.ID)
+		assertEq(t, "stream ptr", int64(-42), *stream.Ptr)
+		assertEq(t, "stream children", int64(1<<53), stream.Children[1])
+	})
+	t.Run("decode errors", func(t *testing.T) {
+		var got int64StringRecord
+		if err := json.Unmarshal([]byte(`{"id":"1"}`), &got); err == nil {
+			t.Fatal("expected error without option")
+		}
+		if err := json.UnmarshalWithOption([]byte(`{"small":"1"}`), &got, json.DecodeInt64String()); err == nil {
+			t.Fatal("expected error for 32-bit integer")
+		}
+		for _, src := range []string{`{"id":"1}`, `{"id":"x"}`, `{"id":"null"}`, `{"id":"1 "}`} {
+			if err := json.UnmarshalWithOption([]byte(src), &got, json.DecodeInt64String()); err == nil {
+				t.Fatalf("expected error for %s", src)
+			}
+			if err := json.NewDecoder(strings.NewReader(src)).DecodeWithOption(&got, json.DecodeInt64String()); err == nil {
+				t.Fatalf("expected stream error for %s", src)
+			}
+		}
+	})
+}
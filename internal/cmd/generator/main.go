@@ -208,6 +208,8 @@ func (t OpType) FieldToOmitEmptyField() OpType {
 		createOpType("RecursivePtr", "Op"),
 		createOpType("RecursiveEnd", "Op"),
 		createOpType("InterfaceEnd", "Op"),
+		createOpType("Custom", "Op"),
+		createOpType("CustomPtr", "Op"),
 	}
 	for _, typ := range primitiveTypesUpper {
 		typ := typ
@@ -158,7 +158,7 @@ func (d *arrayDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 					}
 					cursor = c
 				} else {
-					c, err := skipValue(buf, cursor, depth)
+					c, err := skipValueWithOption(ctx, cursor, depth)
 					if err != nil {
 						return 0, err
 					}
@@ -10,15 +10,18 @@ import (
 	"unicode"
 	"unsafe"
 
+	"github.com/goccy/go-json/internal/errors"
 	"github.com/goccy/go-json/internal/runtime"
 )
 
 var (
-	jsonNumberType   = reflect.TypeOf(json.Number(""))
-	typeAddr         *runtime.TypeAddr
-	cachedDecoderMap unsafe.Pointer // map[uintptr]decoder
-	cachedDecoder    []Decoder
-	initOnce         sync.Once
+	jsonNumberType         = reflect.TypeOf(json.Number(""))
+	typeAddr               *runtime.TypeAddr
+	cachedDecoderMap       unsafe.Pointer // map[uintptr]decoder
+	cachedTaggedDecoderMap unsafe.Pointer // map[taggedTypeKey]decoder
+	cachedDecoder          []Decoder
+	initOnce               sync.Once
+	cacheEvictions         uint64
 )
 
 func initDecoder() {
@@ -39,12 +42,9 @@ func loadDecoderMap() map[uintptr]Decoder {
 
 func storeDecoder(typ uintptr, dec Decoder, m map[uintptr]Decoder) {
 	initDecoder()
-	newDecoderMap := make(map[uintptr]Decoder, len(m)+1)
+	newDecoderMap, evicted := runtime.CopyCacheMap(m)
 	newDecoderMap[typ] = dec
-
-	for k, v := range m {
-		newDecoderMap[k] = v
-	}
+	atomic.AddUint64(&cacheEvictions, uint64(evicted))
 
 	atomic.StorePointer(&cachedDecoderMap, *(*unsafe.Pointer)(unsafe.Pointer(&newDecoderMap)))
 }
@@ -55,7 +55,7 @@ func compileToGetDecoderSlowPath(typeptr uintptr, typ *runtime.Type) (Decoder, e
 		return dec, nil
 	}
 
-	dec, err := compileHead(typ, map[uintptr]Decoder{})
+	dec, err := compileHead(typ, nil, map[uintptr]Decoder{})
 	if err != nil {
 		return nil, err
 	}
@@ -63,17 +63,64 @@ func compileToGetDecoderSlowPath(typeptr uintptr, typ *runtime.Type) (Decoder, e
 	return dec, nil
 }
 
-func compileHead(typ *runtime.Type, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
+// CacheStats returns the number of the cached decoders and the number of the decoders evicted from the caches.
+func CacheStats() (int, uint64) {
+	initDecoder()
+	size := countCachedDecoders() + len(loadDecoderMap()) + len(loadTaggedDecoderMap())
+	return size, atomic.LoadUint64(&cacheEvictions)
+}
+
+// taggedTypeKey identifies decoders compiled with a non-default StructTagOption.
+type taggedTypeKey struct {
+	tag string
+	typ uintptr
+}
+
+func loadTaggedDecoderMap() map[taggedTypeKey]Decoder {
+	p := atomic.LoadPointer(&cachedTaggedDecoderMap)
+	return *(*map[taggedTypeKey]Decoder)(unsafe.Pointer(&p))
+}
+
+func storeTaggedDecoder(key taggedTypeKey, dec Decoder, m map[taggedTypeKey]Decoder) {
+	newDecoderMap, evicted := runtime.CopyCacheMap(m)
+	newDecoderMap[key] = dec
+	atomic.AddUint64(&cacheEvictions, uint64(evicted))
+
+	atomic.StorePointer(&cachedTaggedDecoderMap, *(*unsafe.Pointer)(unsafe.Pointer(&newDecoderMap)))
+}
+
+func compileToGetDecoderWithStructTag(typ *runtime.Type, tagOpt *runtime.StructTagOption) (Decoder, error) {
+	key := taggedTypeKey{tag: tagOpt.CacheKey(), typ: uintptr(unsafe.Pointer(typ))}
+	decoderMap := loadTaggedDecoderMap()
+	if dec, exists := decoderMap[key]; exists {
+		return dec, nil
+	}
+
+	dec, err := compileHead(typ, tagOpt, map[uintptr]Decoder{})
+	if err != nil {
+		return nil, err
+	}
+	storeTaggedDecoder(key, dec, decoderMap)
+	return dec, nil
+}
+
+func compileHead(typ *runtime.Type, tagOpt *runtime.StructTagOption, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
+	if dec := lookupCustomDecoder(typ.Elem()); dec != nil {
+		return newCustomDecoder(dec, "", ""), nil
+	}
 	switch {
 	case implementsUnmarshalJSONType(runtime.PtrTo(typ)):
 		return newUnmarshalJSONDecoder(runtime.PtrTo(typ), "", ""), nil
 	case runtime.PtrTo(typ).Implements(unmarshalTextType):
 		return newUnmarshalTextDecoder(runtime.PtrTo(typ), "", ""), nil
 	}
-	return compile(typ.Elem(), "", "", structTypeToDecoder)
+	return compile(typ.Elem(), "", "", tagOpt, structTypeToDecoder)
 }
 
-func compile(typ *runtime.Type, structName, fieldName string, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
+func compile(typ *runtime.Type, structName, fieldName string, tagOpt *runtime.StructTagOption, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
+	if dec := lookupCustomDecoder(typ); dec != nil {
+		return newCustomDecoder(dec, structName, fieldName), nil
+	}
 	switch {
 	case implementsUnmarshalJSONType(runtime.PtrTo(typ)):
 		return newUnmarshalJSONDecoder(runtime.PtrTo(typ), structName, fieldName), nil
@@ -83,19 +130,19 @@ func compile(typ *runtime.Type, structName, fieldName string, structTypeToDecode
 
 	switch typ.Kind() {
 	case reflect.Ptr:
-		return compilePtr(typ, structName, fieldName, structTypeToDecoder)
+		return compilePtr(typ, structName, fieldName, tagOpt, structTypeToDecoder)
 	case reflect.Struct:
-		return compileStruct(typ, structName, fieldName, structTypeToDecoder)
+		return compileStruct(typ, structName, fieldName, tagOpt, structTypeToDecoder)
 	case reflect.Slice:
 		elem := typ.Elem()
 		if elem.Kind() == reflect.Uint8 {
 			return compileBytes(elem, structName, fieldName)
 		}
-		return compileSlice(typ, structName, fieldName, structTypeToDecoder)
+		return compileSlice(typ, structName, fieldName, tagOpt, structTypeToDecoder)
 	case reflect.Array:
-		return compileArray(typ, structName, fieldName, structTypeToDecoder)
+		return compileArray(typ, structName, fieldName, tagOpt, structTypeToDecoder)
 	case reflect.Map:
-		return compileMap(typ, structName, fieldName, structTypeToDecoder)
+		return compileMap(typ, structName, fieldName, tagOpt, structTypeToDecoder)
 	case reflect.Interface:
 		return compileInterface(typ, structName, fieldName)
 	case reflect.Uintptr:
@@ -156,14 +203,14 @@ func isStringTagSupportedType(typ *runtime.Type) bool {
 	return true
 }
 
-func compileMapKey(typ *runtime.Type, structName, fieldName string, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
+func compileMapKey(typ *runtime.Type, structName, fieldName string, tagOpt *runtime.StructTagOption, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
 	if runtime.PtrTo(typ).Implements(unmarshalTextType) {
 		return newUnmarshalTextDecoder(runtime.PtrTo(typ), structName, fieldName), nil
 	}
 	if typ.Kind() == reflect.String {
 		return newStringDecoder(structName, fieldName), nil
 	}
-	dec, err := compile(typ, structName, fieldName, structTypeToDecoder)
+	dec, err := compile(typ, structName, fieldName, tagOpt, structTypeToDecoder)
 	if err != nil {
 		return nil, err
 	}
@@ -181,8 +228,8 @@ func compileMapKey(typ *runtime.Type, structName, fieldName string, structTypeTo
 	}
 }
 
-func compilePtr(typ *runtime.Type, structName, fieldName string, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
-	dec, err := compile(typ.Elem(), structName, fieldName, structTypeToDecoder)
+func compilePtr(typ *runtime.Type, structName, fieldName string, tagOpt *runtime.StructTagOption, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
+	dec, err := compile(typ.Elem(), structName, fieldName, tagOpt, structTypeToDecoder)
 	if err != nil {
 		return nil, err
 	}
@@ -278,30 +325,30 @@ func compileBytes(typ *runtime.Type, structName, fieldName string) (Decoder, err
 	return newBytesDecoder(typ, structName, fieldName), nil
 }
 
-func compileSlice(typ *runtime.Type, structName, fieldName string, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
+func compileSlice(typ *runtime.Type, structName, fieldName string, tagOpt *runtime.StructTagOption, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
 	elem := typ.Elem()
-	decoder, err := compile(elem, structName, fieldName, structTypeToDecoder)
+	decoder, err := compile(elem, structName, fieldName, tagOpt, structTypeToDecoder)
 	if err != nil {
 		return nil, err
 	}
 	return newSliceDecoder(decoder, elem, elem.Size(), structName, fieldName), nil
 }
 
-func compileArray(typ *runtime.Type, structName, fieldName string, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
+func compileArray(typ *runtime.Type, structName, fieldName string, tagOpt *runtime.StructTagOption, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
 	elem := typ.Elem()
-	decoder, err := compile(elem, structName, fieldName, structTypeToDecoder)
+	decoder, err := compile(elem, structName, fieldName, tagOpt, structTypeToDecoder)
 	if err != nil {
 		return nil, err
 	}
 	return newArrayDecoder(decoder, elem, typ.Len(), structName, fieldName), nil
 }
 
-func compileMap(typ *runtime.Type, structName, fieldName string, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
-	keyDec, err := compileMapKey(typ.Key(), structName, fieldName, structTypeToDecoder)
+func compileMap(typ *runtime.Type, structName, fieldName string, tagOpt *runtime.StructTagOption, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
+	keyDec, err := compileMapKey(typ.Key(), structName, fieldName, tagOpt, structTypeToDecoder)
 	if err != nil {
 		return nil, err
 	}
-	valueDec, err := compile(typ.Elem(), structName, fieldName, structTypeToDecoder)
+	valueDec, err := compile(typ.Elem(), structName, fieldName, tagOpt, structTypeToDecoder)
 	if err != nil {
 		return nil, err
 	}
@@ -316,20 +363,20 @@ func compileFunc(typ *runtime.Type, strutName, fieldName string) (Decoder, error
 	return newFuncDecoder(typ, strutName, fieldName), nil
 }
 
-func typeToStructTags(typ *runtime.Type) runtime.StructTags {
+func typeToStructTags(typ *runtime.Type, tagOpt *runtime.StructTagOption) runtime.StructTags {
 	tags := runtime.StructTags{}
 	fieldNum := typ.NumField()
 	for i := 0; i < fieldNum; i++ {
 		field := typ.Field(i)
-		if runtime.IsIgnoredStructField(field) {
+		if runtime.IsIgnoredStructField(field, tagOpt) {
 			continue
 		}
-		tags = append(tags, runtime.StructTagFromField(field))
+		tags = append(tags, runtime.StructTagFromField(field, tagOpt))
 	}
 	return tags
 }
 
-func compileStruct(typ *runtime.Type, structName, fieldName string, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
+func compileStruct(typ *runtime.Type, structName, fieldName string, tagOpt *runtime.StructTagOption, structTypeToDecoder map[uintptr]Decoder) (Decoder, error) {
 	fieldNum := typ.NumField()
 	fieldMap := map[string]*structFieldSet{}
 	typeptr := uintptr(unsafe.Pointer(typ))
@@ -339,16 +386,38 @@ func compileStruct(typ *runtime.Type, structName, fieldName string, structTypeTo
 	structDec := newStructDecoder(structName, fieldName, fieldMap)
 	structTypeToDecoder[typeptr] = structDec
 	structName = typ.Name()
-	tags := typeToStructTags(typ)
+	tags := typeToStructTags(typ, tagOpt)
 	allFields := []*structFieldSet{}
 	for i := 0; i < fieldNum; i++ {
 		field := typ.Field(i)
-		if runtime.IsIgnoredStructField(field) {
+		if runtime.IsIgnoredStructField(field, tagOpt) {
 			continue
 		}
 		isUnexportedField := unicode.IsLower([]rune(field.Name)[0])
-		tag := runtime.StructTagFromField(field)
-		dec, err := compile(runtime.Type2RType(field.Type), structName, field.Name, structTypeToDecoder)
+		tag := runtime.StructTagFromField(field, tagOpt)
+		if tag.IsInline {
+			if !runtime.IsInlineMapType(field.Type) {
+				return nil, errors.ErrUnsupportedInline(field.Name, field.Type)
+			}
+			dec, err := compileMap(runtime.Type2RType(field.Type), structName, field.Name, tagOpt, structTypeToDecoder)
+			if err != nil {
+				return nil, err
+			}
+			structDec.inlineField = &inlineFieldSet{
+				dec:    dec.(*mapDecoder),
+				offset: field.Offset,
+			}
+			continue
+		}
+		var (
+			dec Decoder
+			err error
+		)
+		if tag.Format != "" {
+			dec, err = compileFormat(runtime.Type2RType(field.Type), tag.Format, structName, field.Name)
+		} else {
+			dec, err = compile(runtime.Type2RType(field.Type), structName, field.Name, tagOpt, structTypeToDecoder)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -358,6 +427,14 @@ func compileStruct(typ *runtime.Type, structName, fieldName string, structTypeTo
 					// recursive definition
 					continue
 				}
+				if stDec.inlineField != nil && structDec.inlineField == nil {
+					structDec.inlineField = &inlineFieldSet{
+						dec:        stDec.inlineField.dec,
+						offset:     field.Offset + stDec.inlineField.offset,
+						embedded:   stDec.inlineField.embedded,
+						structType: stDec.inlineField.structType,
+					}
+				}
 				for k, v := range stDec.fieldMap {
 					if tags.ExistsKey(k) {
 						continue
@@ -385,6 +462,17 @@ func compileStruct(typ *runtime.Type, structName, fieldName string, structTypeTo
 					)
 				}
 				if dec, ok := contentDec.(*structDecoder); ok {
+					if dec.inlineField != nil && structDec.inlineField == nil {
+						if fieldSetErr != nil {
+							return nil, fieldSetErr
+						}
+						structDec.inlineField = &inlineFieldSet{
+							dec:        dec.inlineField.dec,
+							offset:     field.Offset,
+							embedded:   dec.inlineField,
+							structType: pdec.typ,
+						}
+					}
 					for k, v := range dec.fieldMap {
 						if tags.ExistsKey(k) {
 							continue
@@ -404,8 +492,8 @@ func compileStruct(typ *runtime.Type, structName, fieldName string, structTypeTo
 						dec:         pdec,
 						offset:      field.Offset,
 						isTaggedKey: tag.IsTaggedKey,
-						key:         field.Name,
-						keyLen:      int64(len(field.Name)),
+						key:         tag.Key,
+						keyLen:      int64(len(tag.Key)),
 					}
 					allFields = append(allFields, fieldSet)
 				}
@@ -414,8 +502,8 @@ func compileStruct(typ *runtime.Type, structName, fieldName string, structTypeTo
 					dec:         dec,
 					offset:      field.Offset,
 					isTaggedKey: tag.IsTaggedKey,
-					key:         field.Name,
-					keyLen:      int64(len(field.Name)),
+					key:         tag.Key,
+					keyLen:      int64(len(tag.Key)),
 				}
 				allFields = append(allFields, fieldSet)
 			}
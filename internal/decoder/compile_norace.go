@@ -9,8 +9,11 @@ import (
 	"github.com/goccy/go-json/internal/runtime"
 )
 
-func CompileToGetDecoder(typ *runtime.Type) (Decoder, error) {
+func CompileToGetDecoder(typ *runtime.Type, opt *Option) (Decoder, error) {
 	initDecoder()
+	if opt.StructTag != nil {
+		return compileToGetDecoderWithStructTag(typ, opt.StructTag)
+	}
 	typeptr := uintptr(unsafe.Pointer(typ))
 	if typeptr > typeAddr.MaxTypeAddr {
 		return compileToGetDecoderSlowPath(typeptr, typ)
@@ -21,10 +24,20 @@ func CompileToGetDecoder(typ *runtime.Type) (Decoder, error) {
 		return dec, nil
 	}
 
-	dec, err := compileHead(typ, map[uintptr]Decoder{})
+	dec, err := compileHead(typ, nil, map[uintptr]Decoder{})
 	if err != nil {
 		return nil, err
 	}
 	cachedDecoder[index] = dec
 	return dec, nil
 }
+
+func countCachedDecoders() int {
+	var n int
+	for _, dec := range cachedDecoder {
+		if dec != nil {
+			n++
+		}
+	}
+	return n
+}
@@ -12,8 +12,11 @@ import (
 
 var decMu sync.RWMutex
 
-func CompileToGetDecoder(typ *runtime.Type) (Decoder, error) {
+func CompileToGetDecoder(typ *runtime.Type, opt *Option) (Decoder, error) {
 	initDecoder()
+	if opt.StructTag != nil {
+		return compileToGetDecoderWithStructTag(typ, opt.StructTag)
+	}
 	typeptr := uintptr(unsafe.Pointer(typ))
 	if typeptr > typeAddr.MaxTypeAddr {
 		return compileToGetDecoderSlowPath(typeptr, typ)
@@ -27,7 +30,7 @@ func CompileToGetDecoder(typ *runtime.Type) (Decoder, error) {
 	}
 	decMu.RUnlock()
 
-	dec, err := compileHead(typ, map[uintptr]Decoder{})
+	dec, err := compileHead(typ, nil, map[uintptr]Decoder{})
 	if err != nil {
 		return nil, err
 	}
@@ -36,3 +39,15 @@ func CompileToGetDecoder(typ *runtime.Type) (Decoder, error) {
 	decMu.Unlock()
 	return dec, nil
 }
+
+func countCachedDecoders() int {
+	decMu.RLock()
+	defer decMu.RUnlock()
+	var n int
+	for _, dec := range cachedDecoder {
+		if dec != nil {
+			n++
+		}
+	}
+	return n
+}
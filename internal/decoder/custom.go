@@ -0,0 +1,157 @@
+package decoder
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// CustomDecoder is a decode function registered for a type.
+// Either DecodeBytes or DecodeStream is set.
+type CustomDecoder struct {
+	Type *runtime.Type
+
+	// DecodeBytes decodes the raw bytes of a JSON value into p.
+	DecodeBytes func(b []byte, p unsafe.Pointer) error
+
+	// DecodeStream decodes the next JSON value in s into p.
+	DecodeStream func(s *Stream, p unsafe.Pointer) error
+}
+
+var (
+	globalCustomDecoders   unsafe.Pointer // map[*runtime.Type]*CustomDecoder
+	globalCustomDecodersMu sync.Mutex
+)
+
+func loadGlobalCustomDecoders() map[*runtime.Type]*CustomDecoder {
+	p := atomic.LoadPointer(&globalCustomDecoders)
+	return *(*map[*runtime.Type]*CustomDecoder)(unsafe.Pointer(&p))
+}
+
+// RegisterCustomDecoder registers dec for all subsequent compilations.
+// Types that have already been compiled keep their cached decoders.
+func RegisterCustomDecoder(dec *CustomDecoder) {
+	globalCustomDecodersMu.Lock()
+	defer globalCustomDecodersMu.Unlock()
+
+	m := loadGlobalCustomDecoders()
+	newMap := make(map[*runtime.Type]*CustomDecoder, len(m)+1)
+	for k, v := range m {
+		newMap[k] = v
+	}
+	newMap[dec.Type] = dec
+	atomic.StorePointer(&globalCustomDecoders, *(*unsafe.Pointer)(unsafe.Pointer(&newMap)))
+}
+
+func lookupCustomDecoder(typ *runtime.Type) *CustomDecoder {
+	return loadGlobalCustomDecoders()[typ]
+}
+
+// UsesCustomDecoder reports whether values of typ are decoded by a registered decoder,
+// either typ itself or the types of its elements, map values or struct fields, possibly through pointers.
+func UsesCustomDecoder(typ *runtime.Type) bool {
+	if len(loadGlobalCustomDecoders()) == 0 {
+		return false
+	}
+	return usesCustomDecoder(runtime.RType2Type(typ), map[reflect.Type]struct{}{})
+}
+
+func usesCustomDecoder(typ reflect.Type, seen map[reflect.Type]struct{}) bool {
+	if _, exists := seen[typ]; exists {
+		return false
+	}
+	seen[typ] = struct{}{}
+	if lookupCustomDecoder(runtime.Type2RType(typ)) != nil {
+		return true
+	}
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return usesCustomDecoder(typ.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			if usesCustomDecoder(typ.Field(i).Type, seen) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type customDecoder struct {
+	dec        *CustomDecoder
+	structName string
+	fieldName  string
+}
+
+func newCustomDecoder(dec *CustomDecoder, structName, fieldName string) *customDecoder {
+	return &customDecoder{
+		dec:        dec,
+		structName: structName,
+		fieldName:  fieldName,
+	}
+}
+
+func (d *customDecoder) annotateError(cursor int64, err error) {
+	switch e := err.(type) {
+	case *errors.UnmarshalTypeError:
+		e.Struct = d.structName
+		e.Field = d.fieldName
+	case *errors.SyntaxError:
+		e.Offset = cursor
+	}
+}
+
+func (d *customDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
+	s.skipWhiteSpace()
+	if d.dec.DecodeStream != nil {
+		if err := d.dec.DecodeStream(s, p); err != nil {
+			d.annotateError(s.cursor, err)
+			return err
+		}
+		return nil
+	}
+	start := s.cursor
+	if err := s.skipValue(depth); err != nil {
+		return err
+	}
+	if err := d.dec.DecodeBytes(s.buf[start:s.cursor], p); err != nil {
+		d.annotateError(s.cursor, err)
+		return err
+	}
+	return nil
+}
+
+func (d *customDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
+	buf := ctx.Buf
+	cursor = skipWhiteSpace(buf, cursor)
+	start := cursor
+	end, err := skipValueWithOption(ctx, cursor, depth)
+	if err != nil {
+		return 0, err
+	}
+	src := buf[start:end]
+	if d.dec.DecodeBytes != nil {
+		if err := d.dec.DecodeBytes(src, p); err != nil {
+			d.annotateError(cursor, err)
+			return 0, err
+		}
+		return end, nil
+	}
+	s := NewStream(bytes.NewReader(src))
+	s.Option = ctx.Option
+	if err := d.dec.DecodeStream(s, p); err != nil {
+		d.annotateError(cursor, err)
+		return 0, err
+	}
+	return end, nil
+}
+
+func (d *customDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][]byte, int64, error) {
+	return nil, 0, fmt.Errorf("json: custom decoder does not support decode path")
+}
@@ -0,0 +1,213 @@
+package decoder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// dumpNode is a decoder in the graph created by Dump and DumpDOT.
+type dumpNode struct {
+	id    int
+	name  string
+	attrs []string
+	edges []*dumpEdge
+}
+
+type dumpEdge struct {
+	label string
+	to    *dumpNode
+}
+
+func (n *dumpNode) label() string {
+	if len(n.attrs) == 0 {
+		return n.name
+	}
+	return fmt.Sprintf("%s (%s)", n.name, strings.Join(n.attrs, ", "))
+}
+
+type dumper struct {
+	nodes     []*dumpNode
+	decToNode map[Decoder]*dumpNode
+}
+
+func newDumpGraph(dec Decoder) *dumpNode {
+	d := &dumper{decToNode: map[Decoder]*dumpNode{}}
+	return d.node(dec)
+}
+
+func (d *dumper) node(dec Decoder) *dumpNode {
+	if n, exists := d.decToNode[dec]; exists {
+		return n
+	}
+	n := &dumpNode{id: len(d.nodes)}
+	d.nodes = append(d.nodes, n)
+	// register the node before visiting the children to stop at recursive types.
+	d.decToNode[dec] = n
+
+	switch dec := dec.(type) {
+	case *structDecoder:
+		n.name = "structDecoder"
+		n.attrs = append(n.attrs, "keyDecoder: "+dec.keyDecoderName())
+		for _, set := range dec.uniqueFieldSets() {
+			label := fmt.Sprintf("%q offset:%d", set.key, set.offset)
+			if set.isTaggedKey {
+				label += " tagged"
+			}
+			if set.err != nil {
+				label += " unsettable"
+			}
+			d.edge(n, label, set.dec)
+		}
+		if dec.inlineField != nil {
+			d.edge(n, fmt.Sprintf("inline offset:%d", dec.inlineField.offset), dec.inlineField.dec)
+		}
+	case *anonymousFieldDecoder:
+		n.name = "anonymousFieldDecoder"
+		n.attrs = append(n.attrs, "type: *"+dec.structType.String(), fmt.Sprintf("offset: %d", dec.offset))
+		d.edge(n, "elem", dec.dec)
+	case *ptrDecoder:
+		n.name = "ptrDecoder"
+		n.attrs = append(n.attrs, "type: *"+dec.typ.String())
+		d.edge(n, "elem", dec.dec)
+	case *sliceDecoder:
+		n.name = "sliceDecoder"
+		n.attrs = append(n.attrs, "elem: "+dec.elemType.String(), fmt.Sprintf("size: %d", dec.size))
+		d.edge(n, "elem", dec.valueDecoder)
+	case *arrayDecoder:
+		n.name = "arrayDecoder"
+		n.attrs = append(n.attrs, "elem: "+dec.elemType.String(), fmt.Sprintf("len: %d", dec.alen))
+		d.edge(n, "elem", dec.valueDecoder)
+	case *mapDecoder:
+		n.name = "mapDecoder"
+		n.attrs = append(n.attrs, "type: "+dec.mapType.String())
+		if dec.canUseAssignFaststrType {
+			n.attrs = append(n.attrs, "mapassign_faststr")
+		}
+		d.edge(n, "key", dec.keyDecoder)
+		d.edge(n, "value", dec.valueDecoder)
+	case *wrappedStringDecoder:
+		n.name = "wrappedStringDecoder"
+		n.attrs = append(n.attrs, "type: "+dec.typ.String())
+		d.edge(n, "value", dec.dec)
+	case *bytesDecoder:
+		n.name = "bytesDecoder"
+		n.attrs = append(n.attrs, "elem: "+dec.typ.String())
+	case *interfaceDecoder:
+		n.name = "interfaceDecoder"
+		n.attrs = append(n.attrs, "type: "+dec.typ.String())
+	case *intDecoder:
+		n.name = "intDecoder"
+		n.attrs = append(n.attrs, "type: "+dec.typ.String())
+	case *uintDecoder:
+		n.name = "uintDecoder"
+		n.attrs = append(n.attrs, "type: "+dec.typ.String())
+	case *floatDecoder:
+		n.name = "floatDecoder"
+	case *numberDecoder:
+		n.name = "numberDecoder"
+	case *stringDecoder:
+		n.name = "stringDecoder"
+	case *boolDecoder:
+		n.name = "boolDecoder"
+	case *unmarshalJSONDecoder:
+		n.name = "unmarshalJSONDecoder"
+		n.attrs = append(n.attrs, "type: "+dec.typ.String())
+	case *unmarshalTextDecoder:
+		n.name = "unmarshalTextDecoder"
+		n.attrs = append(n.attrs, "type: "+dec.typ.String())
+	case *funcDecoder:
+		n.name = "funcDecoder"
+		n.attrs = append(n.attrs, "type: "+dec.typ.String())
+	case *customDecoder:
+		n.name = "customDecoder"
+		n.attrs = append(n.attrs, "type: "+dec.dec.Type.String())
+	case *invalidDecoder:
+		n.name = "invalidDecoder"
+		n.attrs = append(n.attrs, "type: "+dec.typ.String())
+	default:
+		n.name = fmt.Sprintf("%T", dec)
+	}
+	return n
+}
+
+func (d *dumper) edge(from *dumpNode, label string, to Decoder) {
+	from.edges = append(from.edges, &dumpEdge{label: label, to: d.node(to)})
+}
+
+func (d *structDecoder) keyDecoderName() string {
+	switch {
+	case d.keyBitmapUint8 != nil:
+		return "bitmap uint8"
+	case d.keyBitmapUint16 != nil:
+		return "bitmap uint16"
+	}
+	return "field map"
+}
+
+// uniqueFieldSets returns the field sets sorted by offset and key,
+// without the duplicates registered in fieldMap for the lower case keys.
+func (d *structDecoder) uniqueFieldSets() []*structFieldSet {
+	exists := map[*structFieldSet]struct{}{}
+	sets := make([]*structFieldSet, 0, len(d.fieldMap))
+	for _, set := range d.fieldMap {
+		if _, ok := exists[set]; ok {
+			continue
+		}
+		exists[set] = struct{}{}
+		sets = append(sets, set)
+	}
+	sort.Slice(sets, func(i, j int) bool {
+		if sets[i].offset != sets[j].offset {
+			return sets[i].offset < sets[j].offset
+		}
+		return sets[i].key < sets[j].key
+	})
+	return sets
+}
+
+// Dump returns the tree of the decoders as text.
+// The decoder already shown, such as the decoder of a recursive type, is referred to by its number.
+func Dump(dec Decoder) string {
+	var b strings.Builder
+	visited := map[*dumpNode]struct{}{}
+	var dump func(n *dumpNode, prefix string, indent int)
+	dump = func(n *dumpNode, prefix string, indent int) {
+		fmt.Fprintf(&b, "%s%s[%03d]", strings.Repeat("  ", indent), prefix, n.id)
+		if _, exists := visited[n]; exists {
+			fmt.Fprintf(&b, " %s (see above)\n", n.name)
+			return
+		}
+		visited[n] = struct{}{}
+		fmt.Fprintf(&b, " %s\n", n.label())
+		for _, e := range n.edges {
+			dump(e.to, e.label+": ", indent+1)
+		}
+	}
+	dump(newDumpGraph(dec), "", 0)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// DumpDOT returns the graph of the decoders in the DOT language of Graphviz.
+func DumpDOT(dec Decoder) string {
+	root := newDumpGraph(dec)
+	var b strings.Builder
+	fmt.Fprintln(&b, "digraph \"decoder\" {")
+	fmt.Fprintln(&b, "rankdir=TD;\nordering=out;\nnode[shape=box];")
+	visited := map[*dumpNode]struct{}{}
+	var dump func(n *dumpNode)
+	dump = func(n *dumpNode) {
+		if _, exists := visited[n]; exists {
+			return
+		}
+		visited[n] = struct{}{}
+		fmt.Fprintf(&b, "\"%d\" [label=%q];\n", n.id, n.label())
+		for _, e := range n.edges {
+			fmt.Fprintf(&b, "\"%d\" -> \"%d\" [label=%q];\n", n.id, e.to.id, e.label)
+			dump(e.to)
+		}
+	}
+	dump(root)
+	fmt.Fprint(&b, "}")
+	return b.String()
+}
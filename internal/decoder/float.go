@@ -119,9 +119,10 @@ func (d *floatDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, erro
 }
 
 func (d *floatDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
+	start := s.cursor
 	bytes, err := d.decodeStreamByte(s)
 	if err != nil {
-		return err
+		return d.decodeStreamNonFinite(s, start, p, err)
 	}
 	if bytes == nil {
 		return nil
@@ -129,7 +130,21 @@ func (d *floatDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) er
 	str := *(*string)(unsafe.Pointer(&bytes))
 	f64, err := strconv.ParseFloat(str, 64)
 	if err != nil {
-		return errors.ErrSyntax(err.Error(), s.totalOffset())
+		return d.decodeStreamNonFinite(s, start, p, errors.ErrSyntax(err.Error(), s.totalOffset()))
+	}
+	d.op(p, f64)
+	return nil
+}
+
+// decodeStreamNonFinite decodes NaN, Infinity or -Infinity at start with JSON5Option, or returns err.
+func (d *floatDecoder) decodeStreamNonFinite(s *Stream, start int64, p unsafe.Pointer, err error) error {
+	if s.Option.Flags&JSON5Option == 0 {
+		return err
+	}
+	s.cursor = start
+	_, f64, ok := s.nonFiniteNumber()
+	if !ok {
+		return err
 	}
 	d.op(p, f64)
 	return nil
@@ -139,15 +154,15 @@ func (d *floatDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 	buf := ctx.Buf
 	bytes, c, err := d.decodeByte(buf, cursor)
 	if err != nil {
-		return 0, err
+		return d.decodeNonFinite(ctx, cursor, p, err)
 	}
 	if bytes == nil {
 		return c, nil
 	}
-	cursor = c
-	if !validEndNumberChar[buf[cursor]] {
-		return 0, errors.ErrUnexpectedEndOfJSON("float", cursor)
+	if !validEndNumberChar[buf[c]] {
+		return d.decodeNonFinite(ctx, cursor, p, errors.ErrUnexpectedEndOfJSON("float", c))
 	}
+	cursor = c
 	s := *(*string)(unsafe.Pointer(&bytes))
 	f64, err := strconv.ParseFloat(s, 64)
 	if err != nil {
@@ -157,8 +172,27 @@ func (d *floatDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe
 	return cursor, nil
 }
 
+// decodeNonFinite decodes NaN, Infinity or -Infinity at cursor with JSON5Option, or returns err.
+func (d *floatDecoder) decodeNonFinite(ctx *RuntimeContext, cursor int64, p unsafe.Pointer, err error) (int64, error) {
+	if ctx.Option.Flags&JSON5Option == 0 {
+		return 0, err
+	}
+	_, f64, end, ok := nonFiniteNumber(ctx.Buf, skipWhiteSpace(ctx.Buf, cursor))
+	if !ok {
+		return 0, err
+	}
+	d.op(p, f64)
+	return end, nil
+}
+
 func (d *floatDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][]byte, int64, error) {
 	buf := ctx.Buf
+	if ctx.Option.Flags&JSON5Option != 0 {
+		start := skipWhiteSpace(buf, cursor)
+		if _, _, end, ok := nonFiniteNumber(buf, start); ok {
+			return [][]byte{buf[start:end]}, end, nil
+		}
+	}
 	bytes, c, err := d.decodeByte(buf, cursor)
 	if err != nil {
 		return nil, 0, err
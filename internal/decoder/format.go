@@ -0,0 +1,292 @@
+package decoder
+
+import (
+	"bytes"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// compileFormat creates a decoder for typ that follows the format option of the struct tag.
+func compileFormat(typ *runtime.Type, format, structName, fieldName string) (Decoder, error) {
+	if typ.Kind() == reflect.Ptr {
+		dec, err := compileFormat(typ.Elem(), format, structName, fieldName)
+		if err != nil {
+			return nil, err
+		}
+		return newPtrDecoder(dec, typ.Elem(), structName, fieldName), nil
+	}
+	var fn func(b []byte, p unsafe.Pointer) error
+	switch {
+	case runtime.IsTimeType(typ):
+		fn = timeFormatDecodeFunc(format)
+	case runtime.IsDurationType(typ):
+		fn = durationFormatDecodeFunc(format)
+	case runtime.IsByteSliceType(typ):
+		fn = bytesFormatDecodeFunc(format)
+	}
+	if fn == nil {
+		return nil, errors.ErrUnsupportedFormat(format, runtime.RType2Type(typ))
+	}
+	return newCustomDecoder(&CustomDecoder{
+		Type: typ,
+		DecodeBytes: func(b []byte, p unsafe.Pointer) error {
+			if bytes.Equal(b, nullbytes) {
+				if runtime.IsByteSliceType(typ) {
+					*(*[]byte)(p) = nil
+				}
+				return nil
+			}
+			err := fn(b, p)
+			if e, ok := err.(*errors.UnmarshalTypeError); ok {
+				e.Type = runtime.RType2Type(typ)
+			}
+			return err
+		},
+	}, structName, fieldName), nil
+}
+
+func formatTypeError(b []byte) error {
+	value := "string"
+	switch b[0] {
+	case '[':
+		value = "array"
+	case '{':
+		value = "object"
+	case 't', 'f':
+		value = "bool"
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		value = "number " + string(b)
+	}
+	return &errors.UnmarshalTypeError{Value: value}
+}
+
+// formatString returns the unquoted content of the JSON string b.
+func formatString(b []byte) ([]byte, error) {
+	s, ok := unquoteBytes(b)
+	if !ok {
+		return nil, formatTypeError(b)
+	}
+	return s, nil
+}
+
+// formatUnitNumber parses the JSON number b, which may have a fraction and an exponent, in a unit.
+// It returns the integer part and the fraction in billionths of the unit, both with the sign of b.
+// The digits of the fraction after the ninth are truncated.
+// If b is not a number or the integer part overflows int64, it returns an *UnmarshalTypeError.
+func formatUnitNumber(b []byte) (int64, int64, error) {
+	intPart, fracPart, neg, ok := splitDecimal(b)
+	if !ok {
+		return 0, 0, formatTypeError(b)
+	}
+	v, err := strconv.ParseUint(intPart, 10, 63)
+	if err != nil {
+		return 0, 0, formatTypeError(b)
+	}
+	var frac uint64
+	if fracPart != "" {
+		frac, _ = strconv.ParseUint(fracPart+"000000000"[len(fracPart):], 10, 64)
+	}
+	if neg {
+		return -int64(v), -int64(frac), nil
+	}
+	return int64(v), int64(frac), nil
+}
+
+// splitDecimal splits the JSON number b into the digits of the integer part and up to nine digits of the fraction,
+// moving the decimal point by the exponent.
+func splitDecimal(b []byte) (string, string, bool, bool) {
+	num := string(b)
+	neg := strings.HasPrefix(num, "-")
+	if neg {
+		num = num[1:]
+	}
+	exp := 0
+	if idx := strings.IndexAny(num, "eE"); idx >= 0 {
+		e, err := strconv.Atoi(strings.TrimPrefix(num[idx+1:], "+"))
+		if err != nil {
+			return "", "", false, false
+		}
+		num, exp = num[:idx], e
+	}
+	intDigits, fracDigits := num, ""
+	if idx := strings.IndexByte(num, '.'); idx >= 0 {
+		intDigits, fracDigits = num[:idx], num[idx+1:]
+	}
+	if intDigits == "" || strings.Trim(intDigits+fracDigits, "0123456789") != "" {
+		return "", "", false, false
+	}
+	digits := strings.TrimLeft(intDigits+fracDigits, "0")
+	// point is the position of the decimal point in digits.
+	point := len(intDigits) - (len(intDigits) + len(fracDigits) - len(digits))
+	if digits == "" {
+		return "0", "", neg, true
+	}
+	if exp > 20 || point+exp > 20 {
+		// too large for int64
+		return "", "", false, false
+	}
+	point += exp
+	switch {
+	case point <= -9:
+		return "0", "", neg, true
+	case point <= 0:
+		frac := strings.Repeat("0", -point) + digits
+		if len(frac) > 9 {
+			frac = frac[:9]
+		}
+		return "0", frac, neg, true
+	case point >= len(digits):
+		return digits + strings.Repeat("0", point-len(digits)), "", neg, true
+	}
+	frac := digits[point:]
+	if len(frac) > 9 {
+		frac = frac[:9]
+	}
+	return digits[:point], frac, neg, true
+}
+
+func timeFormatDecodeFunc(format string) func(b []byte, p unsafe.Pointer) error {
+	var unit time.Duration
+	switch format {
+	case "unix":
+		return func(b []byte, p unsafe.Pointer) error {
+			sec, nsec, err := formatUnitNumber(b)
+			if err != nil {
+				return err
+			}
+			*(*time.Time)(p) = time.Unix(sec, nsec)
+			return nil
+		}
+	case "unixmilli":
+		unit = time.Millisecond
+	case "unixmicro":
+		unit = time.Microsecond
+	case "unixnano":
+		unit = time.Nanosecond
+	default:
+		layout := runtime.TimeLayout(format)
+		return func(b []byte, p unsafe.Pointer) error {
+			s, err := formatString(b)
+			if err != nil {
+				return err
+			}
+			t, err := time.Parse(layout, string(s))
+			if err != nil {
+				return err
+			}
+			*(*time.Time)(p) = t
+			return nil
+		}
+	}
+	return func(b []byte, p unsafe.Pointer) error {
+		v, frac, err := formatUnitNumber(b)
+		if err != nil {
+			return err
+		}
+		perSec := int64(time.Second / unit)
+		*(*time.Time)(p) = time.Unix(v/perSec, (v%perSec)*int64(unit)+frac*int64(unit)/int64(time.Second))
+		return nil
+	}
+}
+
+func durationFormatDecodeFunc(format string) func(b []byte, p unsafe.Pointer) error {
+	var unit time.Duration
+	switch format {
+	case "units":
+		return func(b []byte, p unsafe.Pointer) error {
+			s, err := formatString(b)
+			if err != nil {
+				return err
+			}
+			d, err := time.ParseDuration(string(s))
+			if err != nil {
+				return err
+			}
+			*(*time.Duration)(p) = d
+			return nil
+		}
+	case "nano":
+		unit = time.Nanosecond
+	case "sec":
+		unit = time.Second
+	case "milli":
+		unit = time.Millisecond
+	case "micro":
+		unit = time.Microsecond
+	default:
+		return nil
+	}
+	return func(b []byte, p unsafe.Pointer) error {
+		v, frac, err := formatUnitNumber(b)
+		if err != nil {
+			return err
+		}
+		if v > math.MaxInt64/int64(unit) || v < math.MinInt64/int64(unit) {
+			return formatTypeError(b)
+		}
+		*(*time.Duration)(p) = time.Duration(v)*unit + time.Duration(frac*int64(unit)/int64(time.Second))
+		return nil
+	}
+}
+
+func bytesFormatDecodeFunc(format string) func(b []byte, p unsafe.Pointer) error {
+	var decode func(s []byte) ([]byte, error)
+	switch format {
+	case "array":
+		// the elements are decoded as uint8 since the decoder of []byte decodes a base64 string.
+		elem := runtime.Type2RType(reflect.TypeOf(uint8(0)))
+		elemDec, _ := compileUint8(elem, "", "")
+		dec := newSliceDecoder(elemDec, elem, elem.Size(), "", "")
+		return func(b []byte, p unsafe.Pointer) error {
+			if b[0] != '[' {
+				return formatTypeError(b)
+			}
+			buf := make([]byte, len(b)+1)
+			copy(buf, b)
+			_, err := dec.Decode(&RuntimeContext{Buf: buf, Option: &Option{}}, 0, 0, p)
+			return err
+		}
+	case "hex":
+		decode = encodingDecodeFunc(hex.DecodedLen, hex.Decode)
+	case "base64":
+		decode = encodingDecodeFunc(base64.StdEncoding.DecodedLen, base64.StdEncoding.Decode)
+	case "base64url":
+		decode = encodingDecodeFunc(base64.URLEncoding.DecodedLen, base64.URLEncoding.Decode)
+	case "base32":
+		decode = encodingDecodeFunc(base32.StdEncoding.DecodedLen, base32.StdEncoding.Decode)
+	case "base32hex":
+		decode = encodingDecodeFunc(base32.HexEncoding.DecodedLen, base32.HexEncoding.Decode)
+	default:
+		return nil
+	}
+	return func(b []byte, p unsafe.Pointer) error {
+		s, err := formatString(b)
+		if err != nil {
+			return err
+		}
+		v, err := decode(s)
+		if err != nil {
+			return err
+		}
+		*(*[]byte)(p) = v
+		return nil
+	}
+}
+
+func encodingDecodeFunc(decodedLen func(int) int, decode func(dst, src []byte) (int, error)) func(s []byte) ([]byte, error) {
+	return func(s []byte) ([]byte, error) {
+		dst := make([]byte, decodedLen(len(s)))
+		n, err := decode(dst, s)
+		return dst[:n], err
+	}
+}
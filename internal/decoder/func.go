@@ -84,7 +84,7 @@ func (d *funcDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.
 	buf := ctx.Buf
 	cursor = skipWhiteSpace(buf, cursor)
 	start := cursor
-	end, err := skipValue(buf, cursor, depth)
+	end, err := skipValueWithOption(ctx, cursor, depth)
 	if err != nil {
 		return 0, err
 	}
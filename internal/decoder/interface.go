@@ -270,6 +270,10 @@ func (d *interfaceDecoder) decodeStreamEmptyInterface(s *Stream, depth int64, p
 			}
 			*(*interface{})(p) = nil
 			return nil
+		case 'N', 'I':
+			if s.Option.Flags&JSON5Option != 0 {
+				return d.numDecoder(s).DecodeStream(s, depth, p)
+			}
 		case nul:
 			if s.read() {
 				c = s.char()
@@ -328,7 +332,7 @@ func (d *interfaceDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer
 		*(*interface{})(p) = nil
 		return nil
 	}
-	decoder, err := CompileToGetDecoder(typ)
+	decoder, err := CompileToGetDecoder(typ, s.Option)
 	if err != nil {
 		return err
 	}
@@ -393,7 +397,7 @@ func (d *interfaceDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p un
 		**(**interface{})(unsafe.Pointer(&p)) = nil
 		return cursor, nil
 	}
-	decoder, err := CompileToGetDecoder(typ)
+	decoder, err := CompileToGetDecoder(typ, ctx.Option)
 	if err != nil {
 		return 0, err
 	}
@@ -454,6 +458,10 @@ func (d *interfaceDecoder) decodeEmptyInterface(ctx *RuntimeContext, cursor, dep
 		cursor += 4
 		**(**interface{})(unsafe.Pointer(&p)) = nil
 		return cursor, nil
+	case 'N', 'I':
+		if ctx.Option.Flags&JSON5Option != 0 {
+			return d.floatDecoder.Decode(ctx, cursor, depth, p)
+		}
 	}
 	return cursor, errors.ErrInvalidBeginningOfValue(buf[cursor], cursor)
 }
@@ -523,6 +531,10 @@ func (d *interfaceDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64)
 		}
 		cursor += 4
 		return [][]byte{nullbytes}, cursor, nil
+	case 'N', 'I':
+		if ctx.Option.Flags&JSON5Option != 0 {
+			return d.floatDecoder.DecodePath(ctx, cursor, depth)
+		}
 	}
 	return nil, cursor, errors.ErrInvalidBeginningOfValue(buf[cursor], cursor)
 }
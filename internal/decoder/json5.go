@@ -0,0 +1,648 @@
+package decoder
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// json5Translator translates the JSON5 ( https://spec.json5.org ) source into JSON token by token,
+// so that the decoders can decode it without knowing JSON5.
+// Comments are replaced with a space, trailing commas are removed, unquoted keys and single-quoted strings are
+// double-quoted and hexadecimal numbers are converted to decimal numbers. NaN, Infinity and -Infinity have no JSON
+// representation and are kept as they are, and the number decoders accept them with JSON5Option.
+// The structure is not validated here but by the decoders.
+type json5Translator struct {
+	r          json5Source
+	offset     int64  // offset of the next byte of the source
+	stack      []byte // '{' or '[' of the enclosing containers
+	expectKey  bool
+	afterValue bool
+	comma      bool // whether the comma following the last value is held until the next token shows it is not trailing
+}
+
+// json5Source is the source of json5Translator, such as *bytes.Reader and *bufio.Reader.
+type json5Source interface {
+	io.ByteScanner
+	io.RuneScanner
+}
+
+// TranslateJSON5 translates the JSON5 source src into JSON.
+func TranslateJSON5(src []byte) ([]byte, error) {
+	t := &json5Translator{r: bytes.NewReader(src)}
+	dst := make([]byte, 0, len(src)+1)
+	for {
+		var err error
+		dst, err = t.translate(dst)
+		if err == io.EOF {
+			return dst, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (t *json5Translator) readByte() (byte, error) {
+	c, err := t.r.ReadByte()
+	if err == nil {
+		t.offset++
+	}
+	return c, err
+}
+
+func (t *json5Translator) unreadByte() {
+	if err := t.r.UnreadByte(); err == nil {
+		t.offset--
+	}
+}
+
+// peekByte returns the next byte of the source without consuming it, or nul at the end of the source.
+func (t *json5Translator) peekByte() byte {
+	c, err := t.readByte()
+	if err != nil {
+		return nul
+	}
+	t.unreadByte()
+	return c
+}
+
+// translate appends to dst the translation of the next token or white space of the source.
+// It returns io.EOF at the end of the source.
+func (t *json5Translator) translate(dst []byte) ([]byte, error) {
+	c, err := t.readByte()
+	if err != nil {
+		return t.flushComma(dst), err
+	}
+	switch c {
+	case ' ', '\t', '\n', '\r':
+		return t.space(dst, c), nil
+	case '\v', '\f':
+		return t.space(dst, ' '), nil
+	case '/':
+		if err := t.comment(); err != nil {
+			return nil, err
+		}
+		return t.space(dst, ' '), nil
+	case '{', '[':
+		t.stack = append(t.stack, c)
+		t.expectKey = c == '{'
+		t.afterValue = false
+		return append(t.flushComma(dst), c), nil
+	case '}', ']':
+		// the comma held here follows a value and is trailing.
+		t.comma = false
+		if len(t.stack) > 0 {
+			t.stack = t.stack[:len(t.stack)-1]
+		}
+		t.expectKey = false
+		t.afterValue = true
+		return append(dst, c), nil
+	case ',':
+		dst = t.flushComma(dst)
+		if len(t.stack) > 0 && t.afterValue {
+			t.comma = true
+		} else {
+			dst = append(dst, c)
+		}
+		t.expectKey = len(t.stack) > 0 && t.stack[len(t.stack)-1] == '{'
+		t.afterValue = false
+		return dst, nil
+	case ':':
+		t.expectKey = false
+		t.afterValue = false
+		return append(t.flushComma(dst), c), nil
+	case '"', '\'':
+		dst, err := t.string(t.flushComma(dst), c)
+		if err != nil {
+			return nil, err
+		}
+		t.afterValue = !t.expectKey
+		t.expectKey = false
+		return dst, nil
+	case '-', '+', '.', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		dst, err := t.number(t.flushComma(dst), c)
+		if err != nil {
+			return nil, err
+		}
+		t.expectKey = false
+		t.afterValue = true
+		return dst, nil
+	}
+	r := rune(c)
+	if c >= utf8.RuneSelf {
+		t.unreadByte()
+		if r, err = t.readRune(); err != nil {
+			return nil, err
+		}
+		if isJSON5Space(r) {
+			return t.space(dst, ' '), nil
+		}
+	}
+	if !isIdentifierStart(r) && r != '\\' {
+		return nil, errors.ErrInvalidBeginningOfValue(c, t.offset-1)
+	}
+	start := t.offset - int64(utf8.RuneLen(r))
+	name, err := t.identifier(r)
+	if err != nil {
+		return nil, err
+	}
+	dst = t.flushComma(dst)
+	if t.expectKey {
+		// unquoted key
+		t.expectKey = false
+		t.afterValue = false
+		dst = append(dst, '"')
+		dst = append(dst, name...)
+		return append(dst, '"'), nil
+	}
+	switch string(name) {
+	case "true", "false", "null", "NaN", "Infinity":
+		t.afterValue = true
+		return append(dst, name...), nil
+	}
+	return nil, errors.ErrSyntax(fmt.Sprintf("invalid value %q", name), start)
+}
+
+func (t *json5Translator) flushComma(dst []byte) []byte {
+	if !t.comma {
+		return dst
+	}
+	t.comma = false
+	return append(dst, ',')
+}
+
+// space appends the white space c unless a comma is held, which is moved to the next token.
+func (t *json5Translator) space(dst []byte, c byte) []byte {
+	if t.comma {
+		return dst
+	}
+	return append(dst, c)
+}
+
+// comment skips the comment following '/'.
+func (t *json5Translator) comment() error {
+	c, err := t.readByte()
+	if err != nil {
+		return errors.ErrUnexpectedEndOfJSON("comment", t.offset)
+	}
+	switch c {
+	case '/':
+		for {
+			c, err := t.readByte()
+			if err != nil || c == '\n' || c == '\r' {
+				return nil
+			}
+		}
+	case '*':
+		for prev := byte(0); ; {
+			c, err := t.readByte()
+			if err != nil {
+				return errors.ErrUnexpectedEndOfJSON("comment", t.offset)
+			}
+			if prev == '*' && c == '/' {
+				return nil
+			}
+			prev = c
+		}
+	}
+	return errors.ErrInvalidCharacter(c, "comment", t.offset-1)
+}
+
+// string appends the JSON string of the JSON5 string quoted by quote.
+// The JSON escape sequences are kept, so that the JSON strings are not changed.
+func (t *json5Translator) string(dst []byte, quote byte) ([]byte, error) {
+	dst = append(dst, '"')
+	for {
+		c, err := t.readByte()
+		if err != nil {
+			return nil, errors.ErrUnexpectedEndOfJSON("string", t.offset)
+		}
+		switch {
+		case c == quote:
+			return append(dst, '"'), nil
+		case c == '"':
+			dst = append(dst, '\\', '"')
+		case c == '\\':
+			if dst, err = t.escape(dst); err != nil {
+				return nil, err
+			}
+		case c == '\n' || c == '\r':
+			return nil, errors.ErrSyntax("line terminator in string", t.offset-1)
+		case c < 0x20:
+			dst = append(dst, fmt.Sprintf(`\u%04x`, c)...)
+		default:
+			dst = append(dst, c)
+		}
+	}
+}
+
+// escape appends the JSON escape sequence of the JSON5 escape sequence following '\'.
+func (t *json5Translator) escape(dst []byte) ([]byte, error) {
+	c, err := t.readByte()
+	if err != nil {
+		return nil, errors.ErrUnexpectedEndOfJSON("string", t.offset)
+	}
+	switch c {
+	case '"', '\\', '/', 'b', 'f', 'n', 'r', 't':
+		return append(dst, '\\', c), nil
+	case '\'':
+		return append(dst, c), nil
+	case 'v':
+		return append(dst, `\u000b`...), nil
+	case '0':
+		if next := t.peekByte(); '0' <= next && next <= '9' {
+			return nil, errors.ErrSyntax("octal escape sequence in string", t.offset)
+		}
+		return append(dst, `\u0000`...), nil
+	case 'x':
+		hex, err := t.hex(2)
+		if err != nil {
+			return nil, err
+		}
+		return append(append(dst, `\u00`...), hex...), nil
+	case 'u':
+		hex, err := t.hex(4)
+		if err != nil {
+			return nil, err
+		}
+		return append(append(dst, `\u`...), hex...), nil
+	case '\n':
+		// line continuation
+		return dst, nil
+	case '\r':
+		if t.peekByte() == '\n' {
+			_, _ = t.readByte()
+		}
+		return dst, nil
+	case '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return nil, errors.ErrSyntax("octal escape sequence in string", t.offset-1)
+	}
+	if c >= utf8.RuneSelf {
+		t.unreadByte()
+		r, err := t.readRune()
+		if err != nil {
+			return nil, err
+		}
+		if r == '\u2028' || r == '\u2029' {
+			// line continuation
+			return dst, nil
+		}
+		return utf8.AppendRune(dst, r), nil
+	}
+	if c < 0x20 {
+		return append(dst, fmt.Sprintf(`\u%04x`, c)...), nil
+	}
+	// the other characters escape themselves.
+	return append(dst, c), nil
+}
+
+func (t *json5Translator) hex(n int) ([]byte, error) {
+	hex := make([]byte, n)
+	for i := range hex {
+		c, err := t.readByte()
+		if err != nil {
+			return nil, errors.ErrUnexpectedEndOfJSON("escape sequence", t.offset)
+		}
+		if !isHexDigit(c) {
+			return nil, errors.ErrInvalidCharacter(c, "escape sequence", t.offset-1)
+		}
+		hex[i] = c
+	}
+	return hex, nil
+}
+
+// number appends the JSON number of the JSON5 number beginning with c.
+func (t *json5Translator) number(dst []byte, c byte) ([]byte, error) {
+	start := t.offset - 1
+	neg := c == '-'
+	if c == '-' || c == '+' {
+		var err error
+		if c, err = t.readByte(); err != nil {
+			return nil, errors.ErrUnexpectedEndOfJSON("number", t.offset)
+		}
+	}
+	if c == 'I' || c == 'N' {
+		name, err := t.identifier(rune(c))
+		if err != nil {
+			return nil, err
+		}
+		switch string(name) {
+		case "Infinity":
+			if neg {
+				dst = append(dst, '-')
+			}
+			return append(dst, name...), nil
+		case "NaN":
+			return append(dst, name...), nil
+		}
+		return nil, errors.ErrSyntax(fmt.Sprintf("invalid number %q", name), start)
+	}
+	if neg {
+		dst = append(dst, '-')
+	}
+	if c == '0' {
+		if next := t.peekByte(); next == 'x' || next == 'X' {
+			_, _ = t.readByte()
+			return t.hexNumber(dst, start)
+		}
+	}
+	digits := t.digits(nil, c)
+	if c == '.' || t.peekByte() == '.' {
+		if c != '.' {
+			_, _ = t.readByte()
+		}
+		fraction := t.digits(nil, t.nextDigit())
+		if len(digits) == 0 && len(fraction) == 0 {
+			return nil, errors.ErrSyntax("invalid number", start)
+		}
+		if len(digits) == 0 {
+			digits = append(digits, '0')
+		}
+		dst = append(dst, digits...)
+		if len(fraction) > 0 {
+			dst = append(append(dst, '.'), fraction...)
+		}
+	} else {
+		if len(digits) == 0 {
+			return nil, errors.ErrSyntax("invalid number", start)
+		}
+		dst = append(dst, digits...)
+	}
+	if next := t.peekByte(); next == 'e' || next == 'E' {
+		_, _ = t.readByte()
+		dst = append(dst, next)
+		if sign := t.peekByte(); sign == '+' || sign == '-' {
+			_, _ = t.readByte()
+			dst = append(dst, sign)
+		}
+		exponent := t.digits(nil, t.nextDigit())
+		if len(exponent) == 0 {
+			return nil, errors.ErrSyntax("invalid number", start)
+		}
+		dst = append(dst, exponent...)
+	}
+	return dst, nil
+}
+
+// nextDigit consumes and returns the next byte if it is a digit, or returns nul.
+func (t *json5Translator) nextDigit() byte {
+	if c := t.peekByte(); '0' <= c && c <= '9' {
+		_, _ = t.readByte()
+		return c
+	}
+	return nul
+}
+
+// digits appends c and the following digits if c is a digit.
+func (t *json5Translator) digits(dst []byte, c byte) []byte {
+	for '0' <= c && c <= '9' {
+		dst = append(dst, c)
+		c = t.nextDigit()
+	}
+	return dst
+}
+
+func (t *json5Translator) hexNumber(dst []byte, start int64) ([]byte, error) {
+	var hex []byte
+	for isHexDigit(t.peekByte()) {
+		c, _ := t.readByte()
+		hex = append(hex, c)
+	}
+	n, ok := new(big.Int).SetString(string(hex), 16)
+	if !ok {
+		return nil, errors.ErrSyntax("invalid hexadecimal number", start)
+	}
+	return n.Append(dst, 10), nil
+}
+
+// identifier reads the identifier beginning with r, which is not consumed yet if it is '\'.
+func (t *json5Translator) identifier(r rune) ([]byte, error) {
+	var name []byte
+	for {
+		if r == '\\' {
+			// unicode escape sequence
+			if c, err := t.readByte(); err != nil || c != 'u' {
+				return nil, errors.ErrSyntax("invalid escape sequence in identifier", t.offset)
+			}
+			hex, err := t.hex(4)
+			if err != nil {
+				return nil, err
+			}
+			var v rune
+			for _, c := range hex {
+				v = v<<4 | rune(unhex(c))
+			}
+			r = v
+		}
+		if len(name) == 0 && !isIdentifierStart(r) || !isIdentifierPart(r) {
+			return nil, errors.ErrSyntax(fmt.Sprintf("invalid character %q in identifier", r), t.offset)
+		}
+		name = utf8.AppendRune(name, r)
+
+		c := t.peekByte()
+		switch {
+		case c == '\\':
+			_, _ = t.readByte()
+			r = '\\'
+		case c >= utf8.RuneSelf:
+			next, err := t.readRune()
+			if err != nil {
+				return nil, err
+			}
+			if !isIdentifierPart(next) {
+				t.unreadRune(next)
+				return name, nil
+			}
+			r = next
+		case isIdentifierPart(rune(c)):
+			_, _ = t.readByte()
+			r = rune(c)
+		default:
+			return name, nil
+		}
+	}
+}
+
+func (t *json5Translator) readRune() (rune, error) {
+	r, size, err := t.r.ReadRune()
+	if err != nil {
+		return 0, errors.ErrUnexpectedEndOfJSON("character", t.offset)
+	}
+	t.offset += int64(size)
+	if r == utf8.RuneError && size == 1 {
+		return 0, errors.ErrSyntax("invalid UTF-8", t.offset-1)
+	}
+	return r, nil
+}
+
+func (t *json5Translator) unreadRune(r rune) {
+	if err := t.r.UnreadRune(); err == nil {
+		t.offset -= int64(utf8.RuneLen(r))
+	}
+}
+
+func isJSON5Space(r rune) bool {
+	return r == '\u00a0' || r == '\ufeff' || r == '\u2028' || r == '\u2029' || unicode.Is(unicode.Zs, r)
+}
+
+func isIdentifierStart(r rune) bool {
+	return r == '$' || r == '_' || unicode.IsLetter(r) || unicode.Is(unicode.Nl, r)
+}
+
+func isIdentifierPart(r rune) bool {
+	return isIdentifierStart(r) || unicode.IsDigit(r) || unicode.In(r, unicode.Mn, unicode.Mc, unicode.Pc) ||
+		r == '\u200c' || r == '\u200d'
+}
+
+func isHexDigit(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	}
+	return c - 'A' + 10
+}
+
+// json5Reader is the reader of the JSON translated from the JSON5 source.
+type json5Reader struct {
+	t   json5Translator
+	buf []byte // translated bytes which are not read yet
+	err error  // io.EOF at the end of the source, or the error of the translation
+}
+
+func newJSON5Reader(r io.Reader, offset int64) *json5Reader {
+	return &json5Reader{t: json5Translator{r: bufio.NewReader(r), offset: offset}}
+}
+
+func (r *json5Reader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		r.buf, r.err = r.t.translate(r.buf[:0])
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+var nonFiniteNumbers = []struct {
+	literal string
+	value   float64
+}{
+	{literal: "NaN", value: math.NaN()},
+	{literal: "Infinity", value: math.Inf(1)},
+	{literal: "-Infinity", value: math.Inf(-1)},
+}
+
+// nonFiniteNumber returns the literal, the value and the end of NaN, Infinity or -Infinity at cursor,
+// which are left in the JSON translated from JSON5.
+func nonFiniteNumber(buf []byte, cursor int64) (string, float64, int64, bool) {
+	for _, n := range nonFiniteNumbers {
+		if bytes.HasPrefix(buf[cursor:], []byte(n.literal)) {
+			return n.literal, n.value, cursor + int64(len(n.literal)), true
+		}
+	}
+	return "", 0, 0, false
+}
+
+// skipValueWithOption is skipValue which also skips NaN, Infinity and -Infinity with JSON5Option.
+func skipValueWithOption(ctx *RuntimeContext, cursor, depth int64) (int64, error) {
+	if ctx.Option.Flags&JSON5Option != 0 {
+		if _, _, end, ok := nonFiniteNumber(ctx.Buf, skipWhiteSpace(ctx.Buf, cursor)); ok {
+			return end, nil
+		}
+	}
+	return skipValue(ctx.Buf, cursor, depth)
+}
+
+// JSON5Enabled reports whether EnableJSON5 has been called.
+func (s *Stream) JSON5Enabled() bool {
+	return s.json5 != nil
+}
+
+// EnableJSON5 makes the stream translate the JSON5 source into JSON from the current position.
+func (s *Stream) EnableJSON5() {
+	if s.json5 != nil {
+		return
+	}
+	rest := make([]byte, 0, s.length-s.cursor)
+	for _, c := range s.buf[s.cursor:s.length] {
+		if c == nul {
+			break
+		}
+		rest = append(rest, c)
+	}
+	for i := s.cursor; i < s.length; i++ {
+		s.buf[i] = nul
+	}
+	s.length = s.cursor
+	s.allRead = false
+	s.json5 = newJSON5Reader(io.MultiReader(bytes.NewReader(rest), s.r), s.totalOffset())
+	s.r = s.json5
+}
+
+// JSON5Error returns the error found by translating the JSON5 source if any, otherwise err.
+// The decoders only see the end of the input when the translation fails.
+func (s *Stream) JSON5Error(err error) error {
+	if s.json5 != nil && s.json5.err != nil && s.json5.err != io.EOF {
+		return s.json5.err
+	}
+	return err
+}
+
+// nonFiniteNumber consumes and returns the literal and the value of NaN, Infinity or -Infinity at the cursor.
+func (s *Stream) nonFiniteNumber() (string, float64, bool) {
+	s.skipWhiteSpace()
+	for _, n := range nonFiniteNumbers {
+		if s.hasPrefix(n.literal) {
+			s.cursor += int64(len(n.literal))
+			return n.literal, n.value, true
+		}
+	}
+	return "", 0, false
+}
+
+func (s *Stream) hasPrefix(prefix string) bool {
+	for i := 0; i < len(prefix); i++ {
+		for s.buf[s.cursor+int64(i)] == nul {
+			if !s.read() {
+				return false
+			}
+		}
+		if s.buf[s.cursor+int64(i)] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// nonFiniteToken returns the token of NaN, Infinity or -Infinity at start with JSON5Option, or returns err.
+func (s *Stream) nonFiniteToken(start int64, err error) (interface{}, error) {
+	if s.Option.Flags&JSON5Option == 0 {
+		return nil, err
+	}
+	s.cursor = start
+	literal, f64, ok := s.nonFiniteNumber()
+	if !ok {
+		return nil, err
+	}
+	if s.UseNumber {
+		return json.Number(literal), nil
+	}
+	return f64, nil
+}
@@ -186,6 +186,38 @@ func (d *mapDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.P
 	}
 }
 
+// decodeEntry decodes the value at cursor and assigns it to the map pointed to by p with key.
+// It is used to collect unknown object members into the inline field of a struct.
+func (d *mapDecoder) decodeEntry(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer, key string) (int64, error) {
+	v := unsafe_New(d.valueType)
+	c, err := d.valueDecoder.Decode(ctx, cursor, depth, v)
+	if err != nil {
+		return 0, err
+	}
+	d.assignEntry(p, key, v)
+	return c, nil
+}
+
+func (d *mapDecoder) decodeStreamEntry(s *Stream, depth int64, p unsafe.Pointer, key string) error {
+	v := unsafe_New(d.valueType)
+	if err := d.valueDecoder.DecodeStream(s, depth, v); err != nil {
+		return err
+	}
+	d.assignEntry(p, key, v)
+	return nil
+}
+
+func (d *mapDecoder) assignEntry(p unsafe.Pointer, key string, v unsafe.Pointer) {
+	mapValue := *(*unsafe.Pointer)(p)
+	if mapValue == nil {
+		mapValue = makemap(d.mapType, 0)
+		**(**unsafe.Pointer)(unsafe.Pointer(&p)) = mapValue
+	}
+	k := unsafe_New(d.keyType)
+	*(*string)(k) = key
+	d.mapassign(d.mapType, mapValue, k, v)
+}
+
 func (d *mapDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][]byte, int64, error) {
 	buf := ctx.Buf
 	depth++
@@ -253,7 +285,7 @@ func (d *mapDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][]b
 				cursor = c
 			} else {
 				start := cursor
-				end, err := skipValue(buf, cursor, depth)
+				end, err := skipValueWithOption(ctx, cursor, depth)
 				if err != nil {
 					return nil, 0, err
 				}
@@ -261,7 +293,7 @@ func (d *mapDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][]b
 				cursor = end
 			}
 		} else {
-			c, err := skipValue(buf, cursor, depth)
+			c, err := skipValueWithOption(ctx, cursor, depth)
 			if err != nil {
 				return nil, 0, err
 			}
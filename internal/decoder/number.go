@@ -25,25 +25,41 @@ func newNumberDecoder(structName, fieldName string, op func(unsafe.Pointer, json
 }
 
 func (d *numberDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
+	start := s.cursor
 	bytes, err := d.decodeStreamByte(s)
 	if err != nil {
-		return err
+		return d.decodeStreamNonFinite(s, start, p, err)
 	}
 	if _, err := strconv.ParseFloat(*(*string)(unsafe.Pointer(&bytes)), 64); err != nil {
-		return errors.ErrSyntax(err.Error(), s.totalOffset())
+		return d.decodeStreamNonFinite(s, start, p, errors.ErrSyntax(err.Error(), s.totalOffset()))
 	}
 	d.op(p, json.Number(string(bytes)))
 	s.reset()
 	return nil
 }
 
+// decodeStreamNonFinite decodes NaN, Infinity or -Infinity at start with JSON5Option, or returns err.
+func (d *numberDecoder) decodeStreamNonFinite(s *Stream, start int64, p unsafe.Pointer, err error) error {
+	if s.Option.Flags&JSON5Option == 0 {
+		return err
+	}
+	s.cursor = start
+	literal, _, ok := s.nonFiniteNumber()
+	if !ok {
+		return err
+	}
+	d.op(p, json.Number(literal))
+	s.reset()
+	return nil
+}
+
 func (d *numberDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
 	bytes, c, err := d.decodeByte(ctx.Buf, cursor)
 	if err != nil {
-		return 0, err
+		return d.decodeNonFinite(ctx, cursor, p, err)
 	}
 	if _, err := strconv.ParseFloat(*(*string)(unsafe.Pointer(&bytes)), 64); err != nil {
-		return 0, errors.ErrSyntax(err.Error(), c)
+		return d.decodeNonFinite(ctx, cursor, p, errors.ErrSyntax(err.Error(), c))
 	}
 	cursor = c
 	s := *(*string)(unsafe.Pointer(&bytes))
@@ -51,6 +67,19 @@ func (d *numberDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 	return cursor, nil
 }
 
+// decodeNonFinite decodes NaN, Infinity or -Infinity at cursor with JSON5Option, or returns err.
+func (d *numberDecoder) decodeNonFinite(ctx *RuntimeContext, cursor int64, p unsafe.Pointer, err error) (int64, error) {
+	if ctx.Option.Flags&JSON5Option == 0 {
+		return 0, err
+	}
+	literal, _, end, ok := nonFiniteNumber(ctx.Buf, skipWhiteSpace(ctx.Buf, cursor))
+	if !ok {
+		return 0, err
+	}
+	d.op(p, json.Number(literal))
+	return end, nil
+}
+
 func (d *numberDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][]byte, int64, error) {
 	bytes, c, err := d.decodeByte(ctx.Buf, cursor)
 	if err != nil {
@@ -1,6 +1,10 @@
 package decoder
 
-import "context"
+import (
+	"context"
+
+	"github.com/goccy/go-json/internal/runtime"
+)
 
 type OptionFlags uint8
 
@@ -8,10 +12,13 @@ const (
 	FirstWinOption OptionFlags = 1 << iota
 	ContextOption
 	PathOption
+	Int64StringOption
+	JSON5Option
 )
 
 type Option struct {
-	Flags   OptionFlags
-	Context context.Context
-	Path    *Path
+	Flags     OptionFlags
+	Context   context.Context
+	Path      *Path
+	StructTag *runtime.StructTagOption
 }
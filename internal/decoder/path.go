@@ -153,8 +153,16 @@ func (b *PathBuilder) buildQuoteSelector(buf []rune, sel QuotePathSelector) (int
 	case '[', ']', '$', '.', '*', '\'', '"':
 		return 0, errors.ErrInvalidPath("found invalid path character %c after quote", buf[0])
 	}
+	var escaped bool
 	for cursor := 0; cursor < len(buf); cursor++ {
 		switch buf[cursor] {
+		case '\\':
+			// the quotes and the backslash in the quoted selector are escaped by a backslash.
+			if cursor+1 >= len(buf) {
+				return 0, errors.ErrInvalidPath("JSON Path ends with backslash character in field selector context")
+			}
+			escaped = true
+			cursor++
 		case '\'':
 			if sel != SingleQuotePathSelector {
 				return 0, errors.ErrInvalidPath("found double quote character in field selector with single quote context")
@@ -166,6 +174,9 @@ func (b *PathBuilder) buildQuoteSelector(buf []rune, sel QuotePathSelector) (int
 				return 0, errors.ErrInvalidPath("expect right bracket for field selector with single quote but found %c", buf[cursor+1])
 			}
 			selector := buf[:cursor]
+			if escaped {
+				selector = unescapePathSelector(selector)
+			}
 			b.addSelectorNode(string(selector))
 			b.singleQuotePathSelector = true
 			return b.buildNextCharIfExists(buf, cursor+2)
@@ -174,6 +185,9 @@ func (b *PathBuilder) buildQuoteSelector(buf []rune, sel QuotePathSelector) (int
 				return 0, errors.ErrInvalidPath("found single quote character in field selector with double quote context")
 			}
 			selector := buf[:cursor]
+			if escaped {
+				selector = unescapePathSelector(selector)
+			}
 			b.addSelectorNode(string(selector))
 			b.doubleQuotePathSelector = true
 			return b.buildNextCharIfExists(buf, cursor+1)
@@ -182,6 +196,18 @@ func (b *PathBuilder) buildQuoteSelector(buf []rune, sel QuotePathSelector) (int
 	return 0, errors.ErrInvalidPath("couldn't find quote character in selector quote path context")
 }
 
+// unescapePathSelector removes the backslashes escaping the characters of the quoted selector.
+func unescapePathSelector(buf []rune) []rune {
+	selector := make([]rune, 0, len(buf))
+	for i := 0; i < len(buf); i++ {
+		if buf[i] == '\\' {
+			i++
+		}
+		selector = append(selector, buf[i])
+	}
+	return selector
+}
+
 func (b *PathBuilder) buildPathRecursive(buf []rune) (int, error) {
 	switch buf[0] {
 	case '.', '[', ']', '$', '*':
@@ -413,7 +439,7 @@ func (n *PathSelectorNode) Get(src, dst reflect.Value) error {
 	case reflect.Struct:
 		typ := src.Type()
 		for i := 0; i < typ.Len(); i++ {
-			tag := runtime.StructTagFromField(typ.Field(i))
+			tag := runtime.StructTagFromField(typ.Field(i), nil)
 			child, found, err := n.Field(tag.Key)
 			if err != nil {
 				return err
@@ -621,7 +647,7 @@ func (n *PathRecursiveNode) Get(src, dst reflect.Value) error {
 	case reflect.Struct:
 		typ := src.Type()
 		for i := 0; i < typ.Len(); i++ {
-			tag := runtime.StructTagFromField(typ.Field(i))
+			tag := runtime.StructTagFromField(typ.Field(i), nil)
 			child, found, err := n.Field(tag.Key)
 			if err != nil {
 				return err
@@ -0,0 +1,79 @@
+package decoder
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// RecordSeparator is the byte that precedes each JSON text in JSON text sequences ( RFC 7464 ).
+const RecordSeparator = 0x1E
+
+// ReadSeqElement returns the next element of the JSON text sequence, that is, the bytes up to the next record separator.
+// The record separators are skipped, and the elements consisting only of white space are ignored
+// in the same way as the empty elements between the consecutive record separators.
+// The bytes before the first record separator are returned as an element.
+// It returns io.EOF at the end of the input, and the error of the reader if reading fails.
+func (s *Stream) ReadSeqElement() ([]byte, error) {
+	for {
+		start := s.cursor
+		cursor := start
+		for {
+			c := s.buf[cursor]
+			if c == RecordSeparator {
+				s.cursor = cursor + 1
+				break
+			}
+			if c == nul {
+				s.cursor = cursor
+				if s.read() {
+					continue
+				}
+				if err := s.readErr; err != nil {
+					// the element is read again from start by the next call.
+					s.readErr = nil
+					s.cursor = start
+					return nil, err
+				}
+				break
+			}
+			cursor++
+		}
+		elem := s.buf[start:cursor]
+		if !isBlank(elem) {
+			// keep the space for nul since the element is decoded from the copy.
+			copied := make([]byte, len(elem), len(elem)+1)
+			copy(copied, elem)
+			s.reset()
+			return copied, nil
+		}
+		s.reset()
+		if s.buf[s.cursor] == nul && !s.read() {
+			if err := s.readErr; err != nil {
+				s.readErr = nil
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+	}
+}
+
+// ValidateEnd returns an error if anything but white space remains in the input.
+func (s *Stream) ValidateEnd() error {
+	if c := s.skipWhiteSpace(); c != nul {
+		return errors.ErrSyntax(fmt.Sprintf("invalid character '%c' after top-level value", c), s.totalOffset()+1)
+	}
+	return nil
+}
+
+func isBlank(b []byte) bool {
+	for _, c := range b {
+		switch c {
+		case ' ', '\t', '\r', '\n':
+		default:
+			return false
+		}
+	}
+	return true
+}
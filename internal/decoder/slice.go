@@ -349,7 +349,7 @@ func (d *sliceDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][
 						cursor = c
 					} else {
 						start := cursor
-						end, err := skipValue(buf, cursor, depth)
+						end, err := skipValueWithOption(ctx, cursor, depth)
 						if err != nil {
 							return nil, 0, err
 						}
@@ -357,7 +357,7 @@ func (d *sliceDecoder) DecodePath(ctx *RuntimeContext, cursor, depth int64) ([][
 						cursor = end
 					}
 				} else {
-					c, err := skipValue(buf, cursor, depth)
+					c, err := skipValueWithOption(ctx, cursor, depth)
 					if err != nil {
 						return nil, 0, err
 					}
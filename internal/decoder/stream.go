@@ -27,6 +27,10 @@ type Stream struct {
 	UseNumber             bool
 	DisallowUnknownFields bool
 	Option                *Option
+	json5                 *json5Reader
+
+	// readErr is the last error other than io.EOF returned by r.
+	readErr error
 }
 
 func NewStream(r io.Reader) *Stream {
@@ -138,14 +142,18 @@ func (s *Stream) Token() (interface{}, error) {
 		case ',', ':':
 			s.cursor++
 		case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+			start := s.cursor
 			bytes := floatBytes(s)
 			str := *(*string)(unsafe.Pointer(&bytes))
 			if s.UseNumber {
+				if str == "-" && s.Option.Flags&JSON5Option != 0 {
+					return s.nonFiniteToken(start, errors.ErrInvalidCharacter(s.char(), "number", s.totalOffset()))
+				}
 				return json.Number(str), nil
 			}
 			f64, err := strconv.ParseFloat(str, 64)
 			if err != nil {
-				return nil, err
+				return s.nonFiniteToken(start, err)
 			}
 			return f64, nil
 		case '"':
@@ -175,7 +183,7 @@ func (s *Stream) Token() (interface{}, error) {
 			}
 			goto END
 		default:
-			return nil, errors.ErrInvalidCharacter(s.char(), "token", s.totalOffset())
+			return s.nonFiniteToken(s.cursor, errors.ErrInvalidCharacter(s.char(), "token", s.totalOffset()))
 		}
 	}
 END:
@@ -229,6 +237,7 @@ func (s *Stream) read() bool {
 	if err == io.EOF {
 		s.allRead = true
 	} else if err != nil {
+		s.readErr = err
 		return false
 	}
 	return true
@@ -378,6 +387,11 @@ func (s *Stream) skipArray(depth int64) error {
 }
 
 func (s *Stream) skipValue(depth int64) error {
+	if s.Option.Flags&JSON5Option != 0 {
+		if _, _, ok := s.nonFiniteNumber(); ok {
+			return nil
+		}
+	}
 	_, cursor, p := s.stat()
 	for {
 		switch char(p, cursor) {
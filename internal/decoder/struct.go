@@ -11,6 +11,7 @@ import (
 	"unsafe"
 
 	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/runtime"
 )
 
 type structFieldSet struct {
@@ -35,6 +36,31 @@ type structDecoder struct {
 	sortedFieldSets    []*structFieldSet
 	keyDecoder         func(*structDecoder, []byte, int64) (int64, *structFieldSet, error)
 	keyStreamDecoder   func(*structDecoder, *Stream) (*structFieldSet, string, error)
+	inlineField        *inlineFieldSet
+}
+
+// inlineFieldSet is the map field tagged with the inline option.
+// It collects the object members that do not match any other field.
+type inlineFieldSet struct {
+	dec    *mapDecoder
+	offset uintptr
+
+	// embedded is the inline field of the struct embedded by the pointer at offset.
+	// The pointer is allocated with structType when it is nil.
+	embedded   *inlineFieldSet
+	structType *runtime.Type
+}
+
+// mapPtr returns the address of the inline map in the struct at p.
+func (s *inlineFieldSet) mapPtr(p unsafe.Pointer) unsafe.Pointer {
+	p = unsafe.Pointer(uintptr(p) + s.offset)
+	if s.embedded == nil {
+		return p
+	}
+	if *(*unsafe.Pointer)(p) == nil {
+		*(*unsafe.Pointer)(p) = unsafe_New(s.structType)
+	}
+	return s.embedded.mapPtr(*(*unsafe.Pointer)(p))
 }
 
 var (
@@ -94,6 +120,11 @@ func (d *structDecoder) tryOptimize() {
 	if d.isTriedOptimize {
 		return
 	}
+	if d.inlineField != nil {
+		// the optimized key decoders do not keep the unknown keys required by the inline field.
+		d.isTriedOptimize = true
+		return
+	}
 	fieldMap := map[string]*structFieldSet{}
 	conflicted := map[string]struct{}{}
 	for k, v := range d.fieldMap {
@@ -660,6 +691,15 @@ func decodeKeyStream(d *structDecoder, s *Stream) (*structFieldSet, string, erro
 	return d.fieldMap[k], k, nil
 }
 
+// inlineFieldLookup returns the field for key of the struct that has the inline field.
+// Since such a struct does not use the optimized key decoders, keys are matched case-insensitively here.
+func (d *structDecoder) inlineFieldLookup(key string) *structFieldSet {
+	if field, exists := d.fieldMap[key]; exists {
+		return field
+	}
+	return d.fieldMap[strings.ToLower(key)]
+}
+
 func (d *structDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
 	depth++
 	if depth > maxDecodeNestingDepth {
@@ -697,6 +737,9 @@ func (d *structDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) e
 		if err != nil {
 			return err
 		}
+		if field == nil && d.inlineField != nil {
+			field = d.inlineFieldLookup(key)
+		}
 		if s.skipWhiteSpace() != ':' {
 			return errors.ErrExpected("colon after object key", s.totalOffset())
 		}
@@ -715,7 +758,7 @@ func (d *structDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) e
 						return err
 					}
 					seenFieldNum++
-					if d.fieldUniqueNameNum <= seenFieldNum {
+					if d.fieldUniqueNameNum <= seenFieldNum && d.inlineField == nil {
 						return s.skipObject(depth)
 					}
 					seenFields[field.fieldIdx] = struct{}{}
@@ -725,6 +768,11 @@ func (d *structDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) e
 					return err
 				}
 			}
+		} else if d.inlineField != nil {
+			// key refers to the stream buffer that may be overwritten while decoding the value.
+			if err := d.inlineField.dec.decodeStreamEntry(s, depth, d.inlineField.mapPtr(p), string([]byte(key))); err != nil {
+				return err
+			}
 		} else if s.DisallowUnknownFields {
 			return fmt.Errorf("json: unknown field %q", key)
 		} else {
@@ -779,7 +827,18 @@ func (d *structDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 		seenFields = make(map[int]struct{}, d.fieldUniqueNameNum)
 	}
 	for {
-		c, field, err := d.keyDecoder(d, buf, cursor)
+		var (
+			c     int64
+			field *structFieldSet
+			key   []byte
+			err   error
+		)
+		if d.inlineField != nil {
+			key, c, err = d.stringDecoder.decodeByte(buf, cursor)
+			field = d.inlineFieldLookup(*(*string)(unsafe.Pointer(&key)))
+		} else {
+			c, field, err = d.keyDecoder(d, buf, cursor)
+		}
 		if err != nil {
 			return 0, err
 		}
@@ -797,7 +856,7 @@ func (d *structDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 			}
 			if firstWin {
 				if _, exists := seenFields[field.fieldIdx]; exists {
-					c, err := skipValue(buf, cursor, depth)
+					c, err := skipValueWithOption(ctx, cursor, depth)
 					if err != nil {
 						return 0, err
 					}
@@ -809,7 +868,7 @@ func (d *structDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 					}
 					cursor = c
 					seenFieldNum++
-					if d.fieldUniqueNameNum <= seenFieldNum {
+					if d.fieldUniqueNameNum <= seenFieldNum && d.inlineField == nil {
 						return skipObject(buf, cursor, depth)
 					}
 					seenFields[field.fieldIdx] = struct{}{}
@@ -821,8 +880,14 @@ func (d *structDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsaf
 				}
 				cursor = c
 			}
+		} else if d.inlineField != nil {
+			c, err := d.inlineField.dec.decodeEntry(ctx, cursor, depth, d.inlineField.mapPtr(p), string(key))
+			if err != nil {
+				return 0, err
+			}
+			cursor = c
 		} else {
-			c, err := skipValue(buf, cursor, depth)
+			c, err := skipValueWithOption(ctx, cursor, depth)
 			if err != nil {
 				return 0, err
 			}
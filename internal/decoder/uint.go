@@ -128,10 +128,49 @@ func (d *uintDecoder) decodeByte(buf []byte, cursor int64) ([]byte, int64, error
 	}
 }
 
+// decodeQuotedStreamByte reads a number enclosed in a JSON string.
+func (d *uintDecoder) decodeQuotedStreamByte(s *Stream) ([]byte, error) {
+	if s.char() != '"' {
+		return d.decodeStreamByte(s)
+	}
+	s.cursor++
+	num, err := d.decodeStreamByte(s)
+	if err != nil {
+		return nil, err
+	}
+	if num == nil || s.char() != '"' {
+		return nil, d.typeError([]byte{s.char()}, s.totalOffset())
+	}
+	s.cursor++
+	return num, nil
+}
+
+// decodeQuotedByte reads a number enclosed in a JSON string.
+func (d *uintDecoder) decodeQuotedByte(buf []byte, cursor int64) ([]byte, int64, error) {
+	cursor = skipWhiteSpace(buf, cursor)
+	if buf[cursor] != '"' {
+		return d.decodeByte(buf, cursor)
+	}
+	num, c, err := d.decodeByte(buf, cursor+1)
+	if err != nil {
+		return nil, 0, err
+	}
+	if num == nil || buf[c] != '"' {
+		return nil, 0, d.typeError([]byte{buf[c]}, c)
+	}
+	return num, c + 1, nil
+}
+
 func (d *uintDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) error {
 	bytes, err := d.decodeStreamByte(s)
 	if err != nil {
-		return err
+		if !isInt64StringAllowed(s.Option, d.typ) {
+			return err
+		}
+		bytes, err = d.decodeQuotedStreamByte(s)
+		if err != nil {
+			return err
+		}
 	}
 	if bytes == nil {
 		return nil
@@ -161,7 +200,13 @@ func (d *uintDecoder) DecodeStream(s *Stream, depth int64, p unsafe.Pointer) err
 func (d *uintDecoder) Decode(ctx *RuntimeContext, cursor, depth int64, p unsafe.Pointer) (int64, error) {
 	bytes, c, err := d.decodeByte(ctx.Buf, cursor)
 	if err != nil {
-		return 0, err
+		if !isInt64StringAllowed(ctx.Option, d.typ) {
+			return 0, err
+		}
+		bytes, c, err = d.decodeQuotedByte(ctx.Buf, cursor)
+		if err != nil {
+			return 0, err
+		}
 	}
 	if bytes == nil {
 		return c, nil
@@ -73,7 +73,7 @@ func (d *unmarshalJSONDecoder) Decode(ctx *RuntimeContext, cursor, depth int64,
 	buf := ctx.Buf
 	cursor = skipWhiteSpace(buf, cursor)
 	start := cursor
-	end, err := skipValue(buf, cursor, depth)
+	end, err := skipValueWithOption(ctx, cursor, depth)
 	if err != nil {
 		return 0, err
 	}
@@ -0,0 +1,333 @@
+package encoder
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// Canonicalize appends the canonical form of the JSON-encoded src defined by
+// RFC 8785 ( JSON Canonicalization Scheme ) to dst.
+func Canonicalize(dst, src []byte) ([]byte, error) {
+	if len(src) == 0 {
+		return nil, errors.ErrUnexpectedEndOfJSON("", 0)
+	}
+
+	ctx := TakeRuntimeContext()
+	ctxBuf := ctx.Buf[:0]
+	ctxBuf = append(append(ctxBuf, src...), nul)
+	ctx.Buf = ctxBuf
+	defer ReleaseRuntimeContext(ctx)
+
+	dst, cursor, err := canonicalValue(dst, ctxBuf, 0)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateEndBuf(ctxBuf, cursor); err != nil {
+		return nil, err
+	}
+	return dst, nil
+}
+
+func canonicalValue(dst, src []byte, cursor int64) ([]byte, int64, error) {
+	cursor = skipWhiteSpace(src, cursor)
+	switch src[cursor] {
+	case '{':
+		return canonicalObject(dst, src, cursor)
+	case '[':
+		return canonicalArray(dst, src, cursor)
+	case '"':
+		s, c, err := canonicalUnquote(nil, src, cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		return appendCanonicalString(dst, s), c, nil
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return canonicalNumber(dst, src, cursor)
+	case 't':
+		return compactTrue(dst, src, cursor)
+	case 'f':
+		return compactFalse(dst, src, cursor)
+	case 'n':
+		return compactNull(dst, src, cursor)
+	case nul:
+		return nil, 0, errors.ErrUnexpectedEndOfJSON("value", cursor)
+	}
+	return nil, 0, errors.ErrSyntax(fmt.Sprintf("unexpected character '%c'", src[cursor]), cursor)
+}
+
+type canonicalMember struct {
+	key   []uint16
+	entry []byte // canonical form of "key":value
+}
+
+func canonicalObject(dst, src []byte, cursor int64) ([]byte, int64, error) {
+	cursor = skipWhiteSpace(src, cursor+1)
+	if src[cursor] == '}' {
+		return append(dst, '{', '}'), cursor + 1, nil
+	}
+	var (
+		members []canonicalMember
+		key     []byte
+		entry   []byte
+		err     error
+	)
+	for {
+		cursor = skipWhiteSpace(src, cursor)
+		key, cursor, err = canonicalUnquote(nil, src, cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		cursor = skipWhiteSpace(src, cursor)
+		if src[cursor] != ':' {
+			return nil, 0, errors.ErrExpected("colon after object key", cursor)
+		}
+		entry = append(appendCanonicalString(nil, key), ':')
+		entry, cursor, err = canonicalValue(entry, src, cursor+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		members = append(members, canonicalMember{
+			key:   utf16.Encode([]rune(string(key))),
+			entry: entry,
+		})
+		cursor = skipWhiteSpace(src, cursor)
+		switch src[cursor] {
+		case '}':
+			cursor++
+		case ',':
+			cursor++
+			continue
+		default:
+			return nil, 0, errors.ErrExpected("comma after object value", cursor)
+		}
+		break
+	}
+	// object keys are sorted by their UTF-16 code units ( RFC 8785 section 3.2.3 )
+	sort.Slice(members, func(i, j int) bool {
+		return compareUTF16(members[i].key, members[j].key) < 0
+	})
+	dst = append(dst, '{')
+	for i, member := range members {
+		if i > 0 {
+			if compareUTF16(members[i-1].key, member.key) == 0 {
+				return nil, 0, errors.ErrSyntax(fmt.Sprintf("duplicate object key %q", string(utf16.Decode(member.key))), cursor)
+			}
+			dst = append(dst, ',')
+		}
+		dst = append(dst, member.entry...)
+	}
+	return append(dst, '}'), cursor, nil
+}
+
+func compareUTF16(a, b []uint16) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			if a[i] < b[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return len(a) - len(b)
+}
+
+func canonicalArray(dst, src []byte, cursor int64) ([]byte, int64, error) {
+	dst = append(dst, '[')
+	cursor = skipWhiteSpace(src, cursor+1)
+	if src[cursor] == ']' {
+		return append(dst, ']'), cursor + 1, nil
+	}
+	var err error
+	for {
+		dst, cursor, err = canonicalValue(dst, src, cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		cursor = skipWhiteSpace(src, cursor)
+		switch src[cursor] {
+		case ']':
+			return append(dst, ']'), cursor + 1, nil
+		case ',':
+			dst = append(dst, ',')
+		default:
+			return nil, 0, errors.ErrExpected("comma after array value", cursor)
+		}
+		cursor++
+	}
+}
+
+// canonicalNumber formats the number in the same way as Number.prototype.toString of ECMAScript.
+func canonicalNumber(dst, src []byte, cursor int64) ([]byte, int64, error) {
+	start := cursor
+	for {
+		cursor++
+		if floatTable[src[cursor]] {
+			continue
+		}
+		break
+	}
+	num := src[start:cursor]
+	f, err := strconv.ParseFloat(*(*string)(unsafe.Pointer(&num)), 64)
+	if err != nil {
+		return nil, 0, err
+	}
+	if f == 0 {
+		// negative zero is also serialized as 0
+		return append(dst, '0'), cursor, nil
+	}
+	abs := math.Abs(f)
+	format := byte('f')
+	if abs < 1e-6 || abs >= 1e21 {
+		format = 'e'
+	}
+	dst = strconv.AppendFloat(dst, f, format, -1, 64)
+	if format == 'e' {
+		// clean up e-09 to e-9
+		n := len(dst)
+		if n >= 4 && dst[n-4] == 'e' && dst[n-3] == '-' && dst[n-2] == '0' {
+			dst[n-2] = dst[n-1]
+			dst = dst[:n-1]
+		}
+	}
+	return dst, cursor, nil
+}
+
+// canonicalUnquote appends the value of the JSON string at cursor to dst.
+// The value must be valid UTF-8 without unpaired surrogates ( RFC 7493 ).
+func canonicalUnquote(dst, src []byte, cursor int64) ([]byte, int64, error) {
+	if src[cursor] != '"' {
+		return nil, 0, errors.ErrInvalidCharacter(src[cursor], "string", cursor)
+	}
+	cursor++
+	for {
+		switch c := src[cursor]; {
+		case c == '"':
+			return dst, cursor + 1, nil
+		case c == '\\':
+			cursor++
+			switch src[cursor] {
+			case '"', '\\', '/':
+				dst = append(dst, src[cursor])
+			case 'b':
+				dst = append(dst, '\b')
+			case 'f':
+				dst = append(dst, '\f')
+			case 'n':
+				dst = append(dst, '\n')
+			case 'r':
+				dst = append(dst, '\r')
+			case 't':
+				dst = append(dst, '\t')
+			case 'u':
+				r, c, err := canonicalUnicode(src, cursor)
+				if err != nil {
+					return nil, 0, err
+				}
+				dst = utf8.AppendRune(dst, r)
+				cursor = c
+			default:
+				return nil, 0, errors.ErrInvalidCharacter(src[cursor], "escape sequence", cursor)
+			}
+			cursor++
+		case c < 0x20:
+			if c == nul && cursor == int64(len(src))-1 {
+				return nil, 0, errors.ErrUnexpectedEndOfJSON("string", cursor)
+			}
+			return nil, 0, errors.ErrInvalidCharacter(c, "string", cursor)
+		case c < utf8.RuneSelf:
+			dst = append(dst, c)
+			cursor++
+		default:
+			r, size := utf8.DecodeRune(src[cursor:])
+			if r == utf8.RuneError && size == 1 {
+				return nil, 0, errors.ErrSyntax("invalid UTF-8 in string", cursor)
+			}
+			dst = append(dst, src[cursor:cursor+int64(size)]...)
+			cursor += int64(size)
+		}
+	}
+}
+
+// canonicalUnicode reads the \uXXXX escape sequence whose 'u' is at cursor,
+// combining it with the following escape sequence if it is a surrogate pair.
+// It returns the cursor of the last hex digit.
+func canonicalUnicode(src []byte, cursor int64) (rune, int64, error) {
+	r, err := parseHex4(src, cursor+1)
+	if err != nil {
+		return 0, 0, err
+	}
+	cursor += 4
+	if !utf16.IsSurrogate(r) {
+		return r, cursor, nil
+	}
+	if r < 0xDC00 && src[cursor+1] == '\\' && src[cursor+2] == 'u' {
+		r2, err := parseHex4(src, cursor+3)
+		if err != nil {
+			return 0, 0, err
+		}
+		if combined := utf16.DecodeRune(r, r2); combined != utf8.RuneError {
+			return combined, cursor + 6, nil
+		}
+	}
+	return 0, 0, errors.ErrSyntax("invalid surrogate pair in string", cursor)
+}
+
+func parseHex4(src []byte, cursor int64) (rune, error) {
+	var r rune
+	for i := int64(0); i < 4; i++ {
+		c := src[cursor+i]
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c = c - 'a' + 10
+		case 'A' <= c && c <= 'F':
+			c = c - 'A' + 10
+		default:
+			if c == nul && cursor+i == int64(len(src))-1 {
+				return 0, errors.ErrUnexpectedEndOfJSON("string", cursor+i)
+			}
+			return 0, errors.ErrInvalidCharacter(c, "escape sequence", cursor+i)
+		}
+		r = r*16 + rune(c)
+	}
+	return r, nil
+}
+
+// appendCanonicalString appends s as a JSON string that escapes only the characters required by RFC 8785.
+func appendCanonicalString(dst, s []byte) []byte {
+	dst = append(dst, '"')
+	start := 0
+	for i, c := range s {
+		if c >= 0x20 && c != '"' && c != '\\' {
+			continue
+		}
+		dst = append(dst, s[start:i]...)
+		switch c {
+		case '"', '\\':
+			dst = append(dst, '\\', c)
+		case '\b':
+			dst = append(dst, '\\', 'b')
+		case '\f':
+			dst = append(dst, '\\', 'f')
+		case '\n':
+			dst = append(dst, '\\', 'n')
+		case '\r':
+			dst = append(dst, '\\', 'r')
+		case '\t':
+			dst = append(dst, '\\', 't')
+		default:
+			dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+		}
+		start = i + 1
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
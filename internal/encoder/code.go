@@ -55,13 +55,15 @@ const (
 	CodeKindMarshalJSON
 	CodeKindMarshalText
 	CodeKindRecursive
+	CodeKindCustom
 )
 
 type IntCode struct {
-	typ      *runtime.Type
-	bitSize  uint8
-	isString bool
-	isPtr    bool
+	typ            *runtime.Type
+	bitSize        uint8
+	isString       bool
+	isUnsafeString bool
+	isPtr          bool
 }
 
 func (c *IntCode) Kind() CodeKind {
@@ -71,6 +73,8 @@ func (c *IntCode) Kind() CodeKind {
 func (c *IntCode) ToOpcode(ctx *compileContext) Opcodes {
 	var code *Opcode
 	switch {
+	case c.isPtr && c.isString:
+		code = newOpCode(ctx, c.typ, OpIntPtrString)
 	case c.isPtr:
 		code = newOpCode(ctx, c.typ, OpIntPtr)
 	case c.isString:
@@ -79,6 +83,9 @@ func (c *IntCode) ToOpcode(ctx *compileContext) Opcodes {
 		code = newOpCode(ctx, c.typ, OpInt)
 	}
 	code.NumBitSize = c.bitSize
+	if c.isUnsafeString {
+		code.Flags |= UnsafeIntStringFlags
+	}
 	ctx.incIndex()
 	return Opcodes{code}
 }
@@ -88,10 +95,11 @@ func (c *IntCode) Filter(_ *FieldQuery) Code {
 }
 
 type UintCode struct {
-	typ      *runtime.Type
-	bitSize  uint8
-	isString bool
-	isPtr    bool
+	typ            *runtime.Type
+	bitSize        uint8
+	isString       bool
+	isUnsafeString bool
+	isPtr          bool
 }
 
 func (c *UintCode) Kind() CodeKind {
@@ -101,6 +109,8 @@ func (c *UintCode) Kind() CodeKind {
 func (c *UintCode) ToOpcode(ctx *compileContext) Opcodes {
 	var code *Opcode
 	switch {
+	case c.isPtr && c.isString:
+		code = newOpCode(ctx, c.typ, OpUintPtrString)
 	case c.isPtr:
 		code = newOpCode(ctx, c.typ, OpUintPtr)
 	case c.isString:
@@ -109,6 +119,9 @@ func (c *UintCode) ToOpcode(ctx *compileContext) Opcodes {
 		code = newOpCode(ctx, c.typ, OpUint)
 	}
 	code.NumBitSize = c.bitSize
+	if c.isUnsafeString {
+		code.Flags |= UnsafeIntStringFlags
+	}
 	ctx.incIndex()
 	return Opcodes{code}
 }
@@ -212,8 +225,9 @@ func (c *BoolCode) Filter(_ *FieldQuery) Code {
 }
 
 type BytesCode struct {
-	typ   *runtime.Type
-	isPtr bool
+	typ      *runtime.Type
+	isPtr    bool
+	isNonNil bool
 }
 
 func (c *BytesCode) Kind() CodeKind {
@@ -228,6 +242,9 @@ func (c *BytesCode) ToOpcode(ctx *compileContext) Opcodes {
 	default:
 		code = newOpCode(ctx, c.typ, OpBytes)
 	}
+	if c.isNonNil {
+		code.Flags |= NonNilFlags
+	}
 	ctx.incIndex()
 	return Opcodes{code}
 }
@@ -237,8 +254,9 @@ func (c *BytesCode) Filter(_ *FieldQuery) Code {
 }
 
 type SliceCode struct {
-	typ   *runtime.Type
-	value Code
+	typ      *runtime.Type
+	value    Code
+	isNonNil bool
 }
 
 func (c *SliceCode) Kind() CodeKind {
@@ -251,6 +269,9 @@ func (c *SliceCode) ToOpcode(ctx *compileContext) Opcodes {
 	//             |________|
 	size := c.typ.Elem().Size()
 	header := newSliceHeaderCode(ctx, c.typ)
+	if c.isNonNil {
+		header.Flags |= NonNilFlags
+	}
 	ctx.incIndex()
 
 	ctx.incIndent()
@@ -270,8 +291,12 @@ func (c *SliceCode) ToOpcode(ctx *compileContext) Opcodes {
 	return Opcodes{header}.Add(codes...).Add(elemCode).Add(end)
 }
 
-func (c *SliceCode) Filter(_ *FieldQuery) Code {
-	return c
+func (c *SliceCode) Filter(query *FieldQuery) Code {
+	return &SliceCode{
+		typ:      c.typ,
+		value:    c.value.Filter(query),
+		isNonNil: c.isNonNil,
+	}
 }
 
 type ArrayCode struct {
@@ -315,14 +340,47 @@ func (c *ArrayCode) ToOpcode(ctx *compileContext) Opcodes {
 	return Opcodes{header}.Add(codes...).Add(elemCode).Add(end)
 }
 
-func (c *ArrayCode) Filter(_ *FieldQuery) Code {
-	return c
+func (c *ArrayCode) Filter(query *FieldQuery) Code {
+	return &ArrayCode{
+		typ:   c.typ,
+		value: c.value.Filter(query),
+	}
 }
 
 type MapCode struct {
-	typ   *runtime.Type
-	key   Code
-	value Code
+	typ      *runtime.Type
+	key      Code
+	value    Code
+	isNonNil bool
+	isInline bool
+
+	// isRedactKey is set if the values of the entries whose keys are the keys of the Redaction are replaced.
+	isRedactKey bool
+
+	// inlineKeys selects the entries of the inline map, or is nil if all entries are encoded.
+	inlineKeys *InlineKeys
+}
+
+// InlineKeys selects the entries of an inline map to encode.
+type InlineKeys struct {
+	// fieldKeys are the keys of the fields of the parent struct of the inline map.
+	// The entries with these keys are skipped so that the fields take precedence.
+	fieldKeys map[string]struct{}
+
+	// query keeps the entries whose keys are kept as fields of the parent struct, or is nil if it is not filtered.
+	query *FieldQuery
+}
+
+// skips reports whether the entry with key is not encoded.
+func (k *InlineKeys) skips(key string) bool {
+	if _, exists := k.fieldKeys[key]; exists {
+		return true
+	}
+	if k.query == nil {
+		return false
+	}
+	_, kept := k.query.lookup(key)
+	return !kept
 }
 
 func (c *MapCode) Kind() CodeKind {
@@ -333,7 +391,15 @@ func (c *MapCode) ToOpcode(ctx *compileContext) Opcodes {
 	// header => code => value => code => key => code => value => code => end
 	//                                     ^                       |
 	//                                     |_______________________|
+	if c.isInline {
+		// entries of the inline map are written at the same level as the fields of the parent object.
+		ctx.decIndent()
+		defer ctx.incIndent()
+	}
 	header := newMapHeaderCode(ctx, c.typ)
+	if c.isNonNil {
+		header.Flags |= NonNilFlags
+	}
 	ctx.incIndex()
 
 	keyCodes := c.key.ToOpcode(ctx)
@@ -362,11 +428,41 @@ func (c *MapCode) ToOpcode(ctx *compileContext) Opcodes {
 	header.End = end
 	key.End = end
 	value.End = end
+	if c.isRedactKey {
+		// the redacted value is written by value itself, which then jumps to key.
+		value.Flags |= RedactFlags
+		value.NextField = key
+	}
+	if c.isInline {
+		header.Flags |= InlineFlags
+		key.Flags |= InlineFlags
+		end.Flags |= InlineFlags
+		header.InlineKeys = c.inlineKeys
+		key.InlineKeys = c.inlineKeys
+	}
 	return Opcodes{header}.Add(keyCodes...).Add(value).Add(valueCodes...).Add(key).Add(end)
 }
 
-func (c *MapCode) Filter(_ *FieldQuery) Code {
-	return c
+// filterInlineEntries returns the inline map whose entries are filtered by query as the fields of the parent struct.
+func (c *MapCode) filterInlineEntries(query *FieldQuery) *MapCode {
+	code := *c
+	code.inlineKeys = &InlineKeys{query: query}
+	if c.inlineKeys != nil {
+		code.inlineKeys.fieldKeys = c.inlineKeys.fieldKeys
+	}
+	return &code
+}
+
+func (c *MapCode) Filter(query *FieldQuery) Code {
+	return &MapCode{
+		typ:         c.typ,
+		key:         c.key,
+		value:       c.value.Filter(query),
+		isNonNil:    c.isNonNil,
+		isInline:    c.isInline,
+		isRedactKey: c.isRedactKey,
+		inlineKeys:  c.inlineKeys,
+	}
 }
 
 type StructCode struct {
@@ -542,6 +638,35 @@ func (c *StructCode) removeFieldsByTags(tags runtime.StructTags) {
 	c.fields = fields
 }
 
+// setInlineKeys gives the keys of the fields, including the fields of the embedded structs, to the inline maps of c.
+func (c *StructCode) setInlineKeys() {
+	keys := map[string]struct{}{}
+	var inlineMaps []*MapCode
+	c.collectInlineKeys(keys, &inlineMaps)
+	if len(keys) == 0 {
+		return
+	}
+	for _, inlineMap := range inlineMaps {
+		inlineMap.inlineKeys = &InlineKeys{fieldKeys: keys}
+	}
+}
+
+func (c *StructCode) collectInlineKeys(keys map[string]struct{}, inlineMaps *[]*MapCode) {
+	for _, field := range c.fields {
+		if field.isInline {
+			if mapCode, ok := field.value.(*MapCode); ok {
+				*inlineMaps = append(*inlineMaps, mapCode)
+			}
+			continue
+		}
+		if structCode := field.getAnonymousStruct(); structCode != nil && !structCode.isRecursive {
+			structCode.collectInlineKeys(keys, inlineMaps)
+			continue
+		}
+		keys[field.key] = struct{}{}
+	}
+}
+
 func (c *StructCode) enableIndirect() {
 	if c.isIndirect {
 		return
@@ -558,33 +683,30 @@ func (c *StructCode) enableIndirect() {
 }
 
 func (c *StructCode) Filter(query *FieldQuery) Code {
-	fieldMap := map[string]*FieldQuery{}
-	for _, field := range query.Fields {
-		fieldMap[field.Name] = field
-	}
 	fields := make([]*StructFieldCode, 0, len(c.fields))
 	for _, field := range c.fields {
-		query, exists := fieldMap[field.key]
-		if !exists {
+		if mapCode, ok := field.value.(*MapCode); ok && field.isInline && !query.hasField(field.key) {
+			// the entries of the inline map are filtered as the fields of this structure.
+			fields = append(fields, field.withValue(mapCode.filterInlineEntries(query)))
 			continue
 		}
-		fieldCode := &StructFieldCode{
-			typ:                field.typ,
-			key:                field.key,
-			tag:                field.tag,
-			value:              field.value,
-			offset:             field.offset,
-			isAnonymous:        field.isAnonymous,
-			isTaggedKey:        field.isTaggedKey,
-			isNilableType:      field.isNilableType,
-			isNilCheck:         field.isNilCheck,
-			isAddrForMarshaler: field.isAddrForMarshaler,
-			isNextOpPtrType:    field.isNextOpPtrType,
+		if structCode := field.getAnonymousStruct(); structCode != nil && !structCode.isRecursive && !query.hasField(field.key) {
+			// the fields of the embedded structure are filtered as the fields of this structure.
+			fieldCode := field.withValue(field.value.Filter(query))
+			if len(fieldCode.getStruct().fields) > 0 {
+				fields = append(fields, fieldCode)
+			}
+			continue
+		}
+		fieldQuery, exists := query.lookup(field.key)
+		if !exists {
+			continue
 		}
-		if len(query.Fields) > 0 {
-			fieldCode.value = fieldCode.value.Filter(query)
+		if fieldQuery != nil {
+			fields = append(fields, field.withValue(field.value.Filter(fieldQuery)))
+		} else {
+			fields = append(fields, field.withValue(field.value))
 		}
-		fields = append(fields, fieldCode)
 	}
 	return &StructCode{
 		typ:                       c.typ,
@@ -609,6 +731,8 @@ type StructFieldCode struct {
 	isAddrForMarshaler bool
 	isNextOpPtrType    bool
 	isMarshalerContext bool
+	isInline           bool
+	isRedacted         bool
 }
 
 func (c *StructFieldCode) getStruct() *StructCode {
@@ -624,6 +748,13 @@ func (c *StructFieldCode) getStruct() *StructCode {
 	return nil
 }
 
+// withValue returns a copy of c that encodes value instead.
+func (c *StructFieldCode) withValue(value Code) *StructFieldCode {
+	fieldCode := *c
+	fieldCode.value = value
+	return &fieldCode
+}
+
 func (c *StructFieldCode) getAnonymousStruct() *StructCode {
 	if !c.isAnonymous {
 		return nil
@@ -654,6 +785,12 @@ func (c *StructFieldCode) headerOpcodes(ctx *compileContext, field *Opcode, valu
 	if value.Flags&MarshalerContextFlags != 0 {
 		field.Flags |= MarshalerContextFlags
 	}
+	if value.Flags&UnsafeIntStringFlags != 0 && !c.tag.IsString {
+		field.Flags |= UnsafeIntStringFlags
+	}
+	if value.Flags&NonNilFlags != 0 {
+		field.Flags |= NonNilFlags
+	}
 	// Set OmitZero flag if tag specifies omitzero
 	if c.tag.IsOmitZero {
 		field.Flags |= OmitZeroFlags
@@ -686,6 +823,12 @@ func (c *StructFieldCode) fieldOpcodes(ctx *compileContext, field *Opcode, value
 	if value.Flags&MarshalerContextFlags != 0 {
 		field.Flags |= MarshalerContextFlags
 	}
+	if value.Flags&UnsafeIntStringFlags != 0 && !c.tag.IsString {
+		field.Flags |= UnsafeIntStringFlags
+	}
+	if value.Flags&NonNilFlags != 0 {
+		field.Flags |= NonNilFlags
+	}
 	// Set OmitZero flag if tag specifies omitzero
 	if c.tag.IsOmitZero {
 		field.Flags |= OmitZeroFlags
@@ -735,6 +878,13 @@ func (c *StructFieldCode) addStructEndCode(ctx *compileContext, codes Opcodes) O
 }
 
 func (c *StructFieldCode) structKey(ctx *compileContext) string {
+	if ctx.keyEscape != nil {
+		opt := *ctx.keyEscape
+		if ctx.escapeKey {
+			opt.Flag |= HTMLEscapeOption
+		}
+		return string(AppendString(&RuntimeContext{Option: &opt}, []byte{}, c.key)) + ":"
+	}
 	if ctx.escapeKey {
 		rctx := &RuntimeContext{Option: &Option{Flag: HTMLEscapeOption}}
 		return string(AppendString(rctx, []byte{}, c.key)) + ":"
@@ -765,6 +915,12 @@ func (c *StructFieldCode) flags() OpFlags {
 	if c.isMarshalerContext {
 		flags |= MarshalerContextFlags
 	}
+	if c.isInline {
+		flags |= InlineFlags
+	}
+	if c.isRedacted {
+		flags |= RedactFlags
+	}
 	return flags
 }
 
@@ -918,6 +1074,42 @@ func (c *MarshalJSONCode) Filter(query *FieldQuery) Code {
 	}
 }
 
+type CustomCode struct {
+	typ           *runtime.Type
+	encoder       *CustomEncoder
+	isNilableType bool
+
+	// isRedacted is set if the value is replaced by the Redaction instead of encoder.
+	isRedacted bool
+	redactKey  string
+}
+
+func (c *CustomCode) Kind() CodeKind {
+	return CodeKindCustom
+}
+
+func (c *CustomCode) ToOpcode(ctx *compileContext) Opcodes {
+	code := newOpCode(ctx, c.typ, OpCustom)
+	// Custom opcodes never have the IsZero() method of a struct field, so the encoder is kept in IsZeroMethodFunc.
+	code.IsZeroMethodFunc = c.encoder
+	if c.isRedacted {
+		// the replacement is written by the VM so that it is colorized as a string.
+		code.Flags |= RedactFlags
+		code.Key = c.redactKey
+	}
+	if c.isNilableType {
+		code.Flags |= IsNilableTypeFlags
+	} else {
+		code.Flags &= ^IsNilableTypeFlags
+	}
+	ctx.incIndex()
+	return Opcodes{code}
+}
+
+func (c *CustomCode) Filter(_ *FieldQuery) Code {
+	return c
+}
+
 type MarshalTextCode struct {
 	typ                *runtime.Type
 	fieldQuery         *FieldQuery
@@ -1003,8 +1195,12 @@ func convertPtrOp(code *Opcode) OpType {
 	switch code.Op {
 	case OpInt:
 		return OpIntPtr
+	case OpIntString:
+		return OpIntPtrString
 	case OpUint:
 		return OpUintPtr
+	case OpUintString:
+		return OpUintPtrString
 	case OpFloat32:
 		return OpFloat32Ptr
 	case OpFloat64:
@@ -1027,6 +1223,8 @@ func convertPtrOp(code *Opcode) OpType {
 		return OpMarshalJSONPtr
 	case OpMarshalText:
 		return OpMarshalTextPtr
+	case OpCustom:
+		return OpCustomPtr
 	case OpInterface:
 		return OpInterfacePtr
 	case OpRecursive:
@@ -0,0 +1,198 @@
+package encoder
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// colorizer formats the JSON-encoded source in the same layout and with the same formats
+// as the encoder with ColorizeOption.
+type colorizer struct {
+	scheme      *EncodeFormatScheme
+	indent      bool
+	prefix      []byte
+	indentBytes []byte
+}
+
+// IndentColor appends to buf the indented form of the JSON-encoded src colored by scheme.
+func IndentColor(buf *bytes.Buffer, src []byte, prefix, indentStr string, scheme *EncodeFormatScheme) error {
+	return colorize(buf, src, &colorizer{
+		scheme:      scheme,
+		indent:      true,
+		prefix:      []byte(prefix),
+		indentBytes: []byte(indentStr),
+	})
+}
+
+// CompactColor appends to buf the JSON-encoded src with insignificant space characters elided, colored by scheme.
+func CompactColor(buf *bytes.Buffer, src []byte, scheme *EncodeFormatScheme) error {
+	return colorize(buf, src, &colorizer{scheme: scheme})
+}
+
+func colorize(buf *bytes.Buffer, src []byte, c *colorizer) error {
+	if len(src) == 0 {
+		return errors.ErrUnexpectedEndOfJSON("", 0)
+	}
+
+	srcCtx, srcBuf := takeIndentSrcRuntimeContext(src)
+	dstCtx := TakeRuntimeContext()
+	defer func() {
+		ReleaseRuntimeContext(srcCtx)
+		ReleaseRuntimeContext(dstCtx)
+	}()
+
+	dst, cursor, err := c.value(dstCtx.Buf[:0], srcBuf, 0, 0)
+	if err != nil {
+		return err
+	}
+	if err := validateEndBuf(srcBuf, cursor); err != nil {
+		return err
+	}
+	dstCtx.Buf = dst
+	_, err = buf.Write(dst)
+	return err
+}
+
+func (c *colorizer) value(dst, src []byte, indentNum int, cursor int64) ([]byte, int64, error) {
+	cursor = skipWhiteSpace(src, cursor)
+	switch src[cursor] {
+	case '{':
+		return c.object(dst, src, indentNum, cursor)
+	case '[':
+		return c.array(dst, src, indentNum, cursor)
+	case '"':
+		return c.string(c.scheme.String, dst, src, cursor)
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return c.number(dst, src, cursor)
+	case 't':
+		return c.format(c.scheme.Bool, dst, src, cursor, compactTrue)
+	case 'f':
+		return c.format(c.scheme.Bool, dst, src, cursor, compactFalse)
+	case 'n':
+		return c.format(c.scheme.Null, dst, src, cursor, compactNull)
+	case nul:
+		return nil, 0, errors.ErrUnexpectedEndOfJSON("value", cursor)
+	}
+	return nil, 0, errors.ErrSyntax(fmt.Sprintf("unexpected character '%c'", src[cursor]), cursor)
+}
+
+func (c *colorizer) object(dst, src []byte, indentNum int, cursor int64) ([]byte, int64, error) {
+	cursor = skipWhiteSpace(src, cursor+1)
+	if src[cursor] == '}' {
+		return c.delimiter(c.scheme.Brace, dst, "{}"), cursor + 1, nil
+	}
+	dst = c.delimiter(c.scheme.Brace, dst, "{")
+	indentNum++
+	var err error
+	for {
+		dst = c.newline(dst, indentNum)
+		cursor = skipWhiteSpace(src, cursor)
+		dst, cursor, err = c.string(c.scheme.ObjectKey, dst, src, cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		cursor = skipWhiteSpace(src, cursor)
+		if src[cursor] != ':' {
+			return nil, 0, errors.ErrExpected("colon after object key", cursor)
+		}
+		dst = c.delimiter(c.scheme.Colon, dst, ":")
+		if c.indent {
+			dst = append(dst, ' ')
+		}
+		dst, cursor, err = c.value(dst, src, indentNum, cursor+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		cursor = skipWhiteSpace(src, cursor)
+		switch src[cursor] {
+		case '}':
+			dst = c.newline(dst, indentNum-1)
+			return c.delimiter(c.scheme.Brace, dst, "}"), cursor + 1, nil
+		case ',':
+			dst = c.delimiter(c.scheme.Comma, dst, ",")
+		default:
+			return nil, 0, errors.ErrExpected("comma after object value", cursor)
+		}
+		cursor++
+	}
+}
+
+func (c *colorizer) array(dst, src []byte, indentNum int, cursor int64) ([]byte, int64, error) {
+	cursor = skipWhiteSpace(src, cursor+1)
+	if src[cursor] == ']' {
+		return c.delimiter(c.scheme.Bracket, dst, "[]"), cursor + 1, nil
+	}
+	dst = c.delimiter(c.scheme.Bracket, dst, "[")
+	indentNum++
+	var err error
+	for {
+		dst = c.newline(dst, indentNum)
+		dst, cursor, err = c.value(dst, src, indentNum, cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		cursor = skipWhiteSpace(src, cursor)
+		switch src[cursor] {
+		case ']':
+			dst = c.newline(dst, indentNum-1)
+			return c.delimiter(c.scheme.Bracket, dst, "]"), cursor + 1, nil
+		case ',':
+			dst = c.delimiter(c.scheme.Comma, dst, ",")
+		default:
+			return nil, 0, errors.ErrExpected("comma after array value", cursor)
+		}
+		cursor++
+	}
+}
+
+// number colors the integers by the Int format and the others by the Float format,
+// since the Go type of the value is unknown.
+func (c *colorizer) number(dst, src []byte, cursor int64) ([]byte, int64, error) {
+	format := c.scheme.Int
+	for i := cursor; floatTable[src[i]]; i++ {
+		if src[i] == '.' || src[i] == 'e' || src[i] == 'E' {
+			format = c.scheme.Float
+			break
+		}
+	}
+	return c.format(format, dst, src, cursor, compactNumber)
+}
+
+func (c *colorizer) string(format EncodeFormat, dst, src []byte, cursor int64) ([]byte, int64, error) {
+	return c.format(format, dst, src, cursor, func(dst, src []byte, cursor int64) ([]byte, int64, error) {
+		return compactString(dst, src, cursor, false)
+	})
+}
+
+func (c *colorizer) format(
+	format EncodeFormat,
+	dst []byte,
+	src []byte,
+	cursor int64,
+	appendValue func(dst, src []byte, cursor int64) ([]byte, int64, error)) ([]byte, int64, error) {
+	dst = append(dst, format.Header...)
+	dst, cursor, err := appendValue(dst, src, cursor)
+	if err != nil {
+		return nil, 0, err
+	}
+	return append(dst, format.Footer...), cursor, nil
+}
+
+func (c *colorizer) delimiter(format EncodeFormat, dst []byte, delim string) []byte {
+	dst = append(dst, format.Header...)
+	dst = append(dst, delim...)
+	return append(dst, format.Footer...)
+}
+
+func (c *colorizer) newline(dst []byte, indentNum int) []byte {
+	if !c.indent {
+		return dst
+	}
+	dst = append(append(dst, '\n'), c.prefix...)
+	for i := 0; i < indentNum; i++ {
+		dst = append(dst, c.indentBytes...)
+	}
+	return dst
+}
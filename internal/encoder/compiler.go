@@ -5,6 +5,7 @@ import (
 	"encoding"
 	"encoding/json"
 	"reflect"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"unsafe"
@@ -24,8 +25,10 @@ var (
 	jsonNumberType         = reflect.TypeOf(json.Number(""))
 	cachedOpcodeSets       []*OpcodeSet
 	cachedOpcodeMap        unsafe.Pointer // map[uintptr]*OpcodeSet
+	cachedOptionOpcodeMap  unsafe.Pointer // map[optionTypeKey]*OpcodeSet
 	typeAddr               *runtime.TypeAddr
 	initEncoderOnce        sync.Once
+	cacheEvictions         uint64
 )
 
 func initEncoder() {
@@ -44,12 +47,9 @@ func loadOpcodeMap() map[uintptr]*OpcodeSet {
 }
 
 func storeOpcodeSet(typ uintptr, set *OpcodeSet, m map[uintptr]*OpcodeSet) {
-	newOpcodeMap := make(map[uintptr]*OpcodeSet, len(m)+1)
+	newOpcodeMap, evicted := runtime.CopyCacheMap(m)
 	newOpcodeMap[typ] = set
-
-	for k, v := range m {
-		newOpcodeMap[k] = v
-	}
+	atomic.AddUint64(&cacheEvictions, uint64(evicted))
 
 	atomic.StorePointer(&cachedOpcodeMap, *(*unsafe.Pointer)(unsafe.Pointer(&newOpcodeMap)))
 }
@@ -59,7 +59,7 @@ func compileToGetCodeSetSlowPath(typeptr uintptr) (*OpcodeSet, error) {
 	if codeSet, exists := opcodeMap[typeptr]; exists {
 		return codeSet, nil
 	}
-	codeSet, err := newCompiler().compile(typeptr)
+	codeSet, err := newCompiler(nil).compile(typeptr)
 	if err != nil {
 		return nil, err
 	}
@@ -67,6 +67,69 @@ func compileToGetCodeSetSlowPath(typeptr uintptr) (*OpcodeSet, error) {
 	return codeSet, nil
 }
 
+// Precompile compiles the opcodes of typ in advance and caches them.
+func Precompile(typ *runtime.Type) error {
+	ctx := TakeRuntimeContext()
+	ctx.Option.Flag = 0
+	_, err := CompileToGetCodeSet(ctx, uintptr(unsafe.Pointer(typ)))
+	ReleaseRuntimeContext(ctx)
+	return err
+}
+
+// CacheStats returns the number of the cached opcode sets and the number of the opcode sets evicted from the caches.
+func CacheStats() (int, uint64) {
+	initEncoder()
+	size := countCachedOpcodeSets() + len(loadOpcodeMap()) + len(loadOptionOpcodeMap())
+	return size, atomic.LoadUint64(&cacheEvictions)
+}
+
+// optionTypeKey identifies opcodes compiled with options that change the compiled code.
+type optionTypeKey struct {
+	option string
+	typ    uintptr
+}
+
+func loadOptionOpcodeMap() map[optionTypeKey]*OpcodeSet {
+	p := atomic.LoadPointer(&cachedOptionOpcodeMap)
+	return *(*map[optionTypeKey]*OpcodeSet)(unsafe.Pointer(&p))
+}
+
+func storeOptionOpcodeSet(key optionTypeKey, set *OpcodeSet, m map[optionTypeKey]*OpcodeSet) {
+	newOpcodeMap, evicted := runtime.CopyCacheMap(m)
+	newOpcodeMap[key] = set
+	atomic.AddUint64(&cacheEvictions, uint64(evicted))
+
+	atomic.StorePointer(&cachedOptionOpcodeMap, *(*unsafe.Pointer)(unsafe.Pointer(&newOpcodeMap)))
+}
+
+// compileOptionFlags is the set of option flags that change the compiled code.
+const compileOptionFlags = Int64StringOption | UnsafeInt64StringOption | NilAsEmptyOption | RedactOption |
+	ASCIIOnlyOption | EscapeCharsOption
+
+// isCompileOptionSpecified reports whether opt has settings that change the compiled code.
+func isCompileOptionSpecified(opt *Option) bool {
+	return opt.StructTag != nil || opt.Encoders != nil || opt.Flag&compileOptionFlags != 0
+}
+
+func compileToGetCodeSetWithOption(ctx *RuntimeContext, typeptr uintptr) (*OpcodeSet, error) {
+	key := optionTypeKey{
+		option: ctx.Option.StructTag.CacheKey() + ";" + ctx.Option.Encoders.CacheKey() + ";" +
+			strconv.FormatUint(uint64(ctx.Option.Flag&compileOptionFlags), 10) + ";" +
+			ctx.Option.redactionCacheKey() + ";" + ctx.Option.escapeSetCacheKey(),
+		typ: typeptr,
+	}
+	opcodeMap := loadOptionOpcodeMap()
+	if codeSet, exists := opcodeMap[key]; exists {
+		return getFilteredCodeSetIfNeeded(ctx, codeSet)
+	}
+	codeSet, err := newCompiler(ctx.Option).compile(typeptr)
+	if err != nil {
+		return nil, err
+	}
+	storeOptionOpcodeSet(key, codeSet, opcodeMap)
+	return getFilteredCodeSetIfNeeded(ctx, codeSet)
+}
+
 func getFilteredCodeSetIfNeeded(ctx *RuntimeContext, codeSet *OpcodeSet) (*OpcodeSet, error) {
 	if (ctx.Option.Flag & ContextOption) == 0 {
 		return codeSet, nil
@@ -80,7 +143,7 @@ func getFilteredCodeSetIfNeeded(ctx *RuntimeContext, codeSet *OpcodeSet) (*Opcod
 	if cacheCodeSet != nil {
 		return cacheCodeSet, nil
 	}
-	queryCodeSet, err := newCompiler().codeToOpcodeSet(codeSet.Type, codeSet.Code.Filter(query))
+	queryCodeSet, err := newCompiler(ctx.Option).codeToOpcodeSet(codeSet.Type, codeSet.Code.Filter(query))
 	if err != nil {
 		return nil, err
 	}
@@ -90,12 +153,32 @@ func getFilteredCodeSetIfNeeded(ctx *RuntimeContext, codeSet *OpcodeSet) (*Opcod
 
 type Compiler struct {
 	structTypeToCode map[uintptr]*StructCode
+	structTagOption  *runtime.StructTagOption
+	encoders         *CustomEncoderSet
+	int64String      OptionFlag
+	nilAsEmpty       bool
+	redaction        *Redaction
+	keyEscape        *Option // escape settings of the struct keys, nil unless ASCIIOnlyOption or EscapeCharsOption is set
 }
 
-func newCompiler() *Compiler {
-	return &Compiler{
+// newCompiler creates a Compiler. opt is nil when compiling with the default settings.
+func newCompiler(opt *Option) *Compiler {
+	c := &Compiler{
 		structTypeToCode: map[uintptr]*StructCode{},
 	}
+	if opt != nil {
+		c.structTagOption = opt.StructTag
+		c.encoders = opt.Encoders
+		c.int64String = opt.Flag & (Int64StringOption | UnsafeInt64StringOption)
+		c.nilAsEmpty = opt.Flag&NilAsEmptyOption != 0
+		if opt.Flag&RedactOption != 0 {
+			c.redaction = opt.Redaction
+		}
+		if flag := opt.Flag & (ASCIIOnlyOption | EscapeCharsOption); flag != 0 {
+			c.keyEscape = &Option{Flag: flag, EscapeSet: opt.EscapeSet}
+		}
+	}
+	return c
 }
 
 func (c *Compiler) compile(typeptr uintptr) (*OpcodeSet, error) {
@@ -112,6 +195,7 @@ func (c *Compiler) codeToOpcodeSet(typ *runtime.Type, code Code) (*OpcodeSet, er
 	noescapeKeyCode := c.codeToOpcode(&compileContext{
 		structTypeToCodes: map[uintptr]Opcodes{},
 		recursiveCodes:    &Opcodes{},
+		keyEscape:         c.keyEscape,
 	}, typ, code)
 	if err := noescapeKeyCode.Validate(); err != nil {
 		return nil, err
@@ -120,6 +204,7 @@ func (c *Compiler) codeToOpcodeSet(typ *runtime.Type, code Code) (*OpcodeSet, er
 		structTypeToCodes: map[uintptr]Opcodes{},
 		recursiveCodes:    &Opcodes{},
 		escapeKey:         true,
+		keyEscape:         c.keyEscape,
 	}, typ, code)
 	noescapeKeyCode = copyOpcode(noescapeKeyCode)
 	escapeKeyCode = copyOpcode(escapeKeyCode)
@@ -142,6 +227,14 @@ func (c *Compiler) codeToOpcodeSet(typ *runtime.Type, code Code) (*OpcodeSet, er
 }
 
 func (c *Compiler) typeToCode(typ *runtime.Type) (Code, error) {
+	if enc := c.customEncoder(typ); enc != nil {
+		return c.customCode(typ, enc)
+	}
+	if typ.Kind() == reflect.Ptr {
+		if enc := c.customEncoder(typ.Elem()); enc != nil {
+			return c.customCode(typ, enc)
+		}
+	}
 	switch {
 	case c.implementsMarshalJSON(typ):
 		return c.marshalJSONCode(typ)
@@ -217,7 +310,12 @@ func (c *Compiler) typeToCode(typ *runtime.Type) (Code, error) {
 }
 
 func (c *Compiler) typeToCodeWithPtr(typ *runtime.Type, isPtr bool) (Code, error) {
+	if enc := c.customEncoder(typ); enc != nil {
+		return c.customCode(typ, enc)
+	}
 	switch {
+	case c.isCustomEncoderPtrType(typ):
+		return c.ptrCode(typ)
 	case c.implementsMarshalJSON(typ):
 		return c.marshalJSONCode(typ)
 	case c.implementsMarshalText(typ):
@@ -279,9 +377,19 @@ func (c *Compiler) typeToCodeWithPtr(typ *runtime.Type, isPtr bool) (Code, error
 
 const intSize = 32 << (^uint(0) >> 63)
 
+// int64StringMode reports whether integers of bitSize are encoded as strings by Int64StringOption,
+// and whether only integers outside the safe range are quoted by UnsafeInt64StringOption.
+func (c *Compiler) int64StringMode(bitSize uint8) (isString, isUnsafeString bool) {
+	if bitSize != 64 || c.int64String == 0 {
+		return false, false
+	}
+	return true, c.int64String&Int64StringOption == 0
+}
+
 //nolint:unparam
 func (c *Compiler) intCode(typ *runtime.Type, isPtr bool) (*IntCode, error) {
-	return &IntCode{typ: typ, bitSize: intSize, isPtr: isPtr}, nil
+	isString, isUnsafeString := c.int64StringMode(intSize)
+	return &IntCode{typ: typ, bitSize: intSize, isPtr: isPtr, isString: isString, isUnsafeString: isUnsafeString}, nil
 }
 
 //nolint:unparam
@@ -301,12 +409,14 @@ func (c *Compiler) int32Code(typ *runtime.Type, isPtr bool) (*IntCode, error) {
 
 //nolint:unparam
 func (c *Compiler) int64Code(typ *runtime.Type, isPtr bool) (*IntCode, error) {
-	return &IntCode{typ: typ, bitSize: 64, isPtr: isPtr}, nil
+	isString, isUnsafeString := c.int64StringMode(64)
+	return &IntCode{typ: typ, bitSize: 64, isPtr: isPtr, isString: isString, isUnsafeString: isUnsafeString}, nil
 }
 
 //nolint:unparam
 func (c *Compiler) uintCode(typ *runtime.Type, isPtr bool) (*UintCode, error) {
-	return &UintCode{typ: typ, bitSize: intSize, isPtr: isPtr}, nil
+	isString, isUnsafeString := c.int64StringMode(intSize)
+	return &UintCode{typ: typ, bitSize: intSize, isPtr: isPtr, isString: isString, isUnsafeString: isUnsafeString}, nil
 }
 
 //nolint:unparam
@@ -326,7 +436,8 @@ func (c *Compiler) uint32Code(typ *runtime.Type, isPtr bool) (*UintCode, error)
 
 //nolint:unparam
 func (c *Compiler) uint64Code(typ *runtime.Type, isPtr bool) (*UintCode, error) {
-	return &UintCode{typ: typ, bitSize: 64, isPtr: isPtr}, nil
+	isString, isUnsafeString := c.int64StringMode(64)
+	return &UintCode{typ: typ, bitSize: 64, isPtr: isPtr, isString: isString, isUnsafeString: isUnsafeString}, nil
 }
 
 //nolint:unparam
@@ -401,7 +512,7 @@ func (c *Compiler) uint64StringCode(typ *runtime.Type) (*UintCode, error) {
 
 //nolint:unparam
 func (c *Compiler) bytesCode(typ *runtime.Type, isPtr bool) (*BytesCode, error) {
-	return &BytesCode{typ: typ, isPtr: isPtr}, nil
+	return &BytesCode{typ: typ, isPtr: isPtr, isNonNil: c.nilAsEmpty}, nil
 }
 
 //nolint:unparam
@@ -419,6 +530,43 @@ func (c *Compiler) marshalJSONCode(typ *runtime.Type) (*MarshalJSONCode, error)
 	}, nil
 }
 
+//nolint:unparam
+func (c *Compiler) customCode(typ *runtime.Type, enc *CustomEncoder) (*CustomCode, error) {
+	return &CustomCode{
+		typ:           typ,
+		encoder:       enc,
+		isNilableType: c.isNilableType(typ),
+	}, nil
+}
+
+func (c *Compiler) redactCode(typ *runtime.Type, key string) *CustomCode {
+	return &CustomCode{
+		typ:           typ,
+		isNilableType: c.isNilableType(typ),
+		isRedacted:    true,
+		redactKey:     key,
+	}
+}
+
+func (c *Compiler) formatCode(typ *runtime.Type, format string, nonNil bool) (Code, error) {
+	if typ.Kind() == reflect.Ptr {
+		code, err := c.formatCode(typ.Elem(), format, nonNil)
+		if err != nil {
+			return nil, err
+		}
+		ptr, ok := code.(*PtrCode)
+		if ok {
+			return &PtrCode{typ: typ, value: ptr.value, ptrNum: ptr.ptrNum + 1}, nil
+		}
+		return &PtrCode{typ: typ, value: code, ptrNum: 1}, nil
+	}
+	enc, err := newFormatEncoder(typ, format, nonNil || c.nilAsEmpty)
+	if err != nil {
+		return nil, err
+	}
+	return c.customCode(typ, enc)
+}
+
 //nolint:unparam
 func (c *Compiler) marshalTextCode(typ *runtime.Type) (*MarshalTextCode, error) {
 	return &MarshalTextCode{
@@ -450,7 +598,7 @@ func (c *Compiler) sliceCode(typ *runtime.Type) (*SliceCode, error) {
 		structCode := code.(*StructCode)
 		structCode.enableIndirect()
 	}
-	return &SliceCode{typ: typ, value: code}, nil
+	return &SliceCode{typ: typ, value: code, isNonNil: c.nilAsEmpty}, nil
 }
 
 func (c *Compiler) arrayCode(typ *runtime.Type) (*ArrayCode, error) {
@@ -479,11 +627,19 @@ func (c *Compiler) mapCode(typ *runtime.Type) (*MapCode, error) {
 		structCode := valueCode.(*StructCode)
 		structCode.enableIndirect()
 	}
-	return &MapCode{typ: typ, key: keyCode, value: valueCode}, nil
+	return &MapCode{
+		typ:         typ,
+		key:         keyCode,
+		value:       valueCode,
+		isNonNil:    c.nilAsEmpty,
+		isRedactKey: c.redaction.hasKeys() && typ.Key().Kind() == reflect.String,
+	}, nil
 }
 
 func (c *Compiler) listElemCode(typ *runtime.Type) (Code, error) {
 	switch {
+	case c.customEncoder(typ) != nil || c.isCustomEncoderPtrType(typ):
+		return c.typeToCodeWithPtr(typ, true)
 	case c.implementsMarshalJSONType(typ) || c.implementsMarshalJSONType(runtime.PtrTo(typ)):
 		return c.marshalJSONCode(typ)
 	case !typ.Implements(marshalTextType) && runtime.PtrTo(typ).Implements(marshalTextType):
@@ -614,6 +770,7 @@ func (c *Compiler) structCode(typ *runtime.Type, isPtr bool) (*StructCode, error
 	fieldMap := c.getFieldMap(fields)
 	duplicatedFieldMap := c.getDuplicatedFieldMap(fieldMap)
 	code.fields = c.filteredDuplicatedFields(fields, duplicatedFieldMap)
+	code.setInlineKeys()
 	if !code.disableIndirectConversion && !indirect && isPtr {
 		code.enableIndirect()
 	}
@@ -643,6 +800,48 @@ func (c *Compiler) structFieldCode(structCode *StructCode, tag *runtime.StructTa
 		isNilCheck:    true,
 	}
 	switch {
+	case !fieldCode.isAnonymous && c.redaction.isRedacted(tag):
+		fieldCode.value = c.redactCode(fieldType, tag.Key)
+		fieldCode.isRedacted = true
+		// the first word of the nilable values is zero when they are nil,
+		// so they can be omitted by the same check as pointers.
+		switch fieldType.Kind() {
+		case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+			fieldCode.isNextOpPtrType = true
+		}
+	case tag.Format != "":
+		code, err := c.formatCode(fieldType, tag.Format, tag.IsNonNil)
+		if err != nil {
+			return nil, err
+		}
+		// the first word of time.Duration and byte slices is zero when they are empty,
+		// so they can be omitted by the same check as pointers.
+		elemType := toElemType(fieldType)
+		if fieldType.Kind() == reflect.Ptr || runtime.IsDurationType(elemType) || runtime.IsByteSliceType(elemType) {
+			fieldCode.isNextOpPtrType = true
+		}
+		fieldCode.value = code
+	case tag.IsInline:
+		if !runtime.IsInlineMapType(field.Type) {
+			return nil, errors.ErrUnsupportedInline(field.Name, field.Type)
+		}
+		code, err := c.mapCode(fieldType)
+		if err != nil {
+			return nil, err
+		}
+		code.isInline = true
+		fieldCode.value = code
+		fieldCode.isInline = true
+	case c.customEncoder(fieldType) != nil || c.isCustomEncoderPtrType(fieldType):
+		// registered encoders take precedence over MarshalJSON / MarshalText
+		code, err := c.typeToCodeWithPtr(fieldType, isPtr)
+		if err != nil {
+			return nil, err
+		}
+		if code.Kind() == CodeKindPtr {
+			fieldCode.isNextOpPtrType = true
+		}
+		fieldCode.value = code
 	case c.isMovePointerPositionFromHeadToFirstMarshalJSONFieldCase(fieldType, isIndirectSpecialCase):
 		code, err := c.marshalJSONCode(fieldType)
 		if err != nil {
@@ -694,9 +893,27 @@ func (c *Compiler) structFieldCode(structCode *StructCode, tag *runtime.StructTa
 		}
 		fieldCode.value = code
 	}
+	if tag.IsNonNil {
+		setNonNilCode(fieldCode.value)
+	}
 	return fieldCode, nil
 }
 
+// setNonNilCode makes the slice, map or byte slice encoded by code, possibly through pointers, encode nil as an empty value.
+func setNonNilCode(code Code) {
+	if ptr, ok := code.(*PtrCode); ok {
+		code = ptr.value
+	}
+	switch c := code.(type) {
+	case *BytesCode:
+		c.isNonNil = true
+	case *SliceCode:
+		c.isNonNil = true
+	case *MapCode:
+		c.isNonNil = true
+	}
+}
+
 func (c *Compiler) isAssignableIndirect(fieldCode *StructFieldCode, isPtr bool) bool {
 	if isPtr {
 		return false
@@ -708,6 +925,9 @@ func (c *Compiler) isAssignableIndirect(fieldCode *StructFieldCode, isPtr bool)
 	if codeType == CodeKindMarshalText {
 		return false
 	}
+	if codeType == CodeKindCustom {
+		return false
+	}
 	return true
 }
 
@@ -814,10 +1034,10 @@ func (c *Compiler) typeToStructTags(typ *runtime.Type) runtime.StructTags {
 	fieldNum := typ.NumField()
 	for i := 0; i < fieldNum; i++ {
 		field := typ.Field(i)
-		if runtime.IsIgnoredStructField(field) {
+		if runtime.IsIgnoredStructField(field, c.structTagOption) {
 			continue
 		}
-		tags = append(tags, runtime.StructTagFromField(field))
+		tags = append(tags, runtime.StructTagFromField(field, c.structTagOption))
 	}
 	return tags
 }
@@ -880,6 +1100,14 @@ func (c *Compiler) isNilableType(typ *runtime.Type) bool {
 	}
 }
 
+func (c *Compiler) customEncoder(typ *runtime.Type) *CustomEncoder {
+	return c.encoders.get(typ)
+}
+
+func (c *Compiler) isCustomEncoderPtrType(typ *runtime.Type) bool {
+	return typ.Kind() == reflect.Ptr && c.customEncoder(typ.Elem()) != nil
+}
+
 func (c *Compiler) implementsMarshalJSONType(typ *runtime.Type) bool {
 	return typ.Implements(marshalJSONType) || typ.Implements(marshalJSONContextType)
 }
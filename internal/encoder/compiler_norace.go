@@ -5,6 +5,9 @@ package encoder
 
 func CompileToGetCodeSet(ctx *RuntimeContext, typeptr uintptr) (*OpcodeSet, error) {
 	initEncoder()
+	if isCompileOptionSpecified(ctx.Option) {
+		return compileToGetCodeSetWithOption(ctx, typeptr)
+	}
 	if typeptr > typeAddr.MaxTypeAddr || typeptr < typeAddr.BaseTypeAddr {
 		codeSet, err := compileToGetCodeSetSlowPath(typeptr)
 		if err != nil {
@@ -20,7 +23,7 @@ func CompileToGetCodeSet(ctx *RuntimeContext, typeptr uintptr) (*OpcodeSet, erro
 		}
 		return filtered, nil
 	}
-	codeSet, err := newCompiler().compile(typeptr)
+	codeSet, err := newCompiler(nil).compile(typeptr)
 	if err != nil {
 		return nil, err
 	}
@@ -31,3 +34,13 @@ func CompileToGetCodeSet(ctx *RuntimeContext, typeptr uintptr) (*OpcodeSet, erro
 	cachedOpcodeSets[index] = codeSet
 	return filtered, nil
 }
+
+func countCachedOpcodeSets() int {
+	var n int
+	for _, codeSet := range cachedOpcodeSets {
+		if codeSet != nil {
+			n++
+		}
+	}
+	return n
+}
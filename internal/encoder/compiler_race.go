@@ -11,6 +11,9 @@ var setsMu sync.RWMutex
 
 func CompileToGetCodeSet(ctx *RuntimeContext, typeptr uintptr) (*OpcodeSet, error) {
 	initEncoder()
+	if isCompileOptionSpecified(ctx.Option) {
+		return compileToGetCodeSetWithOption(ctx, typeptr)
+	}
 	if typeptr > typeAddr.MaxTypeAddr || typeptr < typeAddr.BaseTypeAddr {
 		codeSet, err := compileToGetCodeSetSlowPath(typeptr)
 		if err != nil {
@@ -20,18 +23,14 @@ func CompileToGetCodeSet(ctx *RuntimeContext, typeptr uintptr) (*OpcodeSet, erro
 	}
 	index := (typeptr - typeAddr.BaseTypeAddr) >> typeAddr.AddrShift
 	setsMu.RLock()
-	if codeSet := cachedOpcodeSets[index]; codeSet != nil {
-		filtered, err := getFilteredCodeSetIfNeeded(ctx, codeSet)
-		if err != nil {
-			setsMu.RUnlock()
-			return nil, err
-		}
-		setsMu.RUnlock()
-		return filtered, nil
-	}
+	codeSet := cachedOpcodeSets[index]
 	setsMu.RUnlock()
+	if codeSet != nil {
+		// filter without the lock since hashing the field query encodes it and may compile its type.
+		return getFilteredCodeSetIfNeeded(ctx, codeSet)
+	}
 
-	codeSet, err := newCompiler().compile(typeptr)
+	codeSet, err := newCompiler(nil).compile(typeptr)
 	if err != nil {
 		return nil, err
 	}
@@ -44,3 +43,15 @@ func CompileToGetCodeSet(ctx *RuntimeContext, typeptr uintptr) (*OpcodeSet, erro
 	setsMu.Unlock()
 	return filtered, nil
 }
+
+func countCachedOpcodeSets() int {
+	setsMu.RLock()
+	defer setsMu.RUnlock()
+	var n int
+	for _, codeSet := range cachedOpcodeSets {
+		if codeSet != nil {
+			n++
+		}
+	}
+	return n
+}
@@ -2,6 +2,7 @@ package encoder
 
 import (
 	"context"
+	"io"
 	"sync"
 	"unsafe"
 
@@ -13,6 +14,7 @@ type compileContext struct {
 	ptrIndex          int
 	indent            uint32
 	escapeKey         bool
+	keyEscape         *Option
 	structTypeToCodes map[uintptr]Opcodes
 	recursiveCodes    *Opcodes
 }
@@ -79,11 +81,22 @@ type RuntimeContext struct {
 	Prefix     []byte
 	IndentStr  []byte
 	Option     *Option
+
+	// Writer receives the encoded bytes while encoding once the buffer exceeds FlushThreshold.
+	// The buffer is not flushed if Writer is nil.
+	Writer          io.Writer
+	FlushThreshold  int
+	SortingMapDepth int
+
+	// mapKeyColorScheme is the color scheme of the option while a map key is encoded without colors.
+	mapKeyColorScheme *ColorScheme
 }
 
 func (c *RuntimeContext) Init(p uintptr, codelen int) {
-	if len(c.Ptrs) < codelen {
+	if cap(c.Ptrs) < codelen {
 		c.Ptrs = make([]uintptr, codelen)
+	} else if len(c.Ptrs) < codelen {
+		c.Ptrs = c.Ptrs[:codelen]
 	}
 	c.Ptrs[0] = p
 	c.KeepRefs = c.KeepRefs[:0]
@@ -101,6 +114,52 @@ func (c *RuntimeContext) Reset() {
 	c.BaseIndent = 0
 	c.Prefix = c.Prefix[:0]
 	c.IndentStr = c.IndentStr[:0]
+	c.Option.StructTag = nil
+	c.Option.Encoders = nil
+	c.Writer = nil
+	c.FlushThreshold = 0
+	c.SortingMapDepth = 0
+	if c.mapKeyColorScheme != nil {
+		// encoding failed in the map key.
+		c.Option.ColorScheme = c.mapKeyColorScheme
+		c.mapKeyColorScheme = nil
+	}
+}
+
+var plainColorScheme = &ColorScheme{}
+
+// BeginColorMapKey makes the map key encoded without colors until EndColorMapKey is called,
+// and returns the format of the object keys.
+// The map key is colorized as a whole by the format whatever its type, in the same way as the keys of IndentColor.
+func (c *RuntimeContext) BeginColorMapKey() EncodeFormat {
+	c.mapKeyColorScheme = c.Option.ColorScheme
+	c.Option.ColorScheme = plainColorScheme
+	return c.mapKeyColorScheme.ObjectKey
+}
+
+// EndColorMapKey restores the color scheme after the map key is encoded, and returns the format of the object keys.
+func (c *RuntimeContext) EndColorMapKey() EncodeFormat {
+	c.Option.ColorScheme = c.mapKeyColorScheme
+	c.mapKeyColorScheme = nil
+	return c.Option.ColorScheme.ObjectKey
+}
+
+// flushKeepSize is the number of bytes kept in the buffer by FlushBuffer
+// since the VM rewrites the trailing comma ( and the newline of the indent ) of the buffer.
+const flushKeepSize = 2
+
+// FlushBuffer writes b to ctx.Writer except its last bytes, and returns the rest of b.
+// b is returned as is while a sorted map is encoded, including the values nested in the map,
+// since the entries of the map are sorted in the buffer after all of them are encoded.
+func FlushBuffer(ctx *RuntimeContext, b []byte) ([]byte, error) {
+	if ctx.SortingMapDepth > 0 || len(b) <= flushKeepSize {
+		return b, nil
+	}
+	n := len(b) - flushKeepSize
+	if _, err := ctx.Writer.Write(b[:n]); err != nil {
+		return nil, err
+	}
+	return b[:copy(b, b[n:])], nil
 }
 
 func (c *RuntimeContext) Ptr() uintptr {
@@ -0,0 +1,153 @@
+package encoder
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// CustomEncodeFunc appends the JSON encoding of v to b.
+// v holds a value of the registered type or a non-nil pointer to it.
+type CustomEncodeFunc func(b []byte, v interface{}) ([]byte, error)
+
+// CustomEncoder is an encode function registered for a type.
+type CustomEncoder struct {
+	Type *runtime.Type
+	Func CustomEncodeFunc
+
+	// contextFunc is used instead of Func by the encoders that depend on the options of each encoding call.
+	contextFunc func(ctx *RuntimeContext, b []byte, v interface{}) ([]byte, error)
+}
+
+// NewCustomEncoder creates a CustomEncoder that encodes typ with fn.
+func NewCustomEncoder(typ *runtime.Type, fn CustomEncodeFunc) *CustomEncoder {
+	return &CustomEncoder{
+		Type: typ,
+		Func: fn,
+	}
+}
+
+var (
+	globalCustomEncoders   unsafe.Pointer // map[*runtime.Type]*CustomEncoder
+	globalCustomEncodersMu sync.Mutex
+)
+
+func loadGlobalCustomEncoders() map[*runtime.Type]*CustomEncoder {
+	p := atomic.LoadPointer(&globalCustomEncoders)
+	return *(*map[*runtime.Type]*CustomEncoder)(unsafe.Pointer(&p))
+}
+
+// RegisterCustomEncoder registers enc for all subsequent compilations.
+// Types that have already been compiled keep their cached opcodes.
+func RegisterCustomEncoder(enc *CustomEncoder) {
+	globalCustomEncodersMu.Lock()
+	defer globalCustomEncodersMu.Unlock()
+
+	m := loadGlobalCustomEncoders()
+	newMap := make(map[*runtime.Type]*CustomEncoder, len(m)+1)
+	for k, v := range m {
+		newMap[k] = v
+	}
+	newMap[enc.Type] = enc
+	atomic.StorePointer(&globalCustomEncoders, *(*unsafe.Pointer)(unsafe.Pointer(&newMap)))
+}
+
+// CustomEncoderSet is a set of encoders specified for each encoding call.
+type CustomEncoderSet struct {
+	encoders map[*runtime.Type]*CustomEncoder
+	cacheKey string
+}
+
+// With returns a copy of s that also contains enc.
+func (s *CustomEncoderSet) With(enc *CustomEncoder) *CustomEncoderSet {
+	newSet := &CustomEncoderSet{encoders: map[*runtime.Type]*CustomEncoder{}}
+	if s != nil {
+		for k, v := range s.encoders {
+			newSet.encoders[k] = v
+		}
+	}
+	newSet.encoders[enc.Type] = enc
+
+	typeKeys := make([]string, 0, len(newSet.encoders))
+	for typ := range newSet.encoders {
+		typeKeys = append(typeKeys, strconv.FormatUint(uint64(uintptr(unsafe.Pointer(typ))), 16))
+	}
+	sort.Strings(typeKeys)
+	newSet.cacheKey = strings.Join(typeKeys, ",")
+	return newSet
+}
+
+// CacheKey returns an identifier that distinguishes compiled code for this set from other sets.
+// It depends only on the types of the encoders, since the compiled code looks up the encoders from the option of each encoding call.
+func (s *CustomEncoderSet) CacheKey() string {
+	if s == nil {
+		return ""
+	}
+	return s.cacheKey
+}
+
+func (s *CustomEncoderSet) get(typ *runtime.Type) *CustomEncoder {
+	if s != nil {
+		if _, exists := s.encoders[typ]; exists {
+			return newOptionCustomEncoder(typ)
+		}
+	}
+	return loadGlobalCustomEncoders()[typ]
+}
+
+// newOptionCustomEncoder creates the encoder that encodes typ by the encoder in the option of the encoding call,
+// so that the compiled code is shared by the options created for each call.
+func newOptionCustomEncoder(typ *runtime.Type) *CustomEncoder {
+	enc := NewCustomEncoder(typ, nil)
+	enc.contextFunc = func(ctx *RuntimeContext, b []byte, v interface{}) ([]byte, error) {
+		return ctx.Option.Encoders.encoders[typ].encode(ctx, b, v)
+	}
+	return enc
+}
+
+func (e *CustomEncoder) encode(ctx *RuntimeContext, b []byte, v interface{}) ([]byte, error) {
+	if e.contextFunc != nil {
+		return e.contextFunc(ctx, b, v)
+	}
+	start := len(b)
+	bb, err := e.Func(b, v)
+	if err != nil {
+		return nil, err
+	}
+	return EscapeEncodedStrings(ctx, bb, start), nil
+}
+
+func AppendCustom(ctx *RuntimeContext, code *Opcode, b []byte, v interface{}) ([]byte, error) {
+	bb, err := code.IsZeroMethodFunc.(*CustomEncoder).encode(ctx, b, v)
+	if err != nil {
+		return nil, &errors.MarshalerError{Type: reflect.TypeOf(v), Err: err}
+	}
+	return bb, nil
+}
+
+func AppendCustomIndent(ctx *RuntimeContext, code *Opcode, b []byte, v interface{}) ([]byte, error) {
+	marshalBuf, err := code.IsZeroMethodFunc.(*CustomEncoder).encode(ctx, ctx.MarshalBuf[:0], v)
+	if err != nil {
+		return nil, &errors.MarshalerError{Type: reflect.TypeOf(v), Err: err}
+	}
+	marshalBuf = append(marshalBuf, nul)
+	indentedBuf, err := doIndent(
+		b,
+		marshalBuf,
+		string(ctx.Prefix)+strings.Repeat(string(ctx.IndentStr), int(ctx.BaseIndent+code.Indent)),
+		string(ctx.IndentStr),
+		(ctx.Option.Flag&HTMLEscapeOption) != 0,
+	)
+	if err != nil {
+		return nil, &errors.MarshalerError{Type: reflect.TypeOf(v), Err: err}
+	}
+	ctx.MarshalBuf = marshalBuf
+	return indentedBuf, nil
+}
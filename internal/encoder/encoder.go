@@ -11,6 +11,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"unsafe"
 
 	"github.com/goccy/go-json/internal/errors"
@@ -113,10 +114,28 @@ func (s *OpcodeSet) getQueryCache(hash string) *OpcodeSet {
 	return codeSet
 }
 
+// maxQueryCacheSize is the maximum number of the opcode sets filtered by field queries cached for each type,
+// which bounds the cache even without the cache limit since the queries may come from untrusted input.
+const maxQueryCacheSize = 256
+
 func (s *OpcodeSet) setQueryCache(hash string, codeSet *OpcodeSet) {
+	limit := runtime.CacheLimit()
+	if limit <= 0 || limit > maxQueryCacheSize {
+		limit = maxQueryCacheSize
+	}
 	s.cacheMu.Lock()
+	var evicted int
+	for key := range s.QueryCache {
+		if len(s.QueryCache) < limit {
+			break
+		}
+		// the iteration order of the map is random, so the evicted entries are chosen randomly.
+		delete(s.QueryCache, key)
+		evicted++
+	}
 	s.QueryCache[hash] = codeSet
 	s.cacheMu.Unlock()
+	atomic.AddUint64(&cacheEvictions, uint64(evicted))
 }
 
 type CompiledCode struct {
@@ -303,6 +322,24 @@ func NewMapContext(mapLen int, unorderedMap bool) *MapContext {
 	return ctx
 }
 
+// SkipInlineKeys skips the entries of the inline map that are not selected by keys, from the entry at the iterator.
+// idx is the number of the entries already iterated. The skipped entries are excluded from c.Len.
+// It returns the key of the entry at the iterator.
+func (c *MapContext) SkipInlineKeys(keys *InlineKeys, idx int) unsafe.Pointer {
+	for idx < c.Len {
+		key := MapIterKey(&c.Iter)
+		if !keys.skips(*(*string)(key)) {
+			return key
+		}
+		MapIterNext(&c.Iter)
+		c.Len--
+		if len(c.Slice.Items) > c.Len {
+			c.Slice.Items = c.Slice.Items[:c.Len]
+		}
+	}
+	return nil
+}
+
 func ReleaseMapContext(c *MapContext) {
 	c.Reset()
 	mapContextPool.Put(c)
@@ -458,7 +495,7 @@ func AppendMarshalJSON(ctx *RuntimeContext, code *Opcode, b []byte, v interface{
 		return nil, &errors.MarshalerError{Type: reflect.TypeOf(v), Err: err}
 	}
 	ctx.MarshalBuf = marshalBuf
-	return compactedBuf, nil
+	return EscapeEncodedStrings(ctx, compactedBuf, len(b)), nil
 }
 
 func AppendMarshalJSONIndent(ctx *RuntimeContext, code *Opcode, b []byte, v interface{}) ([]byte, error) {
@@ -508,7 +545,7 @@ func AppendMarshalJSONIndent(ctx *RuntimeContext, code *Opcode, b []byte, v inte
 		return nil, &errors.MarshalerError{Type: reflect.TypeOf(v), Err: err}
 	}
 	ctx.MarshalBuf = marshalBuf
-	return indentedBuf, nil
+	return EscapeEncodedStrings(ctx, indentedBuf, len(b)), nil
 }
 
 func AppendMarshalText(ctx *RuntimeContext, code *Opcode, b []byte, v interface{}) ([]byte, error) {
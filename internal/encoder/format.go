@@ -0,0 +1,170 @@
+package encoder
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	hexenc "encoding/hex"
+	"strconv"
+	"time"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// newFormatEncoder creates an encoder for typ that follows the format option of the struct tag.
+// If nonNil is true, a nil byte slice is encoded as an empty value instead of null.
+func newFormatEncoder(typ *runtime.Type, format string, nonNil bool) (*CustomEncoder, error) {
+	var fn CustomEncodeFunc
+	switch {
+	case runtime.IsTimeType(typ):
+		fn = timeFormatEncodeFunc(format)
+	case runtime.IsDurationType(typ):
+		fn = durationFormatEncodeFunc(format)
+	case runtime.IsByteSliceType(typ):
+		fn = bytesFormatEncodeFunc(format, nonNil)
+	}
+	if fn == nil {
+		return nil, errors.ErrUnsupportedFormat(format, runtime.RType2Type(typ))
+	}
+	return NewCustomEncoder(typ, fn), nil
+}
+
+func timeFormatEncodeFunc(format string) CustomEncodeFunc {
+	switch format {
+	case "unix":
+		return func(b []byte, v interface{}) ([]byte, error) {
+			t := v.(time.Time)
+			sec, nsec := t.Unix(), t.Nanosecond()
+			if sec < 0 && nsec != 0 {
+				// write the sign and the absolute value since the fraction is always positive for t.Nanosecond.
+				b = append(b, '-')
+				sec, nsec = -(sec + 1), 1e9-nsec
+			}
+			b = strconv.AppendInt(b, sec, 10)
+			if nsec != 0 {
+				frac := strconv.AppendInt([]byte{}, int64(nsec)+1e9, 10)[1:]
+				for frac[len(frac)-1] == '0' {
+					frac = frac[:len(frac)-1]
+				}
+				b = append(append(b, '.'), frac...)
+			}
+			return b, nil
+		}
+	case "unixmilli":
+		return func(b []byte, v interface{}) ([]byte, error) {
+			return strconv.AppendInt(b, unixTime(v.(time.Time), time.Millisecond), 10), nil
+		}
+	case "unixmicro":
+		return func(b []byte, v interface{}) ([]byte, error) {
+			return strconv.AppendInt(b, unixTime(v.(time.Time), time.Microsecond), 10), nil
+		}
+	case "unixnano":
+		return func(b []byte, v interface{}) ([]byte, error) {
+			return strconv.AppendInt(b, v.(time.Time).UnixNano(), 10), nil
+		}
+	}
+	layout := runtime.TimeLayout(format)
+	return func(b []byte, v interface{}) ([]byte, error) {
+		b = append(b, '"')
+		b = v.(time.Time).AppendFormat(b, layout)
+		return append(b, '"'), nil
+	}
+}
+
+// unixTime returns t as the number of units elapsed since January 1, 1970 UTC.
+// Unlike t.UnixNano, it does not overflow for times far from the epoch when unit is coarser than a nanosecond.
+func unixTime(t time.Time, unit time.Duration) int64 {
+	return t.Unix()*int64(time.Second/unit) + int64(t.Nanosecond())/int64(unit)
+}
+
+func durationFormatEncodeFunc(format string) CustomEncodeFunc {
+	switch format {
+	case "units":
+		return func(b []byte, v interface{}) ([]byte, error) {
+			b = append(b, '"')
+			b = append(b, v.(time.Duration).String()...)
+			return append(b, '"'), nil
+		}
+	case "sec":
+		return func(b []byte, v interface{}) ([]byte, error) {
+			return strconv.AppendFloat(b, v.(time.Duration).Seconds(), 'f', -1, 64), nil
+		}
+	case "milli":
+		return func(b []byte, v interface{}) ([]byte, error) {
+			return strconv.AppendFloat(b, float64(v.(time.Duration))/float64(time.Millisecond), 'f', -1, 64), nil
+		}
+	case "micro":
+		return func(b []byte, v interface{}) ([]byte, error) {
+			return strconv.AppendFloat(b, float64(v.(time.Duration))/float64(time.Microsecond), 'f', -1, 64), nil
+		}
+	case "nano":
+		return func(b []byte, v interface{}) ([]byte, error) {
+			return strconv.AppendInt(b, int64(v.(time.Duration)), 10), nil
+		}
+	}
+	return nil
+}
+
+func bytesFormatEncodeFunc(format string, nonNil bool) CustomEncodeFunc {
+	switch format {
+	case "array":
+		return appendBytesFormat(func(b, src []byte) []byte {
+			b = append(b, '[')
+			for i, c := range src {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = strconv.AppendUint(b, uint64(c), 10)
+			}
+			return append(b, ']')
+		}, nonNil)
+	case "hex":
+		return appendBytesFormat(func(b, src []byte) []byte {
+			return appendEncodedBytes(b, src, hexenc.EncodedLen(len(src)), func(dst, src []byte) { hexenc.Encode(dst, src) })
+		}, nonNil)
+	case "base64":
+		return appendBytesFormat(func(b, src []byte) []byte {
+			return appendEncodedBytes(b, src, base64.StdEncoding.EncodedLen(len(src)), base64.StdEncoding.Encode)
+		}, nonNil)
+	case "base64url":
+		return appendBytesFormat(func(b, src []byte) []byte {
+			return appendEncodedBytes(b, src, base64.URLEncoding.EncodedLen(len(src)), base64.URLEncoding.Encode)
+		}, nonNil)
+	case "base32":
+		return appendBytesFormat(func(b, src []byte) []byte {
+			return appendEncodedBytes(b, src, base32.StdEncoding.EncodedLen(len(src)), base32.StdEncoding.Encode)
+		}, nonNil)
+	case "base32hex":
+		return appendBytesFormat(func(b, src []byte) []byte {
+			return appendEncodedBytes(b, src, base32.HexEncoding.EncodedLen(len(src)), base32.HexEncoding.Encode)
+		}, nonNil)
+	}
+	return nil
+}
+
+// appendBytesFormat wraps fn to receive the byte slice held by v, which may be a named byte slice type.
+// A nil byte slice is passed to fn as an empty one if nonNil is true.
+func appendBytesFormat(fn func(b, src []byte) []byte, nonNil bool) CustomEncodeFunc {
+	return func(b []byte, v interface{}) ([]byte, error) {
+		src := *(*[]byte)((*emptyInterface)(unsafe.Pointer(&v)).ptr)
+		if src == nil && !nonNil {
+			return append(b, "null"...), nil
+		}
+		return fn(b, src), nil
+	}
+}
+
+// appendEncodedBytes appends src encoded by encode as a JSON string.
+func appendEncodedBytes(b, src []byte, encodedLen int, encode func(dst, src []byte)) []byte {
+	b = append(b, '"')
+	n := len(b)
+	if cap(b)-n < encodedLen+1 {
+		newBuf := make([]byte, n, n+encodedLen+1)
+		copy(newBuf, b)
+		b = newBuf
+	}
+	b = b[:n+encodedLen]
+	encode(b[n:], src)
+	return append(b, '"')
+}
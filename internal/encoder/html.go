@@ -0,0 +1,329 @@
+package encoder
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strconv"
+
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// HTMLScheme is the set of the CSS classes used to render JSON as HTML.
+// The class attribute is omitted if the class is empty.
+type HTMLScheme struct {
+	Number      string
+	String      string
+	Bool        string
+	Null        string
+	Key         string // class of the anchors of the object keys
+	Object      string // class of the objects
+	Array       string // class of the arrays
+	Members     string // class of the members of the objects and the elements of the arrays, hidden to collapse them
+	Punctuation string // class of the braces, brackets, colons and commas
+
+	// AnchorPrefix is prepended to the JSONPath of the object member to make the id of the anchor of the key.
+	AnchorPrefix string
+}
+
+// htmlizer renders the JSON-encoded source as HTML.
+// Each value is wrapped in the span with the data-path attribute of its JSONPath,
+// and each object key is the anchor linked to itself.
+type htmlizer struct {
+	scheme      *HTMLScheme
+	indent      bool
+	prefix      []byte
+	indentBytes []byte
+	path        []byte
+}
+
+// IndentHTML appends to buf the indented form of the JSON-encoded src rendered as HTML by scheme.
+func IndentHTML(buf *bytes.Buffer, src []byte, prefix, indentStr string, scheme *HTMLScheme) error {
+	return renderHTML(buf, src, &htmlizer{
+		scheme:      scheme,
+		indent:      true,
+		prefix:      []byte(prefix),
+		indentBytes: []byte(indentStr),
+	})
+}
+
+// CompactHTML appends to buf the JSON-encoded src rendered as HTML by scheme without insignificant space characters.
+func CompactHTML(buf *bytes.Buffer, src []byte, scheme *HTMLScheme) error {
+	return renderHTML(buf, src, &htmlizer{scheme: scheme})
+}
+
+func renderHTML(buf *bytes.Buffer, src []byte, h *htmlizer) error {
+	if len(src) == 0 {
+		return errors.ErrUnexpectedEndOfJSON("", 0)
+	}
+
+	srcCtx, srcBuf := takeIndentSrcRuntimeContext(src)
+	dstCtx := TakeRuntimeContext()
+	defer func() {
+		ReleaseRuntimeContext(srcCtx)
+		ReleaseRuntimeContext(dstCtx)
+	}()
+
+	h.path = append(h.path[:0], '$')
+	dst, cursor, err := h.value(dstCtx.Buf[:0], srcBuf, 0, 0)
+	if err != nil {
+		return err
+	}
+	if err := validateEndBuf(srcBuf, cursor); err != nil {
+		return err
+	}
+	dstCtx.Buf = dst
+	_, err = buf.Write(dst)
+	return err
+}
+
+func (h *htmlizer) value(dst, src []byte, indentNum int, cursor int64) ([]byte, int64, error) {
+	cursor = skipWhiteSpace(src, cursor)
+	switch src[cursor] {
+	case '{':
+		return h.object(dst, src, indentNum, cursor)
+	case '[':
+		return h.array(dst, src, indentNum, cursor)
+	case '"':
+		return h.scalar(h.scheme.String, dst, src, cursor, func(dst, src []byte, cursor int64) ([]byte, int64, error) {
+			return compactString(dst, src, cursor, false)
+		})
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9':
+		return h.scalar(h.scheme.Number, dst, src, cursor, compactNumber)
+	case 't':
+		return h.scalar(h.scheme.Bool, dst, src, cursor, compactTrue)
+	case 'f':
+		return h.scalar(h.scheme.Bool, dst, src, cursor, compactFalse)
+	case 'n':
+		return h.scalar(h.scheme.Null, dst, src, cursor, compactNull)
+	case nul:
+		return nil, 0, errors.ErrUnexpectedEndOfJSON("value", cursor)
+	}
+	return nil, 0, errors.ErrSyntax(fmt.Sprintf("unexpected character '%c'", src[cursor]), cursor)
+}
+
+func (h *htmlizer) object(dst, src []byte, indentNum int, cursor int64) ([]byte, int64, error) {
+	dst = h.openNode(dst, h.scheme.Object)
+	cursor = skipWhiteSpace(src, cursor+1)
+	if src[cursor] == '}' {
+		dst = h.punctuation(dst, "{}")
+		return append(dst, "</span>"...), cursor + 1, nil
+	}
+	dst = h.openMembers(h.punctuation(dst, "{"))
+	indentNum++
+	parent := len(h.path)
+	var err error
+	for {
+		dst = h.newline(dst, indentNum)
+		cursor = skipWhiteSpace(src, cursor)
+		dst, cursor, err = h.key(dst, src, cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		cursor = skipWhiteSpace(src, cursor)
+		if src[cursor] != ':' {
+			return nil, 0, errors.ErrExpected("colon after object key", cursor)
+		}
+		dst = h.punctuation(dst, ":")
+		if h.indent {
+			dst = append(dst, ' ')
+		}
+		dst, cursor, err = h.value(dst, src, indentNum, cursor+1)
+		if err != nil {
+			return nil, 0, err
+		}
+		h.path = h.path[:parent]
+		cursor = skipWhiteSpace(src, cursor)
+		switch src[cursor] {
+		case '}':
+			dst = h.newline(dst, indentNum-1)
+			dst = h.punctuation(append(dst, "</span>"...), "}")
+			return append(dst, "</span>"...), cursor + 1, nil
+		case ',':
+			dst = h.punctuation(dst, ",")
+		default:
+			return nil, 0, errors.ErrExpected("comma after object value", cursor)
+		}
+		cursor++
+	}
+}
+
+func (h *htmlizer) array(dst, src []byte, indentNum int, cursor int64) ([]byte, int64, error) {
+	dst = h.openNode(dst, h.scheme.Array)
+	cursor = skipWhiteSpace(src, cursor+1)
+	if src[cursor] == ']' {
+		dst = h.punctuation(dst, "[]")
+		return append(dst, "</span>"...), cursor + 1, nil
+	}
+	dst = h.openMembers(h.punctuation(dst, "["))
+	indentNum++
+	parent := len(h.path)
+	var err error
+	for idx := 0; ; idx++ {
+		dst = h.newline(dst, indentNum)
+		h.path = strconv.AppendInt(append(h.path, '['), int64(idx), 10)
+		h.path = append(h.path, ']')
+		dst, cursor, err = h.value(dst, src, indentNum, cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+		h.path = h.path[:parent]
+		cursor = skipWhiteSpace(src, cursor)
+		switch src[cursor] {
+		case ']':
+			dst = h.newline(dst, indentNum-1)
+			dst = h.punctuation(append(dst, "</span>"...), "]")
+			return append(dst, "</span>"...), cursor + 1, nil
+		case ',':
+			dst = h.punctuation(dst, ",")
+		default:
+			return nil, 0, errors.ErrExpected("comma after array value", cursor)
+		}
+		cursor++
+	}
+}
+
+// key appends the anchor of the object key at cursor and appends the selector of the key to h.path.
+func (h *htmlizer) key(dst, src []byte, cursor int64) ([]byte, int64, error) {
+	literal, end, err := compactString(nil, src, cursor, false)
+	if err != nil {
+		return nil, 0, err
+	}
+	name, _, err := canonicalUnquote(nil, src, cursor)
+	if err != nil {
+		// keep the escape sequences which can not be represented in UTF-8.
+		name = literal[1 : len(literal)-1]
+	}
+	h.path = appendPathSelector(h.path, string(name))
+
+	dst = append(dst, "<a"...)
+	dst = appendClass(dst, h.scheme.Key)
+	dst = append(dst, ` id="`...)
+	idStart := len(dst)
+	dst = appendAnchorID(dst, h.scheme.AnchorPrefix)
+	dst = appendAnchorID(dst, string(h.path))
+	id := dst[idStart:]
+	dst = append(dst, `" href="#`...)
+	dst = append(dst, id...)
+	dst = append(dst, `">`...)
+	dst = appendHTMLText(dst, literal)
+	return append(dst, "</a>"...), end, nil
+}
+
+func (h *htmlizer) scalar(
+	class string,
+	dst []byte,
+	src []byte,
+	cursor int64,
+	appendValue func(dst, src []byte, cursor int64) ([]byte, int64, error)) ([]byte, int64, error) {
+	v, cursor, err := appendValue(nil, src, cursor)
+	if err != nil {
+		return nil, 0, err
+	}
+	dst = h.openNode(dst, class)
+	dst = appendHTMLText(dst, v)
+	return append(dst, "</span>"...), cursor, nil
+}
+
+func (h *htmlizer) openNode(dst []byte, class string) []byte {
+	dst = append(dst, "<span"...)
+	dst = appendClass(dst, class)
+	dst = append(dst, ` data-path="`...)
+	dst = append(dst, html.EscapeString(string(h.path))...)
+	return append(dst, `">`...)
+}
+
+func (h *htmlizer) openMembers(dst []byte) []byte {
+	dst = append(dst, "<span"...)
+	dst = appendClass(dst, h.scheme.Members)
+	return append(dst, '>')
+}
+
+func (h *htmlizer) punctuation(dst []byte, delim string) []byte {
+	dst = append(dst, "<span"...)
+	dst = appendClass(dst, h.scheme.Punctuation)
+	dst = append(dst, '>')
+	dst = append(dst, delim...)
+	return append(dst, "</span>"...)
+}
+
+func (h *htmlizer) newline(dst []byte, indentNum int) []byte {
+	if !h.indent {
+		return dst
+	}
+	dst = appendHTMLText(append(dst, '\n'), h.prefix)
+	for i := 0; i < indentNum; i++ {
+		dst = appendHTMLText(dst, h.indentBytes)
+	}
+	return dst
+}
+
+func appendClass(dst []byte, class string) []byte {
+	if class == "" {
+		return dst
+	}
+	dst = append(dst, ` class="`...)
+	dst = append(dst, html.EscapeString(class)...)
+	return append(dst, '"')
+}
+
+// appendHTMLText appends s escaping the characters which have a special meaning in the text of HTML.
+func appendHTMLText(dst, s []byte) []byte {
+	for _, c := range s {
+		switch c {
+		case '<':
+			dst = append(dst, "&lt;"...)
+		case '>':
+			dst = append(dst, "&gt;"...)
+		case '&':
+			dst = append(dst, "&amp;"...)
+		default:
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
+
+// appendAnchorID appends id percent-encoding the space characters, the control characters, the non-ASCII bytes
+// and the characters which are not allowed in the URL fragment or have a special meaning in HTML,
+// so that the id has no space characters and is used as is as the fragment of the link to the anchor.
+func appendAnchorID(dst []byte, id string) []byte {
+	const hex = "0123456789ABCDEF"
+	for i := 0; i < len(id); i++ {
+		c := id[i]
+		switch {
+		case c <= ' ', c >= 0x7f, c == '"', c == '<', c == '>', c == '`', c == '%', c == '#', c == '&':
+			dst = append(dst, '%', hex[c>>4], hex[c&0xf])
+		default:
+			dst = append(dst, c)
+		}
+	}
+	return dst
+}
+
+// appendPathSelector appends the selector of the object member name to the JSONPath.
+// The name is quoted if it contains the characters reserved by JSONPath,
+// and the single quotes and the backslashes in the quoted name are escaped by a backslash.
+func appendPathSelector(path []byte, name string) []byte {
+	if name == "" || containsPathReservedChar(name) {
+		path = append(path, "['"...)
+		for i := 0; i < len(name); i++ {
+			if name[i] == '\'' || name[i] == '\\' {
+				path = append(path, '\\')
+			}
+			path = append(path, name[i])
+		}
+		return append(path, "']"...)
+	}
+	return append(append(path, '.'), name...)
+}
+
+func containsPathReservedChar(name string) bool {
+	for i := 0; i < len(name); i++ {
+		switch name[i] {
+		case '.', '[', ']', '$', '*', '\'', '"', '@', ' ', '\t', '\n', '\r', '\\':
+			return true
+		}
+	}
+	return false
+}
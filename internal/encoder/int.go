@@ -174,3 +174,30 @@ func AppendUint(_ *RuntimeContext, out []byte, p uintptr, code *Opcode) []byte {
 	}
 	return append(out, b[i:]...)
 }
+
+// maxSafeInteger is the largest integer that a float64 ( a JavaScript number ) represents exactly.
+const maxSafeInteger = 1<<53 - 1
+
+func ptrToUint64(p uintptr, bitSize uint8) uint64 {
+	switch bitSize {
+	case 8:
+		return (uint64)(**(**uint8)(unsafe.Pointer(&p)))
+	case 16:
+		return (uint64)(**(**uint16)(unsafe.Pointer(&p)))
+	case 32:
+		return (uint64)(**(**uint32)(unsafe.Pointer(&p)))
+	}
+	return **(**uint64)(unsafe.Pointer(&p))
+}
+
+// IsSafeInt reports whether the signed integer at p is represented exactly by a JavaScript number.
+func IsSafeInt(p uintptr, code *Opcode) bool {
+	shift := 64 - code.NumBitSize
+	v := int64(ptrToUint64(p, code.NumBitSize)<<shift) >> shift
+	return -maxSafeInteger <= v && v <= maxSafeInteger
+}
+
+// IsSafeUint reports whether the unsigned integer at p is represented exactly by a JavaScript number.
+func IsSafeUint(p uintptr, code *Opcode) bool {
+	return ptrToUint64(p, code.NumBitSize)&numMask(code.NumBitSize) <= maxSafeInteger
+}
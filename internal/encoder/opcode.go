@@ -27,6 +27,10 @@ const (
 	NonEmptyInterfaceFlags OpFlags = 1 << 9
 	OmitZeroFlags          OpFlags = 1 << 10
 	OmitEmptyFlags         OpFlags = 1 << 11
+	UnsafeIntStringFlags   OpFlags = 1 << 12
+	NonNilFlags            OpFlags = 1 << 13
+	InlineFlags            OpFlags = 1 << 14
+	RedactFlags            OpFlags = 1 << 15
 )
 
 type Opcode struct {
@@ -52,8 +56,10 @@ type Opcode struct {
 	DisplayKey string        // key text to display
 
 	HasIsZeroMethod      bool        // whether the type has IsZero() method
-	IsZeroMethodFunc     interface{} // cached method.Func for IsZero() method
 	IsZeroMethodNeedsPtr bool        // true if IsZero() method requires pointer receiver
+	IsZeroMethodFunc     interface{} // cached method.Func for IsZero() method, or *CustomEncoder for Custom
+
+	InlineKeys *InlineKeys // selects the entries of the inline map
 }
 
 func (c *Opcode) Validate() error {
@@ -119,6 +125,10 @@ func (c *Opcode) ToHeaderType(isString bool) OpType {
 			return OpStructHeadIntPtrString
 		}
 		return OpStructHeadIntPtr
+	case OpIntString:
+		return OpStructHeadIntString
+	case OpIntPtrString:
+		return OpStructHeadIntPtrString
 	case OpUint:
 		if isString {
 			return OpStructHeadUintString
@@ -129,6 +139,10 @@ func (c *Opcode) ToHeaderType(isString bool) OpType {
 			return OpStructHeadUintPtrString
 		}
 		return OpStructHeadUintPtr
+	case OpUintString:
+		return OpStructHeadUintString
+	case OpUintPtrString:
+		return OpStructHeadUintPtrString
 	case OpFloat32:
 		if isString {
 			return OpStructHeadFloat32String
@@ -222,6 +236,10 @@ func (c *Opcode) ToFieldType(isString bool) OpType {
 			return OpStructFieldIntPtrString
 		}
 		return OpStructFieldIntPtr
+	case OpIntString:
+		return OpStructFieldIntString
+	case OpIntPtrString:
+		return OpStructFieldIntPtrString
 	case OpUint:
 		if isString {
 			return OpStructFieldUintString
@@ -232,6 +250,10 @@ func (c *Opcode) ToFieldType(isString bool) OpType {
 			return OpStructFieldUintPtrString
 		}
 		return OpStructFieldUintPtr
+	case OpUintString:
+		return OpStructFieldUintString
+	case OpUintPtrString:
+		return OpStructFieldUintPtrString
 	case OpFloat32:
 		if isString {
 			return OpStructFieldFloat32String
@@ -353,6 +375,7 @@ func copyOpcode(code *Opcode) *Opcode {
 			HasIsZeroMethod:      c.HasIsZeroMethod,
 			IsZeroMethodFunc:     c.IsZeroMethodFunc,
 			IsZeroMethodNeedsPtr: c.IsZeroMethodNeedsPtr,
+			InlineKeys:           c.InlineKeys,
 		}
 		if c.End != nil {
 			ptr.End = getCodeAddrByIdx(head, c.End.DisplayIdx)
@@ -3,9 +3,11 @@ package encoder
 import (
 	"context"
 	"io"
+
+	"github.com/goccy/go-json/internal/runtime"
 )
 
-type OptionFlag uint8
+type OptionFlag uint16
 
 const (
 	HTMLEscapeOption OptionFlag = 1 << iota
@@ -16,14 +18,26 @@ const (
 	ContextOption
 	NormalizeUTF8Option
 	FieldQueryOption
+	Int64StringOption
+	UnsafeInt64StringOption
+	NilAsEmptyOption
+	HTMLOption
+	RedactOption
+	ASCIIOnlyOption
+	EscapeCharsOption
 )
 
 type Option struct {
 	Flag        OptionFlag
 	ColorScheme *ColorScheme
+	HTMLScheme  *HTMLScheme
+	Redaction   *Redaction
+	EscapeSet   *EscapeSet
 	Context     context.Context
 	DebugOut    io.Writer
 	DebugDOTOut io.WriteCloser
+	StructTag   *runtime.StructTagOption
+	Encoders    *CustomEncoderSet
 }
 
 type EncodeFormat struct {
@@ -40,6 +54,13 @@ type EncodeFormatScheme struct {
 	Binary    EncodeFormat
 	ObjectKey EncodeFormat
 	Null      EncodeFormat
+
+	// The formats of the punctuation. They are not decorated if both Header and Footer are empty.
+
+	Brace   EncodeFormat // '{' and '}' of objects
+	Bracket EncodeFormat // '[' and ']' of arrays
+	Colon   EncodeFormat // ':' between object keys and values
+	Comma   EncodeFormat // ',' between elements
 }
 
 type (
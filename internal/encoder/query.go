@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"strings"
 )
 
 var (
@@ -11,10 +12,19 @@ var (
 	Unmarshal func([]byte, interface{}) error
 )
 
+const (
+	// FieldQueryWildcard is the field name that matches all fields of the structure.
+	FieldQueryWildcard = "*"
+	// FieldQueryExcludeKey is the key of the object that marks an exclusion in the query string, e.g. {"-":"password"}.
+	// An object with this key is a sub field query if its value is an array.
+	FieldQueryExcludeKey = "-"
+)
+
 type FieldQuery struct {
-	Name   string
-	Fields []*FieldQuery
-	hash   string
+	Name    string
+	Fields  []*FieldQuery
+	Exclude bool
+	hash    string
 }
 
 func (q *FieldQuery) Hash() string {
@@ -27,6 +37,9 @@ func (q *FieldQuery) Hash() string {
 }
 
 func (q *FieldQuery) MarshalJSON() ([]byte, error) {
+	if q.Exclude {
+		return Marshal(map[string]string{FieldQueryExcludeKey: q.Name})
+	}
 	if q.Name != "" {
 		if len(q.Fields) > 0 {
 			return Marshal(map[string][]*FieldQuery{q.Name: q.Fields})
@@ -106,7 +119,14 @@ func (s FieldQueryString) buildMap(v reflect.Value) (*FieldQuery, error) {
 		return nil, errors.New("failed to build field query. invalid object key type")
 	}
 	name := key.String()
-	def, err := s.build(v.MapIndex(key))
+	value := v.MapIndex(key)
+	if value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	if name == FieldQueryExcludeKey && value.Kind() == reflect.String {
+		return &FieldQuery{Name: value.String(), Exclude: true}, nil
+	}
+	def, err := s.build(value)
 	if err != nil {
 		return nil, err
 	}
@@ -116,6 +136,77 @@ func (s FieldQueryString) buildMap(v reflect.Value) (*FieldQuery, error) {
 	}, nil
 }
 
+// hasField reports whether q has an entry for the field named name, not including wildcards and paths.
+func (q *FieldQuery) hasField(name string) bool {
+	for _, field := range q.Fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Lookup reports whether the field named name is kept by q, and returns the query for the value of the field.
+// The nil query keeps all fields, and the returned query is nil if the whole value of the field is kept.
+// It is used by the code generated by go-json-gen to filter the fields in the same way as MarshalContext.
+func (q *FieldQuery) Lookup(name string) (*FieldQuery, bool) {
+	if q == nil {
+		return nil, true
+	}
+	return q.lookup(name)
+}
+
+// lookup reports whether the field named name is kept by q.
+// If only a part of the value of the field is kept, it also returns the query for the value.
+//
+// A query that has only exclusions keeps all fields except the excluded ones.
+// The entry name can be the wildcard or a dot-separated path to the field of a nested structure.
+func (q *FieldQuery) lookup(name string) (*FieldQuery, bool) {
+	var (
+		hasInclude bool
+		isIncluded bool
+		isWhole    bool
+		fields     []*FieldQuery
+	)
+	prefix := name + "."
+	for _, field := range q.Fields {
+		if !field.Exclude {
+			hasInclude = true
+		}
+		switch {
+		case field.Name == name, field.Name == FieldQueryWildcard:
+			if field.Exclude {
+				return nil, false
+			}
+			isIncluded = true
+			if len(field.Fields) > 0 {
+				fields = append(fields, field.Fields...)
+			} else {
+				isWhole = true
+			}
+		case strings.HasPrefix(field.Name, prefix):
+			if !field.Exclude {
+				isIncluded = true
+			}
+			fields = append(fields, &FieldQuery{
+				Name:    field.Name[len(prefix):],
+				Fields:  field.Fields,
+				Exclude: field.Exclude,
+			})
+		}
+	}
+	if (hasInclude || len(q.Fields) == 0) && !isIncluded {
+		return nil, false
+	}
+	if len(fields) == 0 {
+		return nil, true
+	}
+	if isWhole {
+		fields = append(fields, &FieldQuery{Name: FieldQueryWildcard})
+	}
+	return &FieldQuery{Name: name, Fields: fields}, true
+}
+
 type queryKey struct{}
 
 func FieldQueryFromContext(ctx context.Context) *FieldQuery {
@@ -0,0 +1,248 @@
+package encoder
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/goccy/go-json/internal/errors"
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// ParseFieldQuery parses the compact field selector syntax like "id,name,owner(id,email),items/price".
+//
+//   - fields are separated by commas
+//   - "name(...)" selects the fields of the value of name
+//   - "a/b" is the path to the field b of the value of a
+//   - "*" selects all fields, and "-name" removes the field
+//
+// An empty selector, including one of only white spaces, returns the nil query, which keeps all fields.
+func ParseFieldQuery(s string) (*FieldQuery, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	p := &fieldQueryParser{src: s}
+	fields, err := p.parseList()
+	if err != nil {
+		return nil, err
+	}
+	if p.cursor < len(p.src) {
+		return nil, p.errUnexpected()
+	}
+	return &FieldQuery{Fields: fields}, nil
+}
+
+type fieldQueryParser struct {
+	src    string
+	cursor int
+}
+
+func (p *fieldQueryParser) char() byte {
+	if p.cursor < len(p.src) {
+		return p.src[p.cursor]
+	}
+	return nul
+}
+
+func (p *fieldQueryParser) skipWhiteSpace() {
+	for {
+		switch p.char() {
+		case ' ', '\n', '\t', '\r':
+			p.cursor++
+		default:
+			return
+		}
+	}
+}
+
+func (p *fieldQueryParser) errUnexpected() error {
+	if p.cursor >= len(p.src) {
+		return errors.ErrSyntax("unexpected end of field query", int64(p.cursor))
+	}
+	return errors.ErrSyntax(fmt.Sprintf("invalid character '%c' in field query", p.src[p.cursor]), int64(p.cursor))
+}
+
+func (p *fieldQueryParser) parseList() ([]*FieldQuery, error) {
+	var fields []*FieldQuery
+	for {
+		field, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+		p.skipWhiteSpace()
+		if p.char() != ',' {
+			return fields, nil
+		}
+		p.cursor++
+	}
+}
+
+func (p *fieldQueryParser) parseField() (*FieldQuery, error) {
+	p.skipWhiteSpace()
+	field := &FieldQuery{}
+	if p.char() == '-' {
+		field.Exclude = true
+		p.cursor++
+	}
+	var names []string
+	for {
+		name := p.parseName()
+		if name == "" {
+			return nil, p.errUnexpected()
+		}
+		names = append(names, name)
+		p.skipWhiteSpace()
+		if p.char() != '/' {
+			break
+		}
+		p.cursor++
+	}
+	field.Name = strings.Join(names, ".")
+	if p.char() != '(' {
+		return field, nil
+	}
+	if field.Exclude {
+		return nil, errors.ErrSyntax("excluded field cannot have sub fields in field query", int64(p.cursor))
+	}
+	p.cursor++
+	fields, err := p.parseList()
+	if err != nil {
+		return nil, err
+	}
+	p.skipWhiteSpace()
+	if p.char() != ')' {
+		return nil, p.errUnexpected()
+	}
+	p.cursor++
+	field.Fields = fields
+	return field, nil
+}
+
+func (p *fieldQueryParser) parseName() string {
+	p.skipWhiteSpace()
+	start := p.cursor
+	for p.cursor < len(p.src) {
+		switch p.src[p.cursor] {
+		case ',', '(', ')', '/', ' ', '\n', '\t', '\r':
+			return p.src[start:p.cursor]
+		}
+		p.cursor++
+	}
+	return p.src[start:p.cursor]
+}
+
+// Validate reports the fields of q that do not exist in the type of v as *errors.FieldQueryError.
+// Field names are resolved in the same way as Marshal with optFuncs, so the struct tag options such as TagKey
+// and FieldNaming are taken into account.
+// The values that are interfaces or implement json.Marshaler are not validated since their fields are unknown.
+func (q *FieldQuery) Validate(v interface{}, optFuncs ...func(*Option)) error {
+	typ := reflect.TypeOf(v)
+	if q == nil || typ == nil {
+		return nil
+	}
+	var opt Option
+	for _, optFunc := range optFuncs {
+		optFunc(&opt)
+	}
+	var paths []string
+	validateFieldQuery(typ, q.Fields, "", opt.StructTag, &paths)
+	if len(paths) > 0 {
+		return &errors.FieldQueryError{Type: typ, Paths: paths}
+	}
+	return nil
+}
+
+func validateFieldQuery(typ reflect.Type, fields []*FieldQuery, path string, tagOpt *runtime.StructTagOption, unknownPaths *[]string) {
+	if len(fields) == 0 {
+		return
+	}
+	typ = fieldQueryTargetType(typ)
+	if typ == nil {
+		return
+	}
+	fieldTypes := map[string]reflect.Type{}
+	if typ.Kind() == reflect.Struct {
+		collectFieldTypes(typ, fieldTypes, tagOpt, map[reflect.Type]struct{}{})
+	}
+	for _, field := range fields {
+		name := field.Name
+		if name == FieldQueryWildcard {
+			continue
+		}
+		subFields := field.Fields
+		fieldType, exists := fieldTypes[name]
+		if !exists {
+			if idx := strings.IndexByte(name, '.'); idx >= 0 {
+				fieldType, exists = fieldTypes[name[:idx]]
+				subFields = []*FieldQuery{{Name: name[idx+1:], Fields: field.Fields}}
+				name = name[:idx]
+			}
+		}
+		fieldPath := name
+		if path != "" {
+			fieldPath = path + "." + name
+		}
+		if !exists {
+			*unknownPaths = append(*unknownPaths, fieldPath)
+			continue
+		}
+		validateFieldQuery(fieldType, subFields, fieldPath, tagOpt, unknownPaths)
+	}
+}
+
+// fieldQueryTargetType returns the type whose fields are selected by the sub field query for typ.
+// It returns nil if the fields cannot be known from typ.
+func fieldQueryTargetType(typ reflect.Type) reflect.Type {
+	for {
+		if typ.Implements(marshalJSONType) || typ.Implements(marshalJSONContextType) ||
+			reflect.PtrTo(typ).Implements(marshalJSONType) || reflect.PtrTo(typ).Implements(marshalJSONContextType) {
+			return nil
+		}
+		switch typ.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			typ = typ.Elem()
+		case reflect.Interface:
+			return nil
+		default:
+			return typ
+		}
+	}
+}
+
+// collectFieldTypes collects the types of the fields of typ by their keys, including the fields of embedded structures.
+func collectFieldTypes(typ reflect.Type, fieldTypes map[string]reflect.Type, tagOpt *runtime.StructTagOption, seen map[reflect.Type]struct{}) {
+	if _, exists := seen[typ]; exists {
+		return
+	}
+	seen[typ] = struct{}{}
+	var embedded []reflect.Type
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if runtime.IsIgnoredStructField(field, tagOpt) {
+			continue
+		}
+		tag := runtime.StructTagFromField(field, tagOpt)
+		if field.Anonymous && !tag.IsTaggedKey {
+			elem := field.Type
+			if elem.Kind() == reflect.Ptr {
+				elem = elem.Elem()
+			}
+			if elem.Kind() == reflect.Struct {
+				embedded = append(embedded, elem)
+				continue
+			}
+		}
+		fieldTypes[tag.Key] = field.Type
+	}
+	// the fields of the structure take precedence over the fields of the embedded structures.
+	for _, elem := range embedded {
+		embeddedFieldTypes := map[string]reflect.Type{}
+		collectFieldTypes(elem, embeddedFieldTypes, tagOpt, seen)
+		for key, fieldType := range embeddedFieldTypes {
+			if _, exists := fieldTypes[key]; !exists {
+				fieldTypes[key] = fieldType
+			}
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package encoder
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unsafe"
+
+	"github.com/goccy/go-json/internal/runtime"
+)
+
+// RedactFunc returns the string that replaces the value v of the struct field or the map entry whose key is key.
+type RedactFunc func(key string, v interface{}) string
+
+// Redaction is the setting of the redaction of the struct field and map values.
+// The values of the fields tagged with the redact option and the fields and map entries whose keys are in keys are replaced with the result of fn.
+type Redaction struct {
+	fn       RedactFunc
+	keys     map[string]struct{}
+	cacheKey string
+}
+
+// NewRedaction creates a Redaction.
+func NewRedaction(fn RedactFunc, keys []string) *Redaction {
+	r := &Redaction{fn: fn, keys: make(map[string]struct{}, len(keys))}
+	for _, key := range keys {
+		r.keys[key] = struct{}{}
+	}
+	sortedKeys := make([]string, 0, len(r.keys))
+	for key := range r.keys {
+		sortedKeys = append(sortedKeys, strconv.Quote(key))
+	}
+	sort.Strings(sortedKeys)
+	r.cacheKey = strings.Join(sortedKeys, ",")
+	return r
+}
+
+// CacheKey returns an identifier that distinguishes compiled code for the redacted fields from others.
+// fn is not a part of it since it is looked up from the option of each encoding call.
+func (r *Redaction) CacheKey() string {
+	if r == nil {
+		return ""
+	}
+	return r.cacheKey
+}
+
+func (r *Redaction) isRedacted(tag *runtime.StructTag) bool {
+	if r == nil {
+		return false
+	}
+	if tag.IsRedact {
+		return true
+	}
+	_, exists := r.keys[tag.Key]
+	return exists
+}
+
+func (r *Redaction) hasKeys() bool {
+	return r != nil && len(r.keys) > 0
+}
+
+func (o *Option) redactionCacheKey() string {
+	if o.Flag&RedactOption == 0 {
+		return ""
+	}
+	return o.Redaction.CacheKey()
+}
+
+// Redact returns the string that replaces the value v of the struct field or the map entry whose key is key
+// by the Redaction of the option of the encoding call. It reports false if v is nil, which is encoded as null.
+func Redact(ctx *RuntimeContext, key string, v interface{}) (string, bool) {
+	if isNilValue(v) {
+		return "", false
+	}
+	return ctx.Option.Redaction.fn(key, v), true
+}
+
+// IsRedactedEmpty reports whether the value of the redacted struct field at ptr is empty in the sense of omitempty,
+// which is checked against the value before it is replaced.
+func IsRedactedEmpty(typ *runtime.Type, ptr uintptr) bool {
+	if ptr == 0 {
+		return true
+	}
+	v := reflect.NewAt(runtime.RType2Type(typ), *(*unsafe.Pointer)(unsafe.Pointer(&ptr))).Elem()
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// RedactedMapValue returns the string key at key and the map value at value if the key is one of the keys of the Redaction.
+// It reports whether the value is redacted. code is the OpMapValue of the map.
+func RedactedMapValue(ctx *RuntimeContext, code *Opcode, key, value unsafe.Pointer) (string, interface{}, bool) {
+	redaction := ctx.Option.Redaction
+	if redaction == nil {
+		return "", nil, false
+	}
+	k := *(*string)(key)
+	if _, exists := redaction.keys[k]; !exists {
+		return "", nil, false
+	}
+	return k, reflect.NewAt(runtime.RType2Type(code.Type), value).Elem().Interface(), true
+}
+
+func isNilValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
@@ -27,6 +27,8 @@ package encoder
 import (
 	"math/bits"
 	"reflect"
+	"unicode/utf16"
+	"unicode/utf8"
 	"unsafe"
 )
 
@@ -47,6 +49,9 @@ func stringToUint64Slice(s string) []uint64 {
 }
 
 func AppendString(ctx *RuntimeContext, buf []byte, s string) []byte {
+	if ctx.Option.Flag&(ASCIIOnlyOption|EscapeCharsOption) != 0 {
+		return appendEscapedString(ctx, buf, s)
+	}
 	if ctx.Option.Flag&HTMLEscapeOption != 0 {
 		if ctx.Option.Flag&NormalizeUTF8Option != 0 {
 			return appendNormalizedHTMLString(buf, s)
@@ -481,3 +486,139 @@ ESCAPE_END:
 
 	return append(append(buf, s[i:]...), '"')
 }
+
+// escapeTable returns the table of the bytes which need to be escaped with ASCIIOnlyOption or EscapeCharsOption.
+// The non-ASCII bytes are inspected if the UTF-8 is normalized or only ASCII characters are written.
+func escapeTable(opt *Option) *[256]bool {
+	var html, nonASCII int
+	if opt.Flag&HTMLEscapeOption != 0 {
+		html = 1
+	}
+	if opt.Flag&(NormalizeUTF8Option|ASCIIOnlyOption) != 0 {
+		nonASCII = 1
+	}
+	if opt.Flag&EscapeCharsOption != 0 && opt.EscapeSet != nil {
+		return &opt.EscapeSet.tables[html][nonASCII]
+	}
+	switch {
+	case html == 1 && nonASCII == 1:
+		return &needEscapeHTMLNormalizeUTF8
+	case html == 1:
+		return &needEscapeHTML
+	case nonASCII == 1:
+		return &needEscapeNormalizeUTF8
+	}
+	return &needEscape
+}
+
+// appendEscapedString is appendString for ASCIIOnlyOption and EscapeCharsOption.
+// It looks up the table of the option for every byte instead of testing 8 bytes at once.
+func appendEscapedString(ctx *RuntimeContext, buf []byte, s string) []byte {
+	table := escapeTable(ctx.Option)
+	asciiOnly := ctx.Option.Flag&ASCIIOnlyOption != 0
+	buf = append(buf, '"')
+	var i, j int
+	for j < len(s) {
+		c := s[j]
+		if !table[c] {
+			j++
+			continue
+		}
+		buf = append(buf, s[i:j]...)
+		if c < utf8.RuneSelf {
+			buf = appendEscapedASCII(buf, c)
+			j++
+			i = j
+			continue
+		}
+		state, size := decodeRuneInString(s[j:])
+		switch {
+		case state == runeErrorState:
+			buf = append(buf, `\ufffd`...)
+		case asciiOnly:
+			r, _ := utf8.DecodeRuneInString(s[j:])
+			buf = appendEscapedRune(buf, r)
+		case state == lineSepState:
+			buf = append(buf, `\u2028`...)
+		case state == paragraphSepState:
+			buf = append(buf, `\u2029`...)
+		default:
+			buf = append(buf, s[j:j+size]...)
+		}
+		j += size
+		i = j
+	}
+	return append(append(buf, s[i:]...), '"')
+}
+
+// appendEscapedASCII appends the escape sequence of the ASCII character c.
+func appendEscapedASCII(buf []byte, c byte) []byte {
+	switch c {
+	case '\\', '"', '/':
+		return append(buf, '\\', c)
+	case '\n':
+		return append(buf, '\\', 'n')
+	case '\r':
+		return append(buf, '\\', 'r')
+	case '\t':
+		return append(buf, '\\', 't')
+	}
+	buf = append(buf, `\u00`...)
+	return append(buf, hex[c>>4], hex[c&0xF])
+}
+
+// appendEscapedRune appends r as \uXXXX, or as the surrogate pair if r is outside the Basic Multilingual Plane.
+func appendEscapedRune(buf []byte, r rune) []byte {
+	if r1, r2 := utf16.EncodeRune(r); r1 != utf8.RuneError || r2 != utf8.RuneError {
+		return appendEscapedRune(appendEscapedRune(buf, r1), r2)
+	}
+	return append(buf, '\\', 'u', hex[r>>12&0xF], hex[r>>8&0xF], hex[r>>4&0xF], hex[r&0xF])
+}
+
+// EscapeEncodedStrings escapes the characters of the strings in the JSON value b[start:] encoded by a marshaler
+// as the encoder does with ASCIIOnlyOption and EscapeCharsOption. The escape sequences in the value are kept.
+func EscapeEncodedStrings(ctx *RuntimeContext, b []byte, start int) []byte {
+	if ctx.Option.Flag&(ASCIIOnlyOption|EscapeCharsOption) == 0 {
+		return b
+	}
+	var set *EscapeSet
+	if ctx.Option.Flag&EscapeCharsOption != 0 {
+		set = ctx.Option.EscapeSet
+	}
+	asciiOnly := ctx.Option.Flag&ASCIIOnlyOption != 0
+	src := append([]byte{}, b[start:]...)
+	b = b[:start]
+	inString := false
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case !inString:
+			inString = c == '"'
+		case c == '"':
+			inString = false
+		case c == '\\':
+			size := 2
+			if i+1 < len(src) && src[i+1] == 'u' {
+				size = 6
+			}
+			if i+size > len(src) {
+				size = len(src) - i
+			}
+			b = append(b, src[i:i+size]...)
+			i += size
+			continue
+		case set.contains(c):
+			b = appendEscapedASCII(b, c)
+			i++
+			continue
+		case c >= utf8.RuneSelf && asciiOnly:
+			r, size := utf8.DecodeRune(src[i:])
+			b = appendEscapedRune(b, r)
+			i += size
+			continue
+		}
+		b = append(b, c)
+		i++
+	}
+	return b
+}
@@ -1,5 +1,10 @@
 package encoder
 
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
 var needEscapeHTMLNormalizeUTF8 = [256]bool{
 	'"':  true,
 	'&':  true,
@@ -413,3 +418,61 @@ var needEscape = [256]bool{
 	0x1f: true,
 	/* 0x20 - 0xff */
 }
+
+// EscapeSet is the set of the ASCII characters escaped in the strings in addition to the characters escaped by default.
+// It keeps the tables merged with each of the default tables, so that the encoder looks up one table per byte.
+type EscapeSet struct {
+	chars    string
+	tables   [2][2][256]bool // indexed by whether HTML characters are escaped and whether non-ASCII bytes are inspected
+	cacheKey string
+}
+
+// NewEscapeSet creates the EscapeSet of the ASCII characters in chars. The non-ASCII characters in chars are ignored.
+func NewEscapeSet(chars string) *EscapeSet {
+	var set [utf8.RuneSelf]bool
+	for i := 0; i < len(chars); i++ {
+		if chars[i] < utf8.RuneSelf {
+			set[chars[i]] = true
+		}
+	}
+	s := &EscapeSet{}
+	for c, exists := range set {
+		if exists {
+			s.chars += string(rune(c))
+		}
+	}
+	for html, base := range [2][2]*[256]bool{
+		{&needEscape, &needEscapeNormalizeUTF8},
+		{&needEscapeHTML, &needEscapeHTMLNormalizeUTF8},
+	} {
+		for nonASCII, table := range base {
+			s.tables[html][nonASCII] = *table
+			for c, exists := range set {
+				if exists {
+					s.tables[html][nonASCII][c] = true
+				}
+			}
+		}
+	}
+	s.cacheKey = strconv.Quote(s.chars)
+	return s
+}
+
+// CacheKey returns an identifier that distinguishes compiled code for this set from other sets.
+func (s *EscapeSet) CacheKey() string {
+	if s == nil {
+		return ""
+	}
+	return s.cacheKey
+}
+
+func (o *Option) escapeSetCacheKey() string {
+	if o.Flag&EscapeCharsOption == 0 {
+		return ""
+	}
+	return o.EscapeSet.CacheKey()
+}
+
+func (s *EscapeSet) contains(c byte) bool {
+	return s != nil && c < utf8.RuneSelf && s.tables[0][0][c] && !needEscape[c]
+}
@@ -106,6 +106,32 @@ func ptrToInterface(code *encoder.Opcode, p uintptr) interface{} {
 		ptr: *(*unsafe.Pointer)(unsafe.Pointer(&p)),
 	}))
 }
+
+func appendIntString(ctx *encoder.RuntimeContext, b []byte, p uintptr, code *encoder.Opcode) []byte {
+	if code.Flags&encoder.UnsafeIntStringFlags != 0 && encoder.IsSafeInt(p, code) {
+		return appendInt(ctx, b, p, code)
+	}
+	b = append(b, '"')
+	b = appendInt(ctx, b, p, code)
+	return append(b, '"')
+}
+
+func appendUintString(ctx *encoder.RuntimeContext, b []byte, p uintptr, code *encoder.Opcode) []byte {
+	if code.Flags&encoder.UnsafeIntStringFlags != 0 && encoder.IsSafeUint(p, code) {
+		return appendUint(ctx, b, p, code)
+	}
+	b = append(b, '"')
+	b = appendUint(ctx, b, p, code)
+	return append(b, '"')
+}
+
+// appendBytes appends src, which is encoded as an empty string instead of null if code has NonNilFlags.
+func appendBytes(ctx *encoder.RuntimeContext, b []byte, src []byte, code *encoder.Opcode) []byte {
+	if src == nil && code.Flags&encoder.NonNilFlags != 0 {
+		src = []byte{}
+	}
+	return appendByteSlice(ctx, b, src)
+}
 func appendInt(ctx *encoder.RuntimeContext, b []byte, p uintptr, code *encoder.Opcode) []byte {
 	format := ctx.Option.ColorScheme.Int
 	b = append(b, format.Header...)
@@ -157,6 +183,33 @@ func appendNumber(ctx *encoder.RuntimeContext, b []byte, n json.Number) ([]byte,
 	}
 	return append(bb, format.Footer...), nil
 }
+
+func appendDelimiter(format encoder.EncodeFormat, b []byte, delim string) []byte {
+	b = append(b, format.Header...)
+	b = append(b, delim...)
+	return append(b, format.Footer...)
+}
+
+// appendStructEndDelimiter appends '}' in format.
+// If the struct has no fields, '{' at the end of b is replaced with "{}" to be colored in the same way as the empty map.
+func appendStructEndDelimiter(format encoder.EncodeFormat, b []byte) []byte {
+	if hasStructHeadSuffix(format, b) {
+		b = b[:len(b)-len(format.Header)-1-len(format.Footer)]
+		return appendDelimiter(format, b, "{}")
+	}
+	return appendDelimiter(format, b, "}")
+}
+
+// hasStructHeadSuffix reports whether b ends with '{' in format.
+func hasStructHeadSuffix(format encoder.EncodeFormat, b []byte) bool {
+	if len(b) < len(format.Header)+len(format.Footer)+1 {
+		return false
+	}
+	footer := len(b) - len(format.Footer)
+	return string(b[footer:]) == format.Footer &&
+		b[footer-1] == '{' &&
+		string(b[footer-1-len(format.Header):footer-1]) == format.Header
+}
 func appendBool(ctx *encoder.RuntimeContext, b []byte, v bool) []byte {
 	format := ctx.Option.ColorScheme.Bool
 	b = append(b, format.Header...)
@@ -184,28 +237,40 @@ func appendNullComma(ctx *encoder.RuntimeContext, b []byte) []byte {
 	b = append(b, "null"...)
 	return append(append(b, format.Footer...), ',')
 }
-func appendColon(_ *encoder.RuntimeContext, b []byte) []byte {
+
+// appendSeparator colors the trailing comma of b before the next element is appended.
+// The comma is appended without color by appendComma since it is removed or replaced at the end of the array or object.
+func appendSeparator(ctx *encoder.RuntimeContext, b []byte) []byte {
+	format := ctx.Option.ColorScheme.Comma
 	last := len(b) - 1
-	b[last] = ':'
-	return b
+	if last < 0 || b[last] != ',' || (format.Header == "" && format.Footer == "") {
+		return b
+	}
+	return appendDelimiter(format, b[:last], ",")
+}
+func appendColon(ctx *encoder.RuntimeContext, b []byte) []byte {
+	return appendDelimiter(ctx.Option.ColorScheme.Colon, b[:len(b)-1], ":")
 }
 
-func appendMapKeyValue(_ *encoder.RuntimeContext, _ *encoder.Opcode, b, key, value []byte) []byte {
+func appendMapKeyValue(ctx *encoder.RuntimeContext, _ *encoder.Opcode, b, key, value []byte) []byte {
+	b = appendSeparator(ctx, b)
 	b = append(b, key[:len(key)-1]...)
-	b = append(b, ':')
+	b = appendDelimiter(ctx.Option.ColorScheme.Colon, b, ":")
 	return append(b, value...)
 }
 
-func appendMapEnd(_ *encoder.RuntimeContext, _ *encoder.Opcode, b []byte) []byte {
-	last := len(b) - 1
-	b[last] = '}'
-	b = append(b, ',')
-	return b
+func appendMapEnd(ctx *encoder.RuntimeContext, _ *encoder.Opcode, b []byte) []byte {
+	b = appendDelimiter(ctx.Option.ColorScheme.Brace, b[:len(b)-1], "}")
+	return append(b, ',')
 }
 func appendMarshalJSON(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []byte, v interface{}) ([]byte, error) {
 	return encoder.AppendMarshalJSON(ctx, code, b, v)
 }
 
+func appendCustom(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []byte, v interface{}) ([]byte, error) {
+	return encoder.AppendCustom(ctx, code, b, v)
+}
+
 func appendMarshalText(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []byte, v interface{}) ([]byte, error) {
 	format := ctx.Option.ColorScheme.String
 	b = append(b, format.Header...)
@@ -215,59 +280,70 @@ func appendMarshalText(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []by
 	}
 	return append(bb, format.Footer...), nil
 }
-func appendArrayHead(_ *encoder.RuntimeContext, _ *encoder.Opcode, b []byte) []byte {
-	return append(b, '[')
+func appendArrayHead(ctx *encoder.RuntimeContext, _ *encoder.Opcode, b []byte) []byte {
+	return appendDelimiter(ctx.Option.ColorScheme.Bracket, b, "[")
 }
 
-func appendArrayEnd(_ *encoder.RuntimeContext, _ *encoder.Opcode, b []byte) []byte {
-	last := len(b) - 1
-	b[last] = ']'
+func appendArrayEnd(ctx *encoder.RuntimeContext, _ *encoder.Opcode, b []byte) []byte {
+	b = appendDelimiter(ctx.Option.ColorScheme.Bracket, b[:len(b)-1], "]")
 	return append(b, ',')
 }
 
-func appendEmptyArray(_ *encoder.RuntimeContext, b []byte) []byte {
-	return append(b, '[', ']', ',')
+func appendEmptyArray(ctx *encoder.RuntimeContext, b []byte) []byte {
+	b = appendDelimiter(ctx.Option.ColorScheme.Bracket, b, "[]")
+	return append(b, ',')
 }
 
-func appendEmptyObject(_ *encoder.RuntimeContext, b []byte) []byte {
-	return append(b, '{', '}', ',')
+func appendEmptyObject(ctx *encoder.RuntimeContext, b []byte) []byte {
+	b = appendDelimiter(ctx.Option.ColorScheme.Brace, b, "{}")
+	return append(b, ',')
 }
 
-func appendObjectEnd(_ *encoder.RuntimeContext, _ *encoder.Opcode, b []byte) []byte {
-	last := len(b) - 1
-	b[last] = '}'
+func appendObjectEnd(ctx *encoder.RuntimeContext, _ *encoder.Opcode, b []byte) []byte {
+	b = appendDelimiter(ctx.Option.ColorScheme.Brace, b[:len(b)-1], "}")
 	return append(b, ',')
 }
 
-func appendStructHead(_ *encoder.RuntimeContext, b []byte) []byte {
-	return append(b, '{')
+func appendStructHead(ctx *encoder.RuntimeContext, b []byte) []byte {
+	return appendDelimiter(ctx.Option.ColorScheme.Brace, b, "{")
 }
 
 func appendStructKey(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []byte) []byte {
+	if code.Flags&encoder.InlineFlags != 0 {
+		return b
+	}
+	b = appendSeparator(ctx, b)
 	format := ctx.Option.ColorScheme.ObjectKey
 	b = append(b, format.Header...)
 	b = append(b, code.Key[:len(code.Key)-1]...)
 	b = append(b, format.Footer...)
 
-	return append(b, ':')
+	return appendDelimiter(ctx.Option.ColorScheme.Colon, b, ":")
 }
 
-func appendStructEnd(_ *encoder.RuntimeContext, _ *encoder.Opcode, b []byte) []byte {
-	return append(b, '}', ',')
+func appendStructEnd(ctx *encoder.RuntimeContext, _ *encoder.Opcode, b []byte) []byte {
+	b = appendStructEndDelimiter(ctx.Option.ColorScheme.Brace, b)
+	return append(b, ',')
 }
 
 func appendStructEndSkipLast(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []byte) []byte {
 	last := len(b) - 1
 	if b[last] == ',' {
-		b[last] = '}'
+		b = appendDelimiter(ctx.Option.ColorScheme.Brace, b[:last], "}")
 		return appendComma(ctx, b)
 	}
 	return appendStructEnd(ctx, code, b)
 }
-func restoreIndent(_ *encoder.RuntimeContext, _ *encoder.Opcode, _ uintptr)               {}
-func storeIndent(_ uintptr, _ *encoder.Opcode, _ uintptr)                                 {}
-func appendMapKeyIndent(_ *encoder.RuntimeContext, _ *encoder.Opcode, b []byte) []byte    { return b }
-func appendArrayElemIndent(_ *encoder.RuntimeContext, _ *encoder.Opcode, b []byte) []byte { return b }
+func restoreIndent(_ *encoder.RuntimeContext, _ *encoder.Opcode, _ uintptr) {}
+func storeIndent(_ uintptr, _ *encoder.Opcode, _ uintptr)                   {}
+
+func appendMapKeyIndent(ctx *encoder.RuntimeContext, _ *encoder.Opcode, b []byte) []byte {
+	return appendSeparator(ctx, b)
+}
+
+func appendArrayElemIndent(ctx *encoder.RuntimeContext, _ *encoder.Opcode, b []byte) []byte {
+	return appendSeparator(ctx, b)
+}
 
 // callIsZeroMethod calls the IsZero() method on a value if it exists.
 // Used for omitzero tag support when a custom IsZero() method is present.
@@ -276,6 +352,31 @@ func callIsZeroMethod(code *encoder.Opcode, ptr uintptr) bool {
 	return encoder.CallIsZeroMethod(code, ptr)
 }
 
+// appendRedacted appends the string that replaces the value v of the struct field or the map entry whose key is key.
+func appendRedacted(ctx *encoder.RuntimeContext, b []byte, key string, v interface{}) []byte {
+	s, ok := encoder.Redact(ctx, key, v)
+	if !ok {
+		return appendNull(ctx, b)
+	}
+	return appendString(ctx, b, s)
+}
+
+// beginMapKey appends the header of the object keys, and makes the map key encoded without colors until endMapKey.
+func beginMapKey(ctx *encoder.RuntimeContext, b []byte) []byte {
+	return append(b, ctx.BeginColorMapKey().Header...)
+}
+
+// endMapKey inserts the footer of the object keys before the trailing comma of the encoded map key.
+func endMapKey(ctx *encoder.RuntimeContext, b []byte) []byte {
+	footer := ctx.EndColorMapKey().Footer
+	return append(append(b[:len(b)-1], footer...), ',')
+}
+
+// isRedactedEmpty reports whether the field of code is redacted and its value at ptr is empty.
+func isRedactedEmpty(code *encoder.Opcode, ptr uintptr) bool {
+	return code.Flags&encoder.RedactFlags != 0 && encoder.IsRedactedEmpty(code.Type, ptr)
+}
+
 // isStructZero checks if a struct value is zero-valued.
 // Uses custom IsZero() method if available, otherwise falls back to reflect.Value.IsZero().
 // This is a wrapper function that delegates to encoder.IsStructZero.
@@ -56,16 +56,30 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			b = appendUint(ctx, b, load(ctxptr, code.Idx), code)
 			b = appendComma(ctx, b)
 			code = code.Next
+		case encoder.OpIntPtrString:
+			p := loadNPtr(ctxptr, code.Idx, code.PtrNum)
+			if p == 0 {
+				b = appendNullComma(ctx, b)
+				code = code.Next
+				break
+			}
+			store(ctxptr, code.Idx, p)
+			fallthrough
 		case encoder.OpIntString:
-			b = append(b, '"')
-			b = appendInt(ctx, b, load(ctxptr, code.Idx), code)
-			b = append(b, '"')
+			b = appendIntString(ctx, b, load(ctxptr, code.Idx), code)
 			b = appendComma(ctx, b)
 			code = code.Next
+		case encoder.OpUintPtrString:
+			p := loadNPtr(ctxptr, code.Idx, code.PtrNum)
+			if p == 0 {
+				b = appendNullComma(ctx, b)
+				code = code.Next
+				break
+			}
+			store(ctxptr, code.Idx, p)
+			fallthrough
 		case encoder.OpUintString:
-			b = append(b, '"')
-			b = appendUint(ctx, b, load(ctxptr, code.Idx), code)
-			b = append(b, '"')
+			b = appendUintString(ctx, b, load(ctxptr, code.Idx), code)
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpFloat32Ptr:
@@ -135,7 +149,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			store(ctxptr, code.Idx, p)
 			fallthrough
 		case encoder.OpBytes:
-			b = appendByteSlice(ctx, b, ptrToBytes(load(ctxptr, code.Idx)))
+			b = appendBytes(ctx, b, ptrToBytes(load(ctxptr, code.Idx)), code)
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpNumberPtr:
@@ -196,7 +210,9 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			}
 			if ifacePtr == nil {
 				isDirectedNil := typ != nil && typ.Kind() == reflect.Struct && !runtime.IfaceIndir(typ)
-				if !isDirectedNil {
+				// a nil map is passed to the map code, which encodes it as {} by NilAsEmptyOption
+				isNilAsEmptyMap := typ != nil && typ.Kind() == reflect.Map && (ctx.Option.Flag&encoder.NilAsEmptyOption) != 0
+				if !isDirectedNil && !isNilAsEmptyMap {
 					b = appendNullComma(ctx, b)
 					code = code.Next
 					break
@@ -274,6 +290,41 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			}
 			b = appendComma(ctx, bb)
 			code = code.Next
+		case encoder.OpCustomPtr:
+			p := loadNPtr(ctxptr, code.Idx, code.PtrNum)
+			if p == 0 {
+				b = appendNullComma(ctx, b)
+				code = code.Next
+				break
+			}
+			store(ctxptr, code.Idx, p)
+			fallthrough
+		case encoder.OpCustom:
+			p := load(ctxptr, code.Idx)
+			if p == 0 {
+				b = appendNullComma(ctx, b)
+				code = code.Next
+				break
+			}
+			if (code.Flags&encoder.IsNilableTypeFlags) != 0 && (code.Flags&encoder.IndirectFlags) != 0 {
+				p = ptrToPtr(p)
+				if p == 0 {
+					b = appendNullComma(ctx, b)
+					code = code.Next
+					break
+				}
+			}
+			if code.Flags&encoder.RedactFlags != 0 {
+				b = appendComma(ctx, appendRedacted(ctx, b, code.Key, ptrToInterface(code, p)))
+				code = code.Next
+				break
+			}
+			bb, err := appendCustom(ctx, code, b, ptrToInterface(code, p))
+			if err != nil {
+				return nil, err
+			}
+			b = appendComma(ctx, bb)
+			code = code.Next
 		case encoder.OpMarshalTextPtr:
 			p := load(ctxptr, code.Idx)
 			if p == 0 {
@@ -315,7 +366,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			// p is a pointer to slice, dereference it to get the actual slice
 			slice := ptrToSlice(p)
 			if slice.Data == nil {
-				b = appendNullComma(ctx, b)
+				if code.Flags&encoder.NonNilFlags != 0 {
+					b = appendEmptyArray(ctx, b)
+				} else {
+					b = appendNullComma(ctx, b)
+				}
 				code = code.End.Next
 				break
 			}
@@ -335,7 +390,11 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p := load(ctxptr, code.Idx)
 			slice := ptrToSlice(p)
 			if p == 0 || slice.Data == nil {
-				b = appendNullComma(ctx, b)
+				if p != 0 && code.Flags&encoder.NonNilFlags != 0 {
+					b = appendEmptyArray(ctx, b)
+				} else {
+					b = appendNullComma(ctx, b)
+				}
 				code = code.End.Next
 				break
 			}
@@ -351,6 +410,13 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				code = code.End.Next
 			}
 		case encoder.OpSliceElem:
+			if ctx.Writer != nil && len(b) >= ctx.FlushThreshold {
+				bb, err := encoder.FlushBuffer(ctx, b)
+				if err != nil {
+					return nil, err
+				}
+				b = bb
+			}
 			idx := load(ctxptr, code.ElemIdx)
 			length := load(ctxptr, code.Length)
 			idx++
@@ -391,6 +457,13 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				code = code.End.Next
 			}
 		case encoder.OpArrayElem:
+			if ctx.Writer != nil && len(b) >= ctx.FlushThreshold {
+				bb, err := encoder.FlushBuffer(ctx, b)
+				if err != nil {
+					return nil, err
+				}
+				b = bb
+			}
 			idx := load(ctxptr, code.ElemIdx)
 			idx++
 			if idx < uintptr(code.Length) {
@@ -420,8 +493,17 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			fallthrough
 		case encoder.OpMap:
 			p := load(ctxptr, code.Idx)
+			if code.Flags&encoder.InlineFlags != 0 && (p == 0 || maplen(ptrToUnsafePtr(p)) <= 0) {
+				// the inline map without entries adds no members to the parent object
+				code = code.End.Next
+				break
+			}
 			if p == 0 {
-				b = appendNullComma(ctx, b)
+				if code.Flags&encoder.NonNilFlags != 0 {
+					b = appendEmptyObject(ctx, b)
+				} else {
+					b = appendNullComma(ctx, b)
+				}
 				code = code.End.Next
 				break
 			}
@@ -432,10 +514,24 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				code = code.End.Next
 				break
 			}
-			b = appendStructHead(ctx, b)
 			unorderedMap := (ctx.Option.Flag & encoder.UnorderedMapOption) != 0
 			mapCtx := encoder.NewMapContext(mlen, unorderedMap)
 			mapiterinit(code.Type, uptr, &mapCtx.Iter)
+			key := mapiterkey(&mapCtx.Iter)
+			if code.Flags&encoder.InlineFlags == 0 {
+				b = appendStructHead(ctx, b)
+			} else {
+				if code.InlineKeys != nil {
+					key = mapCtx.SkipInlineKeys(code.InlineKeys, 0)
+					if mapCtx.Len == 0 {
+						encoder.ReleaseMapContext(mapCtx)
+						code = code.End.Next
+						break
+					}
+				}
+				// the entries of the inline map follow the previous field of the parent object.
+				b = appendSeparator(ctx, b)
+			}
 			store(ctxptr, code.Idx, uintptr(unsafe.Pointer(mapCtx)))
 			ctx.KeepRefs = append(ctx.KeepRefs, unsafe.Pointer(mapCtx))
 			if unorderedMap {
@@ -443,8 +539,9 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			} else {
 				mapCtx.Start = len(b)
 				mapCtx.First = len(b)
+				ctx.SortingMapDepth++
 			}
-			key := mapiterkey(&mapCtx.Iter)
+			b = beginMapKey(ctx, b)
 			store(ctxptr, code.Next.Idx, uintptr(key))
 			code = code.Next
 		case encoder.OpMapKey:
@@ -452,22 +549,39 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			idx := mapCtx.Idx
 			idx++
 			if (ctx.Option.Flag & encoder.UnorderedMapOption) != 0 {
+				if ctx.Writer != nil && len(b) >= ctx.FlushThreshold {
+					bb, err := encoder.FlushBuffer(ctx, b)
+					if err != nil {
+						return nil, err
+					}
+					b = bb
+				}
+				if code.InlineKeys != nil {
+					mapCtx.SkipInlineKeys(code.InlineKeys, idx)
+				}
 				if idx < mapCtx.Len {
 					b = appendMapKeyIndent(ctx, code, b)
+					b = beginMapKey(ctx, b)
 					mapCtx.Idx = int(idx)
 					key := mapiterkey(&mapCtx.Iter)
 					store(ctxptr, code.Next.Idx, uintptr(key))
 					code = code.Next
 				} else {
-					b = appendObjectEnd(ctx, code, b)
+					if code.Flags&encoder.InlineFlags == 0 {
+						b = appendObjectEnd(ctx, code, b)
+					}
 					encoder.ReleaseMapContext(mapCtx)
 					code = code.End.Next
 				}
 			} else {
 				mapCtx.Slice.Items[mapCtx.Idx].Value = b[mapCtx.Start:len(b)]
+				if code.InlineKeys != nil {
+					mapCtx.SkipInlineKeys(code.InlineKeys, idx)
+				}
 				if idx < mapCtx.Len {
 					mapCtx.Idx = int(idx)
 					mapCtx.Start = len(b)
+					b = beginMapKey(ctx, b)
 					key := mapiterkey(&mapCtx.Iter)
 					store(ctxptr, code.Next.Idx, uintptr(key))
 					code = code.Next
@@ -477,6 +591,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			}
 		case encoder.OpMapValue:
 			mapCtx := (*encoder.MapContext)(ptrToUnsafePtr(load(ctxptr, code.Idx)))
+			b = endMapKey(ctx, b)
 			if (ctx.Option.Flag & encoder.UnorderedMapOption) != 0 {
 				b = appendColon(ctx, b)
 			} else {
@@ -484,6 +599,14 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				mapCtx.Start = len(b)
 			}
 			value := mapitervalue(&mapCtx.Iter)
+			if code.Flags&encoder.RedactFlags != 0 {
+				if k, v, ok := encoder.RedactedMapValue(ctx, code, mapiterkey(&mapCtx.Iter), value); ok {
+					b = appendComma(ctx, appendRedacted(ctx, b, k, v))
+					mapiternext(&mapCtx.Iter)
+					code = code.NextField
+					break
+				}
+			}
 			store(ctxptr, code.Next.Idx, uintptr(value))
 			mapiternext(&mapCtx.Iter)
 			code = code.Next
@@ -495,11 +618,14 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			for _, item := range mapCtx.Slice.Items {
 				buf = appendMapKeyValue(ctx, code, buf, item.Key, item.Value)
 			}
-			buf = appendMapEnd(ctx, code, buf)
+			if code.Flags&encoder.InlineFlags == 0 {
+				buf = appendMapEnd(ctx, code, buf)
+			}
 			b = b[:mapCtx.First]
 			b = append(b, buf...)
 			mapCtx.Buf = buf
 			encoder.ReleaseMapContext(mapCtx)
+			ctx.SortingMapDepth--
 			code = code.Next
 		case encoder.OpRecursivePtr:
 			p := load(ctxptr, code.Idx)
@@ -634,7 +760,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				b = appendStructHead(ctx, b)
 			}
 			p += uintptr(code.Offset)
-			if p == 0 || (ptrToPtr(p) == 0 && (code.Flags&encoder.IsNextOpPtrTypeFlags) != 0) {
+			if p == 0 || (ptrToPtr(p) == 0 && (code.Flags&encoder.IsNextOpPtrTypeFlags) != 0) || isRedactedEmpty(code, p) {
 				code = code.NextField
 			} else {
 				// Check OmitZero flag: skip field if struct is zero-valued
@@ -760,9 +886,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				code = code.NextField
 			} else {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendInt(ctx, b, p+uintptr(code.Offset), code)
-				b = append(b, '"')
+				b = appendIntString(ctx, b, p+uintptr(code.Offset), code)
 				b = appendComma(ctx, b)
 				code = code.Next
 			}
@@ -798,9 +922,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				code = code.NextField
 			} else {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendInt(ctx, b, p, code)
-				b = append(b, '"')
+				b = appendIntString(ctx, b, p, code)
 				b = appendComma(ctx, b)
 				code = code.Next
 			}
@@ -912,9 +1034,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = append(b, '"')
-				b = appendInt(ctx, b, p, code)
-				b = append(b, '"')
+				b = appendIntString(ctx, b, p, code)
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -946,9 +1066,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			}
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendInt(ctx, b, p, code)
-				b = append(b, '"')
+				b = appendIntString(ctx, b, p, code)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -1055,9 +1173,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				code = code.NextField
 			} else {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendUint(ctx, b, p+uintptr(code.Offset), code)
-				b = append(b, '"')
+				b = appendUintString(ctx, b, p+uintptr(code.Offset), code)
 				b = appendComma(ctx, b)
 				code = code.Next
 			}
@@ -1092,9 +1208,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				code = code.NextField
 			} else {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendUint(ctx, b, p+uintptr(code.Offset), code)
-				b = append(b, '"')
+				b = appendUintString(ctx, b, p+uintptr(code.Offset), code)
 				b = appendComma(ctx, b)
 				code = code.Next
 			}
@@ -1206,9 +1320,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = append(b, '"')
-				b = appendUint(ctx, b, p, code)
-				b = append(b, '"')
+				b = appendUintString(ctx, b, p, code)
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -1240,9 +1352,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			}
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendUint(ctx, b, p, code)
-				b = append(b, '"')
+				b = appendUintString(ctx, b, p, code)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -2429,7 +2539,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 				b = appendStructHead(ctx, b)
 			}
 			b = appendStructKey(ctx, code, b)
-			b = appendByteSlice(ctx, b, ptrToBytes(p+uintptr(code.Offset)))
+			b = appendBytes(ctx, b, ptrToBytes(p+uintptr(code.Offset)), code)
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpStructPtrHeadOmitEmptyBytes:
@@ -2496,7 +2606,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = appendByteSlice(ctx, b, ptrToBytes(p))
+				b = appendBytes(ctx, b, ptrToBytes(p), code)
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -2528,7 +2638,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			}
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = appendByteSlice(ctx, b, ptrToBytes(p))
+				b = appendBytes(ctx, b, ptrToBytes(p), code)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -3576,7 +3686,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 		case encoder.OpStructFieldOmitEmpty:
 			p := load(ctxptr, code.Idx)
 			p += uintptr(code.Offset)
-			if ptrToPtr(p) == 0 && (code.Flags&encoder.IsNextOpPtrTypeFlags) != 0 {
+			if (ptrToPtr(p) == 0 && (code.Flags&encoder.IsNextOpPtrTypeFlags) != 0) || isRedactedEmpty(code, p) {
 				code = code.NextField
 			} else {
 				// Check OmitZero flag: skip field if struct is zero-valued
@@ -3634,14 +3744,10 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 					continue
 				}
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendInt(ctx, b, p+uintptr(code.Offset), code)
-				b = append(b, '"')
+				b = appendIntString(ctx, b, p+uintptr(code.Offset), code)
 			} else {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendInt(ctx, b, p+uintptr(code.Offset), code)
-				b = append(b, '"')
+				b = appendIntString(ctx, b, p+uintptr(code.Offset), code)
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -3651,9 +3757,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			v := u64 & ((1 << code.NumBitSize) - 1)
 			if v != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendInt(ctx, b, p+uintptr(code.Offset), code)
-				b = append(b, '"')
+				b = appendIntString(ctx, b, p+uintptr(code.Offset), code)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -3696,9 +3800,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = append(b, '"')
-				b = appendInt(ctx, b, p, code)
-				b = append(b, '"')
+				b = appendIntString(ctx, b, p, code)
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -3707,9 +3809,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p = ptrToNPtr(p+uintptr(code.Offset), code.PtrNum)
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendInt(ctx, b, p, code)
-				b = append(b, '"')
+				b = appendIntString(ctx, b, p, code)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -3749,14 +3849,10 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 					continue
 				}
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendUint(ctx, b, p+uintptr(code.Offset), code)
-				b = append(b, '"')
+				b = appendUintString(ctx, b, p+uintptr(code.Offset), code)
 			} else {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendUint(ctx, b, p+uintptr(code.Offset), code)
-				b = append(b, '"')
+				b = appendUintString(ctx, b, p+uintptr(code.Offset), code)
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -3766,9 +3862,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			v := u64 & ((1 << code.NumBitSize) - 1)
 			if v != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendUint(ctx, b, p+uintptr(code.Offset), code)
-				b = append(b, '"')
+				b = appendUintString(ctx, b, p+uintptr(code.Offset), code)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -3805,9 +3899,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = append(b, '"')
-				b = appendUint(ctx, b, p, code)
-				b = append(b, '"')
+				b = appendUintString(ctx, b, p, code)
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -3816,9 +3908,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p = ptrToNPtr(p+uintptr(code.Offset), code.PtrNum)
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendUint(ctx, b, p, code)
-				b = append(b, '"')
+				b = appendUintString(ctx, b, p, code)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -4245,7 +4335,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 		case encoder.OpStructFieldBytes:
 			p := load(ctxptr, code.Idx)
 			b = appendStructKey(ctx, code, b)
-			b = appendByteSlice(ctx, b, ptrToBytes(p+uintptr(code.Offset)))
+			b = appendBytes(ctx, b, ptrToBytes(p+uintptr(code.Offset)), code)
 			b = appendComma(ctx, b)
 			code = code.Next
 		case encoder.OpStructFieldOmitEmptyBytes:
@@ -4264,7 +4354,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = appendByteSlice(ctx, b, ptrToBytes(p))
+				b = appendBytes(ctx, b, ptrToBytes(p), code)
 			}
 			b = appendComma(ctx, b)
 			code = code.Next
@@ -4273,7 +4363,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p = ptrToNPtr(p+uintptr(code.Offset), code.PtrNum)
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = appendByteSlice(ctx, b, ptrToBytes(p))
+				b = appendBytes(ctx, b, ptrToBytes(p), code)
 				b = appendComma(ctx, b)
 			}
 			code = code.Next
@@ -4637,6 +4727,12 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p = ptrToPtr(p + uintptr(code.Offset))
 			if p != 0 {
 				p = ptrToNPtr(p, code.PtrNum)
+			} else if code.Flags&encoder.OmitZeroFlags == 0 {
+				// nil pointer to map is encoded as null even if the map is encoded as an empty object for nil
+				b = appendStructKey(ctx, code, b)
+				b = appendNullComma(ctx, b)
+				code = code.NextField
+				break
 			}
 			// Check OmitZero flag: skip field if map pointer is nil or points to nil map
 			if code.Flags&encoder.OmitZeroFlags != 0 && p == 0 {
@@ -4715,9 +4811,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 		case encoder.OpStructEndIntString:
 			p := load(ctxptr, code.Idx)
 			b = appendStructKey(ctx, code, b)
-			b = append(b, '"')
-			b = appendInt(ctx, b, p+uintptr(code.Offset), code)
-			b = append(b, '"')
+			b = appendIntString(ctx, b, p+uintptr(code.Offset), code)
 			b = appendStructEnd(ctx, code, b)
 			code = code.Next
 		case encoder.OpStructEndOmitEmptyIntString:
@@ -4726,9 +4820,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			v := u64 & ((1 << code.NumBitSize) - 1)
 			if v != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendInt(ctx, b, p+uintptr(code.Offset), code)
-				b = append(b, '"')
+				b = appendIntString(ctx, b, p+uintptr(code.Offset), code)
 				b = appendStructEnd(ctx, code, b)
 			} else {
 				b = appendStructEndSkipLast(ctx, code, b)
@@ -4763,9 +4855,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = append(b, '"')
-				b = appendInt(ctx, b, p, code)
-				b = append(b, '"')
+				b = appendIntString(ctx, b, p, code)
 			}
 			b = appendStructEnd(ctx, code, b)
 			code = code.Next
@@ -4774,9 +4864,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p = ptrToNPtr(p+uintptr(code.Offset), code.PtrNum)
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendInt(ctx, b, p, code)
-				b = append(b, '"')
+				b = appendIntString(ctx, b, p, code)
 				b = appendStructEnd(ctx, code, b)
 			} else {
 				b = appendStructEndSkipLast(ctx, code, b)
@@ -4803,9 +4891,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 		case encoder.OpStructEndUintString:
 			p := load(ctxptr, code.Idx)
 			b = appendStructKey(ctx, code, b)
-			b = append(b, '"')
-			b = appendUint(ctx, b, p+uintptr(code.Offset), code)
-			b = append(b, '"')
+			b = appendUintString(ctx, b, p+uintptr(code.Offset), code)
 			b = appendStructEnd(ctx, code, b)
 			code = code.Next
 		case encoder.OpStructEndOmitEmptyUintString:
@@ -4814,9 +4900,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			v := u64 & ((1 << code.NumBitSize) - 1)
 			if v != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendUint(ctx, b, p+uintptr(code.Offset), code)
-				b = append(b, '"')
+				b = appendUintString(ctx, b, p+uintptr(code.Offset), code)
 				b = appendStructEnd(ctx, code, b)
 			} else {
 				b = appendStructEndSkipLast(ctx, code, b)
@@ -4851,9 +4935,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = append(b, '"')
-				b = appendUint(ctx, b, p, code)
-				b = append(b, '"')
+				b = appendUintString(ctx, b, p, code)
 			}
 			b = appendStructEnd(ctx, code, b)
 			code = code.Next
@@ -4862,9 +4944,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p = ptrToNPtr(p+uintptr(code.Offset), code.PtrNum)
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = append(b, '"')
-				b = appendUint(ctx, b, p, code)
-				b = append(b, '"')
+				b = appendUintString(ctx, b, p, code)
 				b = appendStructEnd(ctx, code, b)
 			} else {
 				b = appendStructEndSkipLast(ctx, code, b)
@@ -5242,7 +5322,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 		case encoder.OpStructEndBytes:
 			p := load(ctxptr, code.Idx)
 			b = appendStructKey(ctx, code, b)
-			b = appendByteSlice(ctx, b, ptrToBytes(p+uintptr(code.Offset)))
+			b = appendBytes(ctx, b, ptrToBytes(p+uintptr(code.Offset)), code)
 			b = appendStructEnd(ctx, code, b)
 			code = code.Next
 		case encoder.OpStructEndOmitEmptyBytes:
@@ -5263,7 +5343,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			if p == 0 {
 				b = appendNull(ctx, b)
 			} else {
-				b = appendByteSlice(ctx, b, ptrToBytes(p))
+				b = appendBytes(ctx, b, ptrToBytes(p), code)
 			}
 			b = appendStructEnd(ctx, code, b)
 			code = code.Next
@@ -5272,7 +5352,7 @@ func Run(ctx *encoder.RuntimeContext, b []byte, codeSet *encoder.OpcodeSet) ([]b
 			p = ptrToNPtr(p+uintptr(code.Offset), code.PtrNum)
 			if p != 0 {
 				b = appendStructKey(ctx, code, b)
-				b = appendByteSlice(ctx, b, ptrToBytes(p))
+				b = appendBytes(ctx, b, ptrToBytes(p), code)
 				b = appendStructEnd(ctx, code, b)
 			} else {
 				b = appendStructEndSkipLast(ctx, code, b)
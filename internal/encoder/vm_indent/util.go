@@ -115,6 +115,32 @@ func ptrToInterface(code *encoder.Opcode, p uintptr) interface{} {
 		ptr: *(*unsafe.Pointer)(unsafe.Pointer(&p)),
 	}))
 }
+
+func appendIntString(ctx *encoder.RuntimeContext, b []byte, p uintptr, code *encoder.Opcode) []byte {
+	if code.Flags&encoder.UnsafeIntStringFlags != 0 && encoder.IsSafeInt(p, code) {
+		return appendInt(ctx, b, p, code)
+	}
+	b = append(b, '"')
+	b = appendInt(ctx, b, p, code)
+	return append(b, '"')
+}
+
+func appendUintString(ctx *encoder.RuntimeContext, b []byte, p uintptr, code *encoder.Opcode) []byte {
+	if code.Flags&encoder.UnsafeIntStringFlags != 0 && encoder.IsSafeUint(p, code) {
+		return appendUint(ctx, b, p, code)
+	}
+	b = append(b, '"')
+	b = appendUint(ctx, b, p, code)
+	return append(b, '"')
+}
+
+// appendBytes appends src, which is encoded as an empty string instead of null if code has NonNilFlags.
+func appendBytes(ctx *encoder.RuntimeContext, b []byte, src []byte, code *encoder.Opcode) []byte {
+	if src == nil && code.Flags&encoder.NonNilFlags != 0 {
+		src = []byte{}
+	}
+	return appendByteSlice(ctx, b, src)
+}
 func appendBool(_ *encoder.RuntimeContext, b []byte, v bool) []byte {
 	if v {
 		return append(b, "true"...)
@@ -132,6 +158,10 @@ func appendComma(_ *encoder.RuntimeContext, b []byte) []byte {
 func appendNullComma(_ *encoder.RuntimeContext, b []byte) []byte {
 	return append(b, "null,\n"...)
 }
+
+func appendSeparator(_ *encoder.RuntimeContext, b []byte) []byte {
+	return b
+}
 func appendColon(_ *encoder.RuntimeContext, b []byte) []byte {
 	return append(b[:len(b)-2], ':', ' ')
 }
@@ -154,6 +184,10 @@ func appendMarshalJSON(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []by
 	return encoder.AppendMarshalJSONIndent(ctx, code, b, v)
 }
 
+func appendCustom(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []byte, v interface{}) ([]byte, error) {
+	return encoder.AppendCustomIndent(ctx, code, b, v)
+}
+
 func appendMarshalText(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []byte, v interface{}) ([]byte, error) {
 	return encoder.AppendMarshalTextIndent(ctx, code, b, v)
 }
@@ -190,6 +224,9 @@ func appendStructHead(_ *encoder.RuntimeContext, b []byte) []byte {
 }
 
 func appendStructKey(ctx *encoder.RuntimeContext, code *encoder.Opcode, b []byte) []byte {
+	if code.Flags&encoder.InlineFlags != 0 {
+		return b
+	}
 	b = appendIndent(ctx, b, code.Indent)
 	b = append(b, code.Key...)
 	return append(b, ' ')
@@ -234,6 +271,23 @@ func callIsZeroMethod(code *encoder.Opcode, ptr uintptr) bool {
 	return encoder.CallIsZeroMethod(code, ptr)
 }
 
+// appendRedacted appends the string that replaces the value v of the struct field or the map entry whose key is key.
+func appendRedacted(ctx *encoder.RuntimeContext, b []byte, key string, v interface{}) []byte {
+	s, ok := encoder.Redact(ctx, key, v)
+	if !ok {
+		return appendNull(ctx, b)
+	}
+	return appendString(ctx, b, s)
+}
+
+func beginMapKey(_ *encoder.RuntimeContext, b []byte) []byte { return b }
+func endMapKey(_ *encoder.RuntimeContext, b []byte) []byte   { return b }
+
+// isRedactedEmpty reports whether the field of code is redacted and its value at ptr is empty.
+func isRedactedEmpty(code *encoder.Opcode, ptr uintptr) bool {
+	return code.Flags&encoder.RedactFlags != 0 && encoder.IsRedactedEmpty(code.Type, ptr)
+}
+
 // isStructZero checks if a struct value is zero-valued.
 // Uses custom IsZero() method if available, otherwise falls back to reflect.Value.IsZero().
 // This is a wrapper function that delegates to encoder.IsStructZero.
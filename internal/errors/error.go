@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 )
 
 type InvalidUTF8Error struct {
@@ -109,6 +110,14 @@ func (e *UnsupportedValueError) Error() string {
 	return "json: unsupported value: " + e.Str
 }
 
+func ErrUnsupportedFormat(format string, typ reflect.Type) error {
+	return fmt.Errorf("json: format %q is not supported for type %s", format, typ)
+}
+
+func ErrUnsupportedInline(field string, typ reflect.Type) error {
+	return fmt.Errorf("json: inline field %s must be a map with string keys, but got %s", field, typ)
+}
+
 func ErrSyntax(msg string, offset int64) *SyntaxError {
 	return &SyntaxError{msg: msg, Offset: offset}
 }
@@ -181,3 +190,43 @@ func ErrInvalidPath(msg string, args ...interface{}) *PathError {
 func ErrEmptyPath() *PathError {
 	return &PathError{msg: "path is empty"}
 }
+
+// FieldQueryError describes the fields of a field query that do not exist in the type.
+type FieldQueryError struct {
+	Type  reflect.Type
+	Paths []string // dot-separated paths of the unknown fields
+}
+
+func (e *FieldQueryError) Error() string {
+	paths := make([]string, 0, len(e.Paths))
+	for _, path := range e.Paths {
+		paths = append(paths, strconv.Quote(path))
+	}
+	return fmt.Sprintf("json: unknown field %s in field query for type %s", strings.Join(paths, ", "), e.Type)
+}
+
+// LineError describes the error of the record at Line of JSON Lines.
+type LineError struct {
+	Line int // 1-based line number of the record
+	Err  error
+}
+
+func (e *LineError) Error() string {
+	return fmt.Sprintf("json: line %d: %s", e.Line, strings.TrimPrefix(e.Err.Error(), "json: "))
+}
+
+// Unwrap returns the underlying error.
+func (e *LineError) Unwrap() error { return e.Err }
+
+// SeqError describes the error of the element at Index of the JSON text sequence.
+type SeqError struct {
+	Index int // 1-based index of the element in the sequence
+	Err   error
+}
+
+func (e *SeqError) Error() string {
+	return fmt.Sprintf("json: sequence element %d: %s", e.Index, strings.TrimPrefix(e.Err.Error(), "json: "))
+}
+
+// Unwrap returns the underlying error.
+func (e *SeqError) Unwrap() error { return e.Err }
@@ -0,0 +1,41 @@
+package runtime
+
+import "sync/atomic"
+
+// cacheLimit is the maximum number of entries held by each of the caches keyed by types outside the binary.
+// Zero means no limit.
+var cacheLimit int64
+
+// SetCacheLimit sets the maximum number of entries held by each of the slow-path caches of compiled codes.
+func SetCacheLimit(n int) {
+	if n < 0 {
+		n = 0
+	}
+	atomic.StoreInt64(&cacheLimit, int64(n))
+}
+
+// CacheLimit returns the limit set by SetCacheLimit. Zero means no limit.
+func CacheLimit() int {
+	return int(atomic.LoadInt64(&cacheLimit))
+}
+
+// CopyCacheMap copies the copy-on-write cache m to a new map for adding an entry.
+// If m has reached the cache limit, arbitrary entries are evicted so that the new entry fits within the limit,
+// and the number of the evicted entries is returned.
+func CopyCacheMap[K comparable, V any](m map[K]V) (map[K]V, int) {
+	var evicted int
+	if limit := int(atomic.LoadInt64(&cacheLimit)); limit > 0 && len(m) >= limit {
+		evicted = len(m) - limit + 1
+	}
+	newMap := make(map[K]V, len(m)+1-evicted)
+	skip := evicted
+	for k, v := range m {
+		if skip > 0 {
+			// the iteration order of the map is random, so the evicted entries are chosen randomly.
+			skip--
+			continue
+		}
+		newMap[k] = v
+	}
+	return newMap, evicted
+}
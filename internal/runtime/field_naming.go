@@ -0,0 +1,94 @@
+package runtime
+
+import (
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode"
+	"unicode/utf8"
+)
+
+// FieldNaming converts a Go struct field name into an object key.
+type FieldNaming struct {
+	name     string
+	fn       func(string) string
+	cacheKey string
+}
+
+var fieldNamingID uint64
+
+// NewFieldNaming creates a FieldNaming from fn.
+// The compiled code is cached per FieldNaming, so create it once and reuse it.
+func NewFieldNaming(name string, fn func(string) string) *FieldNaming {
+	return &FieldNaming{
+		name:     name,
+		fn:       fn,
+		cacheKey: strconv.FormatUint(atomic.AddUint64(&fieldNamingID, 1), 10),
+	}
+}
+
+var (
+	SnakeCaseNaming = NewFieldNaming("snake_case", func(name string) string {
+		return strings.ToLower(strings.Join(splitFieldName(name), "_"))
+	})
+	KebabCaseNaming = NewFieldNaming("kebab-case", func(name string) string {
+		return strings.ToLower(strings.Join(splitFieldName(name), "-"))
+	})
+	CamelCaseNaming = NewFieldNaming("camelCase", func(name string) string {
+		words := splitFieldName(name)
+		if len(words) == 0 {
+			return name
+		}
+		words[0] = strings.ToLower(words[0])
+		return strings.Join(words, "")
+	})
+)
+
+// Name returns the name given to NewFieldNaming.
+func (n *FieldNaming) Name() string {
+	return n.name
+}
+
+// Apply converts the field name.
+func (n *FieldNaming) Apply(name string) string {
+	return n.fn(name)
+}
+
+// splitFieldName splits a Go identifier into words.
+// An uppercase letter starts a new word when it follows a lowercase letter or digit,
+// or when it is the last letter of an acronym followed by a lowercase letter ( e.g. HTTPServer => HTTP, Server ).
+// Underscores separate words and are dropped.
+func splitFieldName(name string) []string {
+	var (
+		words []string
+		start = -1
+		prev  rune
+	)
+	for i, r := range name {
+		if r == '_' {
+			if start >= 0 {
+				words = append(words, name[start:i])
+			}
+			start = -1
+			prev = r
+			continue
+		}
+		if start < 0 {
+			start = i
+			prev = r
+			continue
+		}
+		if unicode.IsUpper(r) {
+			next, _ := utf8.DecodeRuneInString(name[i+utf8.RuneLen(r):])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && unicode.IsLower(next)) {
+				words = append(words, name[start:i])
+				start = i
+			}
+		}
+		prev = r
+	}
+	if start >= 0 {
+		words = append(words, name[start:])
+	}
+	return words
+}
@@ -0,0 +1,57 @@
+package runtime
+
+import (
+	"reflect"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	durationType = reflect.TypeOf(time.Duration(0))
+)
+
+// timeLayouts maps the names of the layout constants in the time package to their layouts.
+var timeLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RubyDate":    time.RubyDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"Stamp":       time.Stamp,
+	"StampMilli":  time.StampMilli,
+	"StampMicro":  time.StampMicro,
+	"StampNano":   time.StampNano,
+	"DateTime":    "2006-01-02 15:04:05",
+	"DateOnly":    "2006-01-02",
+	"TimeOnly":    "15:04:05",
+}
+
+// IsTimeType reports whether typ is time.Time.
+func IsTimeType(typ *Type) bool {
+	return typ == Type2RType(timeType)
+}
+
+// IsDurationType reports whether typ is time.Duration.
+func IsDurationType(typ *Type) bool {
+	return typ == Type2RType(durationType)
+}
+
+// IsByteSliceType reports whether typ is a slice of bytes.
+func IsByteSliceType(typ *Type) bool {
+	return typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8
+}
+
+// TimeLayout returns the layout specified by the format option of time.Time.
+// format is either the name of a layout constant in the time package ( e.g. RFC3339Nano ) or a layout itself.
+func TimeLayout(format string) string {
+	if layout, exists := timeLayouts[format]; exists {
+		return layout
+	}
+	return format
+}
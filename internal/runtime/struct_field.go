@@ -6,11 +6,76 @@ import (
 	"unicode"
 )
 
-func getTag(field reflect.StructField) string {
-	return field.Tag.Get("json")
+// DefaultTagKey is the struct tag key consulted when no tag key is configured.
+const DefaultTagKey = "json"
+
+// StructTagOption controls how struct tags are interpreted when compiling encoders and decoders.
+// A nil *StructTagOption means the default behavior of reading the "json" tag.
+type StructTagOption struct {
+	// TagKeys is the fallback chain of tag keys. The first key present on a field is used.
+	TagKeys []string
+	// Naming converts the Go field name into the object key of fields without a tag name.
+	Naming *FieldNaming
+
+	cacheKey string
+}
+
+// WithTagKeys returns a copy of o that reads struct tags from keys.
+// If keys is only the default tag key, the returned option is equivalent to nil.
+func (o *StructTagOption) WithTagKeys(keys []string) *StructTagOption {
+	var opt StructTagOption
+	if o != nil {
+		opt = *o
+	}
+	if len(keys) == 1 && keys[0] == DefaultTagKey {
+		keys = nil
+	}
+	opt.TagKeys = keys
+	return opt.normalize()
+}
+
+// WithNaming returns a copy of o that names untagged fields with naming.
+func (o *StructTagOption) WithNaming(naming *FieldNaming) *StructTagOption {
+	var opt StructTagOption
+	if o != nil {
+		opt = *o
+	}
+	opt.Naming = naming
+	return opt.normalize()
+}
+
+func (o *StructTagOption) normalize() *StructTagOption {
+	if len(o.TagKeys) == 0 && o.Naming == nil {
+		return nil
+	}
+	o.cacheKey = strings.Join(o.TagKeys, ",")
+	if o.Naming != nil {
+		o.cacheKey += ";" + o.Naming.cacheKey
+	}
+	return o
+}
+
+// CacheKey returns an identifier that distinguishes compiled code for this option from other options.
+func (o *StructTagOption) CacheKey() string {
+	if o == nil {
+		return ""
+	}
+	return o.cacheKey
+}
+
+func getTag(field reflect.StructField, opt *StructTagOption) string {
+	if opt == nil || len(opt.TagKeys) == 0 {
+		return field.Tag.Get(DefaultTagKey)
+	}
+	for _, key := range opt.TagKeys {
+		if tag, ok := field.Tag.Lookup(key); ok {
+			return tag
+		}
+	}
+	return ""
 }
 
-func IsIgnoredStructField(field reflect.StructField) bool {
+func IsIgnoredStructField(field reflect.StructField, opt *StructTagOption) bool {
 	if field.PkgPath != "" {
 		if field.Anonymous {
 			t := field.Type
@@ -25,7 +90,7 @@ func IsIgnoredStructField(field reflect.StructField) bool {
 			return true
 		}
 	}
-	tag := getTag(field)
+	tag := getTag(field, opt)
 	return tag == "-"
 }
 
@@ -35,6 +100,10 @@ type StructTag struct {
 	IsOmitEmpty bool
 	IsOmitZero  bool
 	IsString    bool
+	IsNonNil    bool
+	IsInline    bool
+	IsRedact    bool
+	Format      string
 	Field       reflect.StructField
 }
 
@@ -66,9 +135,9 @@ func isValidTag(s string) bool {
 	return true
 }
 
-func StructTagFromField(field reflect.StructField) *StructTag {
+func StructTagFromField(field reflect.StructField, opt *StructTagOption) *StructTag {
 	keyName := field.Name
-	tag := getTag(field)
+	tag := getTag(field, opt)
 	st := &StructTag{Field: field}
 	opts := strings.Split(tag, ",")
 	if len(opts) > 0 {
@@ -77,6 +146,9 @@ func StructTagFromField(field reflect.StructField) *StructTag {
 			st.IsTaggedKey = true
 		}
 	}
+	if !st.IsTaggedKey && opt != nil && opt.Naming != nil {
+		keyName = opt.Naming.Apply(field.Name)
+	}
 	st.Key = keyName
 	if len(opts) > 1 {
 		for _, opt := range opts[1:] {
@@ -87,8 +159,23 @@ func StructTagFromField(field reflect.StructField) *StructTag {
 				st.IsOmitZero = true
 			case "string":
 				st.IsString = true
+			case "nonnil":
+				st.IsNonNil = true
+			case "inline", "unknown":
+				st.IsInline = true
+			case "redact":
+				st.IsRedact = true
+			default:
+				if strings.HasPrefix(opt, "format:") {
+					st.Format = strings.TrimPrefix(opt, "format:")
+				}
 			}
 		}
 	}
 	return st
 }
+
+// IsInlineMapType reports whether typ can hold the object members collected by the inline tag option.
+func IsInlineMapType(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Map && typ.Key().Kind() == reflect.String
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 
+	"github.com/goccy/go-json/internal/decoder"
 	"github.com/goccy/go-json/internal/encoder"
 )
 
@@ -185,6 +186,31 @@ func MarshalWithOption(v interface{}, optFuncs ...EncodeOptionFunc) ([]byte, err
 	return marshal(v, optFuncs...)
 }
 
+// AppendMarshal appends the JSON encoding of v to dst and returns the extended buffer.
+// The encoding is written directly into dst, so reusing dst with enough capacity avoids allocating the result.
+// If an error occurs, dst is returned as is.
+func AppendMarshal(dst []byte, v interface{}, optFuncs ...EncodeOptionFunc) ([]byte, error) {
+	return appendMarshal(dst, v, optFuncs...)
+}
+
+// AppendMarshalIndent is like AppendMarshal but applies Indent to format the output.
+func AppendMarshalIndent(dst []byte, v interface{}, prefix, indent string, optFuncs ...EncodeOptionFunc) ([]byte, error) {
+	return appendMarshalIndent(dst, v, prefix, indent, optFuncs...)
+}
+
+// MarshalCanonical returns the canonical JSON encoding of v defined by RFC 8785 ( JSON Canonicalization Scheme ).
+// Object keys including struct field names are sorted by their UTF-16 code units,
+// numbers are formatted in the same way as ECMAScript, and strings are escaped minimally,
+// so the output is suitable for hashing and signing.
+// Numbers are represented as IEEE 754 double precision, so integers larger than 2^53 may lose precision.
+func MarshalCanonical(v interface{}) ([]byte, error) {
+	b, err := marshalNoEscape(v)
+	if err != nil {
+		return nil, err
+	}
+	return Canonicalize(b)
+}
+
 // MarshalIndent is like Marshal but applies Indent to format the output.
 // Each JSON element in the output will begin on a new line beginning with prefix
 // followed by one or more copies of indent according to the indentation nesting.
@@ -316,6 +342,12 @@ func Compact(dst *bytes.Buffer, src []byte) error {
 	return encoder.Compact(dst, src, false)
 }
 
+// Canonicalize returns the canonical form of the JSON-encoded src defined by RFC 8785 ( JSON Canonicalization Scheme ).
+// It returns an error if src is not valid I-JSON ( RFC 7493 ), for example if an object has duplicate keys.
+func Canonicalize(src []byte) ([]byte, error) {
+	return encoder.Canonicalize(nil, src)
+}
+
 // Indent appends to dst an indented form of the JSON-encoded src.
 // Each element in a JSON object or array begins on a new,
 // indented line beginning with prefix followed by one or more
@@ -350,16 +382,32 @@ func HTMLEscape(dst *bytes.Buffer, src []byte) {
 
 // Valid reports whether data is a valid JSON encoding.
 func Valid(data []byte) bool {
+	return ValidWithOption(data)
+}
+
+// ValidWithOption reports whether data is a valid JSON encoding with the decode options, such as AllowJSON5.
+func ValidWithOption(data []byte, optFuncs ...DecodeOptionFunc) bool {
+	var opt DecodeOption
+	for _, optFunc := range optFuncs {
+		optFunc(&opt)
+	}
+	if opt.Flags&decoder.JSON5Option != 0 {
+		// unmarshal translates the whole input and validates the end of it,
+		// so the JSON5 syntax after the first value is also validated.
+		// The capacity is limited so that the terminator is not written into data.
+		var v interface{}
+		return unmarshal(data[:len(data):len(data)], &v, optFuncs...) == nil
+	}
 	var v interface{}
-	decoder := NewDecoder(bytes.NewReader(data))
-	err := decoder.Decode(&v)
+	dec := NewDecoder(bytes.NewReader(data))
+	err := dec.DecodeWithOption(&v, optFuncs...)
 	if err != nil {
 		return false
 	}
-	if !decoder.More() {
+	if !dec.More() {
 		return true
 	}
-	return decoder.InputOffset() >= int64(len(data))
+	return dec.InputOffset() >= int64(len(data))
 }
 
 func init() {
@@ -0,0 +1,187 @@
+package json_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+const json5Config = `// server settings
+{
+  name: 'api "v2"',
+  $port: 0x1F90,
+  ratio: +.5,
+  retries: 3.,
+  limit: Infinity,
+  floor: -Infinity,
+  threshold: NaN,
+  hosts: [
+    'a.example.com',
+    "b.example.com", /* backup */
+  ],
+  motd: 'line1 \
+line2',
+  escapes: '\x41\v\0',
+}
+`
+
+type json5Settings struct {
+	Name      string   `json:"name"`
+	Port      int      `json:"$port"`
+	Ratio     float64  `json:"ratio"`
+	Retries   int      `json:"retries"`
+	Limit     float64  `json:"limit"`
+	Floor     float64  `json:"floor"`
+	Threshold float64  `json:"threshold"`
+	Hosts     []string `json:"hosts"`
+	Motd      string   `json:"motd"`
+	Escapes   string   `json:"escapes"`
+}
+
+func assertJSON5Settings(t *testing.T, v json5Settings) {
+	t.Helper()
+	assertEq(t, "name", `api "v2"`, v.Name)
+	assertEq(t, "port", 8080, v.Port)
+	assertEq(t, "ratio", 0.5, v.Ratio)
+	assertEq(t, "retries", 3, v.Retries)
+	assertEq(t, "limit", math.Inf(1), v.Limit)
+	assertEq(t, "floor", math.Inf(-1), v.Floor)
+	assertEq(t, "threshold", true, math.IsNaN(v.Threshold))
+	assertEq(t, "hosts", "a.example.com,b.example.com", strings.Join(v.Hosts, ","))
+	assertEq(t, "motd", "line1 line2", v.Motd)
+	assertEq(t, "escapes", "A\v\x00", v.Escapes)
+}
+
+func TestAllowJSON5(t *testing.T) {
+	t.Run("unmarshal", func(t *testing.T) {
+		var v json5Settings
+		assertErr(t, json.UnmarshalWithOption([]byte(json5Config), &v, json.AllowJSON5()))
+		assertJSON5Settings(t, v)
+
+		assertEq(t, "strict", true, json.Unmarshal([]byte(json5Config), &v) != nil)
+	})
+	t.Run("interface", func(t *testing.T) {
+		var v map[string]interface{}
+		assertErr(t, json.UnmarshalWithOption([]byte(json5Config), &v, json.AllowJSON5()))
+		assertEq(t, "port", float64(8080), v["$port"])
+		assertEq(t, "limit", math.Inf(1), v["limit"])
+		assertEq(t, "floor", math.Inf(-1), v["floor"])
+		assertEq(t, "threshold", true, math.IsNaN(v["threshold"].(float64)))
+		assertEq(t, "hosts", 2, len(v["hosts"].([]interface{})))
+
+		var n struct {
+			Limit json.Number `json:"limit"`
+			Hex   json.Number `json:"hex"`
+		}
+		assertErr(t, json.UnmarshalWithOption([]byte(`{limit: -Infinity, hex: -0xff}`), &n, json.AllowJSON5()))
+		assertEq(t, "number", json.Number("-Infinity"), n.Limit)
+		assertEq(t, "hex number", json.Number("-255"), n.Hex)
+	})
+	t.Run("raw", func(t *testing.T) {
+		var v struct {
+			Raw json.RawMessage `json:"raw"`
+		}
+		assertErr(t, json.UnmarshalWithOption([]byte(`{raw: [NaN, 'a', -Infinity]}`), &v, json.AllowJSON5()))
+		assertEq(t, "raw", `[NaN,"a",-Infinity]`, string(v.Raw))
+
+		dec := json.NewDecoder(strings.NewReader(`{a: 1}`))
+		_, err := dec.Token()
+		assertErr(t, err)
+		if _, err := dec.Token(); err == nil {
+			t.Fatal("expected error for unquoted key in Token")
+		}
+	})
+	t.Run("skip", func(t *testing.T) {
+		var v struct {
+			A int `json:"a"`
+		}
+		assertErr(t, json.UnmarshalWithOption([]byte(`{x: NaN, y: -Infinity, z: [Infinity], a: 1,}`), &v, json.AllowJSON5()))
+		assertEq(t, "a", 1, v.A)
+	})
+	t.Run("stream", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader(json5Config + json5Config))
+		for i := 0; i < 2; i++ {
+			var v json5Settings
+			assertErr(t, dec.DecodeWithOption(&v, json.AllowJSON5()))
+			assertJSON5Settings(t, v)
+		}
+
+		dec = json.NewDecoder(strings.NewReader(`{"a": 1} {b: NaN, a: 2,} [1, -Infinity]`))
+		var v map[string]interface{}
+		assertErr(t, dec.Decode(&v))
+		assertEq(t, "strict value", float64(1), v["a"])
+		dec.UseNumber()
+		v = nil
+		assertErr(t, dec.DecodeWithOption(&v, json.AllowJSON5()))
+		assertEq(t, "json5 value", json.Number("2"), v["a"])
+		assertEq(t, "nan", json.Number("NaN"), v["b"])
+
+		var tokens []interface{}
+		for {
+			token, err := dec.Token()
+			if err != nil {
+				break
+			}
+			tokens = append(tokens, token)
+		}
+		assertEq(t, "tokens", 4, len(tokens))
+		assertEq(t, "token", json.Number("-Infinity"), tokens[2])
+	})
+	t.Run("valid", func(t *testing.T) {
+		assertEq(t, "json5", true, json.ValidWithOption([]byte(json5Config), json.AllowJSON5()))
+		assertEq(t, "strict", false, json.Valid([]byte(json5Config)))
+		assertEq(t, "json", true, json.ValidWithOption([]byte(`{"a": [1, 2]}`), json.AllowJSON5()))
+		assertEq(t, "multiple values", false, json.ValidWithOption([]byte(`{a: 1} 2`), json.AllowJSON5()))
+		for _, src := range []string{`{a: 1} }`, `{a: 1} /* unterminated`, `{a: 1} 'x`, `{a: 1} ]`} {
+			assertEq(t, src, false, json.ValidWithOption([]byte(src), json.AllowJSON5()))
+			var v interface{}
+			assertEq(t, src, true, json.UnmarshalWithOption([]byte(src), &v, json.AllowJSON5()) != nil)
+		}
+		assertEq(t, "trailing comment", true, json.ValidWithOption([]byte("{a: 1} // end\n"), json.AllowJSON5()))
+	})
+	t.Run("path", func(t *testing.T) {
+		path, err := json.CreatePath("$.hosts[1]")
+		assertErr(t, err)
+		var hosts []string
+		assertErr(t, path.Unmarshal([]byte(json5Config), &hosts, json.AllowJSON5()))
+		assertEq(t, "hosts", "b.example.com", strings.Join(hosts, ","))
+
+		path, err = json.CreatePath("$.floor")
+		assertErr(t, err)
+		contents, err := path.Extract([]byte(json5Config), json.AllowJSON5())
+		assertErr(t, err)
+		assertEq(t, "contents", 1, len(contents))
+		assertEq(t, "content", "-Infinity", strings.TrimSpace(string(contents[0])))
+		var floors []float64
+		assertErr(t, path.Unmarshal([]byte(json5Config), &floors, json.AllowJSON5()))
+		assertEq(t, "floor", math.Inf(-1), floors[0])
+	})
+	t.Run("error", func(t *testing.T) {
+		for _, src := range []string{
+			`[1,,]`,
+			`{,}`,
+			`{a: undefined}`,
+			`'unterminated`,
+			"'line\nbreak'",
+			`/* unterminated`,
+			`'\1'`,
+			`0x`,
+			`{a: 1} 2`,
+		} {
+			var v interface{}
+			if err := json.UnmarshalWithOption([]byte(src), &v, json.AllowJSON5()); err == nil {
+				t.Errorf("expected error for %q", src)
+			}
+		}
+
+		dec := json.NewDecoder(strings.NewReader(`{a: 1} {b: 'x' /* unterminated`))
+		var v interface{}
+		assertErr(t, dec.DecodeWithOption(&v, json.AllowJSON5()))
+		err := dec.DecodeWithOption(&v, json.AllowJSON5())
+		if err == nil || !strings.Contains(err.Error(), "comment") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
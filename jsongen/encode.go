@@ -0,0 +1,134 @@
+// Package jsongen provides the runtime support for the code generated by go-json-gen.
+//
+// The generated code encodes and decodes the values in the same way as Marshal and Unmarshal of go-json,
+// and falls back to them for the values that cannot be handled statically.
+// The functions of this package are not intended to be called directly.
+package jsongen
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"reflect"
+	"sort"
+
+	"github.com/goccy/go-json"
+	"github.com/goccy/go-json/internal/encoder"
+)
+
+// encodeContext has the options of Marshal. The functions of the encoder package that take it only read the flags.
+var encodeContext = &encoder.RuntimeContext{
+	Option: &encoder.Option{
+		Flag: encoder.HTMLEscapeOption | encoder.NormalizeUTF8Option,
+	},
+}
+
+// AppendMarshaler is the interface implemented by the types with the methods generated by go-json-gen.
+// AppendJSON appends the JSON encoding of the value to b, keeping the fields selected by query.
+// The nil query keeps all fields.
+type AppendMarshaler interface {
+	AppendJSON(b []byte, query *json.FieldQuery) ([]byte, error)
+}
+
+// Marshal returns the JSON encoding of v in the same way as json.Marshal.
+func Marshal(v AppendMarshaler) ([]byte, error) {
+	return v.AppendJSON(make([]byte, 0, 128), nil)
+}
+
+// AppendString appends s as a JSON string escaped in the same way as json.Marshal.
+func AppendString(b []byte, s string) []byte {
+	return encoder.AppendString(encodeContext, b, s)
+}
+
+// AppendQuotedString appends the JSON string s as a JSON string, as the string option of the struct tag does.
+func AppendQuotedString(b []byte, s string) []byte {
+	return AppendString(b, string(AppendString(nil, s)))
+}
+
+// AppendBytes appends src as a base64 encoded JSON string, or null if src is nil.
+func AppendBytes(b []byte, src []byte) []byte {
+	return encoder.AppendByteSlice(encodeContext, b, src)
+}
+
+// AppendFloat32 appends v as a JSON number. NaN and infinities are reported as *json.UnsupportedValueError.
+func AppendFloat32(b []byte, v float32) ([]byte, error) {
+	f64 := float64(v)
+	if math.IsInf(f64, 0) || math.IsNaN(f64) {
+		return b, encoder.ErrUnsupportedFloat(f64)
+	}
+	return encoder.AppendFloat32(encodeContext, b, v), nil
+}
+
+// AppendFloat64 appends v as a JSON number. NaN and infinities are reported as *json.UnsupportedValueError.
+func AppendFloat64(b []byte, v float64) ([]byte, error) {
+	if math.IsInf(v, 0) || math.IsNaN(v) {
+		return b, encoder.ErrUnsupportedFloat(v)
+	}
+	return encoder.AppendFloat64(encodeContext, b, v), nil
+}
+
+// AppendValue appends the JSON encoding of v by the runtime encoder, keeping the fields selected by query.
+// It is used for the values whose encoding is not generated, such as interfaces and the types implementing json.Marshaler.
+func AppendValue(b []byte, v interface{}, query *json.FieldQuery) ([]byte, error) {
+	if query == nil {
+		return json.AppendMarshal(b, v)
+	}
+	encoded, err := json.MarshalContext(json.SetFieldQueryToContext(context.Background(), query), v)
+	if err != nil {
+		return b, err
+	}
+	return append(b, encoded...), nil
+}
+
+// MapKey is the key of the map with its JSON encoding.
+type MapKey struct {
+	Key     string
+	encoded []byte
+}
+
+type mapKeys []MapKey
+
+func (k mapKeys) Len() int           { return len(k) }
+func (k mapKeys) Less(i, j int) bool { return bytes.Compare(k[i].encoded, k[j].encoded) < 0 }
+func (k mapKeys) Swap(i, j int)      { k[i], k[j] = k[j], k[i] }
+
+// SortMapKeys encodes keys and sorts them in the same order as the runtime encoder, which compares the encoded keys.
+func SortMapKeys(keys []string) []MapKey {
+	sorted := make(mapKeys, len(keys))
+	for i, key := range keys {
+		sorted[i] = MapKey{Key: key, encoded: AppendString(nil, key)}
+	}
+	sort.Sort(sorted)
+	return sorted
+}
+
+// AppendMapKey appends the encoded key of the map to b. The key is followed by a colon.
+func AppendMapKey(b []byte, key MapKey) []byte {
+	return append(append(b, key.encoded...), ':')
+}
+
+// EndObject replaces the comma after the last member with the end of the JSON object,
+// or appends it if the object has no members.
+func EndObject(b []byte) []byte {
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = '}'
+		return b
+	}
+	return append(b, '}')
+}
+
+// EndArray replaces the comma after the last element with the end of the JSON array,
+// or appends it if the array has no elements.
+func EndArray(b []byte) []byte {
+	if b[len(b)-1] == ',' {
+		b[len(b)-1] = ']'
+		return b
+	}
+	return append(b, ']')
+}
+
+// IsZero reports whether v is the zero value of its type.
+// It is used by the omitzero option for the types that are not comparable and have no IsZero method.
+func IsZero(v interface{}) bool {
+	return reflect.ValueOf(v).IsZero()
+}
@@ -0,0 +1,604 @@
+package jsongen
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/goccy/go-json"
+	"github.com/goccy/go-json/internal/errors"
+)
+
+// Lexer reads the JSON values for the code generated by go-json-gen.
+//
+// The methods do nothing once an error occurs, so the generated code checks the error only at the end by Error.
+type Lexer struct {
+	data   []byte
+	cursor int
+	comma  bool // the last element of the array or object is followed by a comma
+	err    error
+}
+
+// NewLexer returns a new lexer that reads data.
+func NewLexer(data []byte) *Lexer {
+	return &Lexer{data: data}
+}
+
+// Error returns the first error that occurred while reading.
+func (l *Lexer) Error() error {
+	return l.err
+}
+
+// SetError records err if no error has occurred yet.
+func (l *Lexer) SetError(err error) {
+	if l.err == nil && err != nil {
+		l.err = err
+	}
+}
+
+// End reports a syntax error if any data other than white spaces remains.
+func (l *Lexer) End() {
+	if l.err != nil {
+		return
+	}
+	if c := l.skipWhiteSpace(); c != 0 || l.cursor < len(l.data) {
+		l.errInvalidCharacter(c, "after top-level value")
+	}
+}
+
+// IsNull consumes null and reports true if the next value is null.
+func (l *Lexer) IsNull() bool {
+	if l.err != nil {
+		return false
+	}
+	if l.skipWhiteSpace() != 'n' {
+		return false
+	}
+	l.literal("null")
+	return l.err == nil
+}
+
+// BeginObject consumes the beginning of a JSON object.
+// The other values are reported as the type error for the type of the value pointed to by ptr.
+func (l *Lexer) BeginObject(ptr interface{}) {
+	l.begin('{', ptr)
+}
+
+// EndObject consumes the end of a JSON object.
+func (l *Lexer) EndObject() {
+	l.delim('}')
+}
+
+// BeginArray consumes the beginning of a JSON array.
+// The other values are reported as the type error for the type of the value pointed to by ptr.
+func (l *Lexer) BeginArray(ptr interface{}) {
+	l.begin('[', ptr)
+}
+
+// EndArray consumes the end of a JSON array.
+func (l *Lexer) EndArray() {
+	l.delim(']')
+}
+
+func (l *Lexer) begin(c byte, ptr interface{}) {
+	if l.err != nil {
+		return
+	}
+	if got := l.skipWhiteSpace(); got != c {
+		l.errType(got, reflect.TypeOf(ptr).Elem())
+		return
+	}
+	l.cursor++
+	l.comma = false
+}
+
+func (l *Lexer) delim(c byte) {
+	if l.err != nil {
+		return
+	}
+	if got := l.skipWhiteSpace(); got != c {
+		l.errInvalidCharacter(got, "looking for beginning of value")
+		return
+	}
+	l.cursor++
+	l.comma = false
+}
+
+// More reports whether the current array or object has the next element.
+func (l *Lexer) More() bool {
+	if l.err != nil {
+		return false
+	}
+	switch c := l.skipWhiteSpace(); c {
+	case ']', '}':
+		if l.comma {
+			l.errInvalidCharacter(c, "looking for beginning of value")
+		}
+		return false
+	case 0:
+		if l.cursor >= len(l.data) {
+			l.err = errors.ErrUnexpectedEndOfJSON("array or object", int64(l.cursor))
+			return false
+		}
+	}
+	return true
+}
+
+// WantComma consumes the comma after the element of the current array or object.
+func (l *Lexer) WantComma() {
+	if l.err != nil {
+		return
+	}
+	switch c := l.skipWhiteSpace(); c {
+	case ',':
+		l.cursor++
+		l.comma = true
+	case ']', '}':
+		l.comma = false
+	default:
+		l.errInvalidCharacter(c, "after array element or object value")
+	}
+}
+
+// Key reads the key of the object member and the following colon.
+// The returned bytes are valid until the next call of the methods.
+func (l *Lexer) Key() []byte {
+	if l.err != nil {
+		return nil
+	}
+	if c := l.skipWhiteSpace(); c != '"' {
+		l.errInvalidCharacter(c, "looking for beginning of object key string")
+		return nil
+	}
+	key := l.unquote()
+	if l.err != nil {
+		return nil
+	}
+	if c := l.skipWhiteSpace(); c != ':' {
+		l.errInvalidCharacter(c, "after object key")
+		return nil
+	}
+	l.cursor++
+	return key
+}
+
+// String reads a JSON string.
+func (l *Lexer) String() string {
+	if l.err != nil {
+		return ""
+	}
+	if c := l.skipWhiteSpace(); c != '"' {
+		l.errType(c, reflect.TypeOf(""))
+		return ""
+	}
+	return string(l.unquote())
+}
+
+// Bool reads true or false.
+func (l *Lexer) Bool() bool {
+	if l.err != nil {
+		return false
+	}
+	switch c := l.skipWhiteSpace(); c {
+	case 't':
+		l.literal("true")
+		return l.err == nil
+	case 'f':
+		l.literal("false")
+	default:
+		l.errType(c, reflect.TypeOf(false))
+	}
+	return false
+}
+
+var (
+	intTypes   = map[int]reflect.Type{0: reflect.TypeOf(int(0)), 8: reflect.TypeOf(int8(0)), 16: reflect.TypeOf(int16(0)), 32: reflect.TypeOf(int32(0)), 64: reflect.TypeOf(int64(0))}
+	uintTypes  = map[int]reflect.Type{0: reflect.TypeOf(uint(0)), 8: reflect.TypeOf(uint8(0)), 16: reflect.TypeOf(uint16(0)), 32: reflect.TypeOf(uint32(0)), 64: reflect.TypeOf(uint64(0))}
+	floatTypes = map[int]reflect.Type{32: reflect.TypeOf(float32(0)), 64: reflect.TypeOf(float64(0))}
+)
+
+// Int reads a JSON number as a signed integer of bitSize bits. The bitSize 0 means int.
+func (l *Lexer) Int(bitSize int) int64 {
+	num, ok := l.number(intTypes[bitSize])
+	if !ok {
+		return 0
+	}
+	v, err := strconv.ParseInt(num, 10, bitSize)
+	if err != nil {
+		l.errNumber(num, intTypes[bitSize])
+	}
+	return v
+}
+
+// Uint reads a JSON number as an unsigned integer of bitSize bits. The bitSize 0 means uint.
+func (l *Lexer) Uint(bitSize int) uint64 {
+	num, ok := l.number(uintTypes[bitSize])
+	if !ok {
+		return 0
+	}
+	v, err := strconv.ParseUint(num, 10, bitSize)
+	if err != nil {
+		l.errNumber(num, uintTypes[bitSize])
+	}
+	return v
+}
+
+// Float reads a JSON number as a floating-point number of bitSize bits.
+func (l *Lexer) Float(bitSize int) float64 {
+	num, ok := l.number(floatTypes[bitSize])
+	if !ok {
+		return 0
+	}
+	v, err := strconv.ParseFloat(num, bitSize)
+	if err != nil {
+		l.errNumber(num, floatTypes[bitSize])
+	}
+	return v
+}
+
+// Bytes reads a base64 encoded JSON string.
+func (l *Lexer) Bytes() []byte {
+	if l.err != nil {
+		return nil
+	}
+	if c := l.skipWhiteSpace(); c != '"' {
+		l.errType(c, reflect.TypeOf([]byte(nil)))
+		return nil
+	}
+	src := l.unquote()
+	if l.err != nil {
+		return nil
+	}
+	b := make([]byte, base64.StdEncoding.DecodedLen(len(src)))
+	n, err := base64.StdEncoding.Decode(b, src)
+	if err != nil {
+		l.err = err
+		return nil
+	}
+	return b[:n]
+}
+
+// Quoted reads a JSON string that holds a JSON value, as the string option of the struct tag does,
+// and returns the lexer that reads the held value. The lexer must be finished by EndQuoted.
+func (l *Lexer) Quoted() *Lexer {
+	if l.err != nil {
+		return &Lexer{err: l.err}
+	}
+	if c := l.skipWhiteSpace(); c != '"' {
+		l.errType(c, reflect.TypeOf(""))
+		return &Lexer{err: l.err}
+	}
+	return &Lexer{data: l.unquote()}
+}
+
+// EndQuoted finishes the lexer returned by Quoted.
+func (l *Lexer) EndQuoted(quoted *Lexer) {
+	quoted.End()
+	if l.err == nil && quoted.err != nil {
+		l.err = fmt.Errorf("json: invalid use of ,string struct tag, trying to unmarshal %q: %w", quoted.data, quoted.err)
+	}
+}
+
+// Skip reads the next value and discards it.
+func (l *Lexer) Skip() {
+	if l.err != nil {
+		return
+	}
+	switch c := l.skipWhiteSpace(); c {
+	case '{':
+		l.delim('{')
+		for l.More() {
+			l.Key()
+			l.Skip()
+			l.WantComma()
+		}
+		l.delim('}')
+	case '[':
+		l.delim('[')
+		for l.More() {
+			l.Skip()
+			l.WantComma()
+		}
+		l.delim(']')
+	case '"':
+		l.unquote()
+	case 't':
+		l.literal("true")
+	case 'f':
+		l.literal("false")
+	case 'n':
+		l.literal("null")
+	default:
+		l.number(nil)
+	}
+}
+
+// Raw reads the next value and returns its bytes.
+func (l *Lexer) Raw() []byte {
+	if l.err != nil {
+		return nil
+	}
+	l.skipWhiteSpace()
+	start := l.cursor
+	l.Skip()
+	if l.err != nil {
+		return nil
+	}
+	return l.data[start:l.cursor]
+}
+
+// Unmarshal reads the next value and decodes it into v by the runtime decoder.
+// It is used for the values whose decoding is not generated.
+func (l *Lexer) Unmarshal(v interface{}) {
+	offset := l.cursor
+	raw := l.Raw()
+	if l.err != nil {
+		return
+	}
+	// the capacity is limited since Unmarshal terminates the data with nul in the spare capacity.
+	if err := json.Unmarshal(raw[:len(raw):len(raw)], v); err != nil {
+		if typeErr, ok := err.(*errors.UnmarshalTypeError); ok {
+			typeErr.Offset += int64(offset)
+		}
+		l.err = err
+	}
+}
+
+// FieldIndex returns the index of the field whose name matches key, or -1 if there is no such field.
+// As well as Unmarshal, the exact match is preferred and the name is otherwise matched case-insensitively.
+func FieldIndex(key []byte, names []string) int {
+	for i, name := range names {
+		if string(key) == name {
+			return i
+		}
+	}
+	s := string(key)
+	for i, name := range names {
+		if strings.EqualFold(s, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (l *Lexer) skipWhiteSpace() byte {
+	for l.cursor < len(l.data) {
+		switch c := l.data[l.cursor]; c {
+		case ' ', '\n', '\t', '\r':
+			l.cursor++
+		default:
+			return c
+		}
+	}
+	return 0
+}
+
+func (l *Lexer) literal(lit string) {
+	if len(l.data)-l.cursor < len(lit) {
+		l.err = errors.ErrUnexpectedEndOfJSON(lit, int64(len(l.data)))
+		return
+	}
+	for i := 0; i < len(lit); i++ {
+		if l.data[l.cursor+i] != lit[i] {
+			l.errInvalidCharacter(l.data[l.cursor+i], "in literal "+lit+" (expecting '"+lit[i:i+1]+"')")
+			return
+		}
+	}
+	l.cursor += len(lit)
+}
+
+// number reads a JSON number. If typ is not nil, the other values are reported as the type error for typ.
+func (l *Lexer) number(typ reflect.Type) (string, bool) {
+	if l.err != nil {
+		return "", false
+	}
+	c := l.skipWhiteSpace()
+	if typ != nil && c != '-' && (c < '0' || '9' < c) {
+		l.errType(c, typ)
+		return "", false
+	}
+	start := l.cursor
+	if c == '-' {
+		l.cursor++
+	}
+	switch c := l.char(); {
+	case c == '0':
+		l.cursor++
+	case '1' <= c && c <= '9':
+		l.digits()
+	default:
+		l.errInvalidCharacter(c, "in numeric literal")
+		return "", false
+	}
+	if l.char() == '.' {
+		l.cursor++
+		if c := l.char(); c < '0' || '9' < c {
+			l.errInvalidCharacter(c, "after decimal point in numeric literal")
+			return "", false
+		}
+		l.digits()
+	}
+	if c := l.char(); c == 'e' || c == 'E' {
+		l.cursor++
+		if c := l.char(); c == '+' || c == '-' {
+			l.cursor++
+		}
+		if c := l.char(); c < '0' || '9' < c {
+			l.errInvalidCharacter(c, "in exponent of numeric literal")
+			return "", false
+		}
+		l.digits()
+	}
+	return string(l.data[start:l.cursor]), true
+}
+
+func (l *Lexer) digits() {
+	for c := l.char(); '0' <= c && c <= '9'; c = l.char() {
+		l.cursor++
+	}
+}
+
+func (l *Lexer) char() byte {
+	if l.cursor < len(l.data) {
+		return l.data[l.cursor]
+	}
+	return 0
+}
+
+// unquote reads the JSON string at the cursor and returns its value.
+// Invalid UTF-8 and unpaired surrogates are replaced with U+FFFD as Unmarshal does.
+func (l *Lexer) unquote() []byte {
+	l.cursor++
+	start := l.cursor
+	for l.cursor < len(l.data) {
+		c := l.data[l.cursor]
+		if c == '"' {
+			l.cursor++
+			return l.data[start : l.cursor-1]
+		}
+		if c == '\\' || c < 0x20 || c >= utf8.RuneSelf {
+			break
+		}
+		l.cursor++
+	}
+	buf := make([]byte, l.cursor-start, l.cursor-start+16)
+	copy(buf, l.data[start:l.cursor])
+	for l.cursor < len(l.data) {
+		switch c := l.data[l.cursor]; {
+		case c == '"':
+			l.cursor++
+			return buf
+		case c == '\\':
+			r, ok := l.escape()
+			if !ok {
+				return nil
+			}
+			buf = utf8.AppendRune(buf, r)
+		case c < 0x20:
+			l.errInvalidCharacter(c, "in string literal")
+			return nil
+		case c < utf8.RuneSelf:
+			buf = append(buf, c)
+			l.cursor++
+		default:
+			r, size := utf8.DecodeRune(l.data[l.cursor:])
+			buf = utf8.AppendRune(buf, r)
+			l.cursor += size
+		}
+	}
+	l.err = errors.ErrUnexpectedEndOfJSON("string", int64(l.cursor))
+	return nil
+}
+
+// escape reads the escape sequence at the cursor.
+func (l *Lexer) escape() (rune, bool) {
+	l.cursor++
+	c := l.char()
+	l.cursor++
+	switch c {
+	case '"', '\\', '/':
+		return rune(c), true
+	case 'b':
+		return '\b', true
+	case 'f':
+		return '\f', true
+	case 'n':
+		return '\n', true
+	case 'r':
+		return '\r', true
+	case 't':
+		return '\t', true
+	case 'u':
+		r, ok := l.hex4()
+		if !ok {
+			return 0, false
+		}
+		if !utf16.IsSurrogate(r) {
+			return r, true
+		}
+		if len(l.data)-l.cursor >= 6 && l.data[l.cursor] == '\\' && l.data[l.cursor+1] == 'u' {
+			cursor := l.cursor
+			l.cursor += 2
+			r2, ok := l.hex4()
+			if !ok {
+				return 0, false
+			}
+			if combined := utf16.DecodeRune(r, r2); combined != utf8.RuneError {
+				return combined, true
+			}
+			l.cursor = cursor
+		}
+		return utf8.RuneError, true
+	}
+	l.cursor--
+	l.errInvalidCharacter(c, "in string escape code")
+	return 0, false
+}
+
+func (l *Lexer) hex4() (rune, bool) {
+	if len(l.data)-l.cursor < 4 {
+		l.err = errors.ErrUnexpectedEndOfJSON("string", int64(len(l.data)))
+		return 0, false
+	}
+	var r rune
+	for i := 0; i < 4; i++ {
+		c := l.data[l.cursor]
+		switch {
+		case '0' <= c && c <= '9':
+			c -= '0'
+		case 'a' <= c && c <= 'f':
+			c = c - 'a' + 10
+		case 'A' <= c && c <= 'F':
+			c = c - 'A' + 10
+		default:
+			l.errInvalidCharacter(c, "in \\u hexadecimal character escape")
+			return 0, false
+		}
+		r = r*16 + rune(c)
+		l.cursor++
+	}
+	return r, true
+}
+
+func (l *Lexer) errInvalidCharacter(c byte, context string) {
+	if c == 0 && l.cursor >= len(l.data) {
+		l.err = errors.ErrUnexpectedEndOfJSON(context, int64(l.cursor))
+		return
+	}
+	l.err = errors.ErrInvalidCharacter(c, context, int64(l.cursor))
+}
+
+// errType reports the value starting with c as the type error for typ.
+func (l *Lexer) errType(c byte, typ reflect.Type) {
+	var value string
+	switch c {
+	case '{':
+		value = "object"
+	case '[':
+		value = "array"
+	case '"':
+		value = "string"
+	case 't', 'f':
+		value = "bool"
+	case 'n':
+		value = "null"
+	default:
+		if c == '-' || ('0' <= c && c <= '9') {
+			value = "number"
+			break
+		}
+		l.errInvalidCharacter(c, "looking for beginning of value")
+		return
+	}
+	offset := l.cursor
+	l.Skip()
+	l.err = &errors.UnmarshalTypeError{Value: value, Type: typ, Offset: int64(offset)}
+}
+
+func (l *Lexer) errNumber(num string, typ reflect.Type) {
+	l.err = &errors.UnmarshalTypeError{Value: "number " + num, Type: typ, Offset: int64(l.cursor)}
+}
@@ -0,0 +1,248 @@
+package json
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"runtime"
+	"sync"
+
+	"github.com/goccy/go-json/internal/encoder"
+)
+
+var (
+	errLineDecoderClosed = errors.New("json: Decode called after Close")
+	errNewlineInRecord   = errors.New("json: encoded record contains a newline")
+)
+
+// A LineDecoder reads the records of JSON Lines ( also known as NDJSON ), which are JSON values separated by newlines,
+// from an input stream and decodes them into values of type T in parallel.
+//
+// The records are read sequentially and unmarshaled by a pool of goroutines, each into the zero value of T.
+// Decode returns them in the order of the lines by default, or in the order in which they are decoded after SetOrdered(false).
+// The lines consisting only of white space are skipped. Each record must fit in a line,
+// so the values spanning multiple lines are reported as the errors of their lines.
+type LineDecoder[T any] struct {
+	r        *bufio.Reader
+	optFuncs []DecodeOptionFunc
+	workers  int
+	ordered  bool
+
+	startOnce sync.Once
+	closeOnce sync.Once
+	quit      chan struct{}
+	jobs      chan *lineRecord[T]
+	records   chan *lineRecord[T] // in the order of the lines if ordered, otherwise in the order of completion
+	err       error               // error reading the input, visible after records is closed
+}
+
+type lineRecord[T any] struct {
+	line int
+	data []byte
+	v    T
+	err  error
+	done chan struct{} // closed when v or err is set, used only if ordered
+}
+
+// NewLineDecoder returns a new LineDecoder that reads from r and unmarshals the records with optFuncs.
+//
+// The decoder introduces its own buffering and may read data from r beyond the records requested.
+func NewLineDecoder[T any](r io.Reader, optFuncs ...DecodeOptionFunc) *LineDecoder[T] {
+	return &LineDecoder[T]{
+		r:        bufio.NewReader(r),
+		optFuncs: optFuncs,
+		workers:  runtime.GOMAXPROCS(0),
+		ordered:  true,
+		quit:     make(chan struct{}),
+	}
+}
+
+// SetWorkers sets the number of goroutines that unmarshal the records. The default is GOMAXPROCS.
+// It has no effect after the first call to Decode.
+func (d *LineDecoder[T]) SetWorkers(n int) {
+	if n < 1 {
+		n = 1
+	}
+	d.workers = n
+}
+
+// SetOrdered specifies whether Decode returns the records in the order of the lines.
+// Returning them in the order in which they are decoded avoids waiting for the slow records,
+// and the line numbers returned by Decode tell where they came from.
+// It has no effect after the first call to Decode.
+func (d *LineDecoder[T]) SetOrdered(ordered bool) {
+	d.ordered = ordered
+}
+
+// Decode stores the next record in the value pointed to by v and returns its 1-based line number.
+//
+// If the record can not be unmarshaled, Decode returns a *LineError and leaves v unchanged,
+// and the following records can still be decoded. At the end of the input, Decode returns io.EOF.
+// If reading the input fails, Decode returns the error after the records read before it.
+func (d *LineDecoder[T]) Decode(v *T) (int, error) {
+	d.startOnce.Do(d.start)
+	select {
+	case <-d.quit:
+		return 0, errLineDecoderClosed
+	default:
+	}
+	rec, ok := <-d.records
+	if !ok {
+		return 0, d.err
+	}
+	if d.ordered {
+		<-rec.done
+	}
+	if rec.err != nil {
+		return rec.line, &LineError{Line: rec.line, Err: rec.err}
+	}
+	*v = rec.v
+	return rec.line, nil
+}
+
+// Close stops the goroutines of the decoder without reading the rest of the input.
+// A goroutine blocked in reading the input exits when the read returns.
+// Decode must not be called after Close.
+func (d *LineDecoder[T]) Close() error {
+	d.closeOnce.Do(func() { close(d.quit) })
+	return nil
+}
+
+func (d *LineDecoder[T]) start() {
+	d.jobs = make(chan *lineRecord[T], 2*d.workers)
+	d.records = make(chan *lineRecord[T], 2*d.workers)
+
+	var wg sync.WaitGroup
+	wg.Add(d.workers)
+	for i := 0; i < d.workers; i++ {
+		go func() {
+			defer wg.Done()
+			d.work()
+		}()
+	}
+	go d.read()
+	if !d.ordered {
+		go func() {
+			wg.Wait()
+			close(d.records)
+		}()
+	}
+}
+
+// read splits the input into the records and passes them to the workers.
+func (d *LineDecoder[T]) read() {
+	defer close(d.jobs)
+	if d.ordered {
+		defer close(d.records)
+	}
+	for line := 1; ; line++ {
+		data, err := d.r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			d.err = err
+			return
+		}
+		if !isBlankLine(data) {
+			rec := &lineRecord[T]{line: line, data: bytes.TrimSuffix(data, []byte{'\n'})}
+			if d.ordered {
+				rec.done = make(chan struct{})
+				select {
+				case d.records <- rec:
+				case <-d.quit:
+					return
+				}
+			}
+			select {
+			case d.jobs <- rec:
+			case <-d.quit:
+				return
+			}
+		}
+		if err == io.EOF {
+			d.err = io.EOF
+			return
+		}
+	}
+}
+
+func (d *LineDecoder[T]) work() {
+	for rec := range d.jobs {
+		rec.err = unmarshal(rec.data, &rec.v, d.optFuncs...)
+		rec.data = nil
+		if d.ordered {
+			close(rec.done)
+			continue
+		}
+		select {
+		case d.records <- rec:
+		case <-d.quit:
+			return
+		}
+	}
+}
+
+func isBlankLine(data []byte) bool {
+	for _, c := range data {
+		switch c {
+		case ' ', '\t', '\r', '\n':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// A LineEncoder writes values to an output stream as JSON Lines ( also known as NDJSON ),
+// that is, each value is written as a compact JSON text followed by a newline.
+type LineEncoder struct {
+	enc  *Encoder
+	line int
+}
+
+// NewLineEncoder returns a new LineEncoder that writes to w.
+func NewLineEncoder(w io.Writer) *LineEncoder {
+	return &LineEncoder{enc: NewEncoder(w)}
+}
+
+// Encode writes the JSON encoding of v to the stream as a line.
+//
+// If v can not be encoded, Encode returns a *LineError with the line number the record would have had
+// and writes nothing, so the following values can still be written. Errors writing to the stream are returned as they are.
+func (e *LineEncoder) Encode(v interface{}) error {
+	return e.EncodeWithOption(v)
+}
+
+// EncodeWithOption call Encode with EncodeOption.
+func (e *LineEncoder) EncodeWithOption(v interface{}, optFuncs ...EncodeOptionFunc) error {
+	ctx := encoder.TakeRuntimeContext()
+	ctx.Option.Flag = 0
+
+	err := e.encodeWithOption(ctx, v, optFuncs...)
+
+	encoder.ReleaseRuntimeContext(ctx)
+	return err
+}
+
+func (e *LineEncoder) encodeWithOption(ctx *encoder.RuntimeContext, v interface{}, optFuncs ...EncodeOptionFunc) error {
+	line := e.line + 1
+	buf, err := e.enc.encodeValue(ctx, v, "", optFuncs...)
+	if err != nil {
+		return &LineError{Line: line, Err: err}
+	}
+	// the newlines in the strings are escaped, but the formats of Colorize may contain them.
+	if bytes.IndexByte(buf, '\n') >= 0 {
+		return &LineError{Line: line, Err: errNewlineInRecord}
+	}
+	buf = append(buf, '\n')
+	if _, err := e.enc.w.Write(buf); err != nil {
+		return err
+	}
+	e.line = line
+	return nil
+}
+
+// SetEscapeHTML specifies whether problematic HTML characters should be escaped inside JSON quoted strings
+// in the same way as Encoder.SetEscapeHTML.
+func (e *LineEncoder) SetEscapeHTML(on bool) {
+	e.enc.SetEscapeHTML(on)
+}
@@ -0,0 +1,194 @@
+package json_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+type lineRecord struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type errReader struct {
+	r   io.Reader
+	err error
+}
+
+func (r *errReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err == io.EOF {
+		return n, r.err
+	}
+	return n, err
+}
+
+func TestLineDecoder(t *testing.T) {
+	var src strings.Builder
+	for i := 1; i <= 1000; i++ {
+		fmt.Fprintf(&src, "{\"id\":%d,\"name\":\"rec%d\"}\n", i, i)
+	}
+
+	t.Run("ordered", func(t *testing.T) {
+		dec := json.NewLineDecoder[lineRecord](strings.NewReader(src.String()))
+		dec.SetWorkers(4)
+		for i := 1; i <= 1000; i++ {
+			var v lineRecord
+			line, err := dec.Decode(&v)
+			assertErr(t, err)
+			assertEq(t, "line", i, line)
+			assertEq(t, "id", i, v.ID)
+			assertEq(t, "name", fmt.Sprintf("rec%d", i), v.Name)
+		}
+		var v lineRecord
+		_, err := dec.Decode(&v)
+		assertEq(t, "eof", io.EOF, err)
+		_, err = dec.Decode(&v)
+		assertEq(t, "eof again", io.EOF, err)
+	})
+	t.Run("unordered", func(t *testing.T) {
+		dec := json.NewLineDecoder[*lineRecord](strings.NewReader(src.String()))
+		dec.SetWorkers(8)
+		dec.SetOrdered(false)
+		var lines []int
+		for {
+			var v *lineRecord
+			line, err := dec.Decode(&v)
+			if err == io.EOF {
+				break
+			}
+			assertErr(t, err)
+			assertEq(t, "id", line, v.ID)
+			lines = append(lines, line)
+		}
+		assertEq(t, "records", 1000, len(lines))
+		sort.Ints(lines)
+		for i, line := range lines {
+			assertEq(t, "line", i+1, line)
+		}
+	})
+	t.Run("record error", func(t *testing.T) {
+		dec := json.NewLineDecoder[lineRecord](strings.NewReader("{\"id\":1}\r\n\n  \n{\"id\":\"x\"}\n{\"id\":\n3}\n{\"id\":4}"))
+		var v lineRecord
+		line, err := dec.Decode(&v)
+		assertErr(t, err)
+		assertEq(t, "line", 1, line)
+		assertEq(t, "id", 1, v.ID)
+
+		for _, expected := range []int{4, 5, 6} {
+			line, err = dec.Decode(&v)
+			var lineErr *json.LineError
+			if !errors.As(err, &lineErr) {
+				t.Fatalf("expected LineError but got %v", err)
+			}
+			assertEq(t, "line", expected, line)
+			assertEq(t, "error line", expected, lineErr.Line)
+			assertEq(t, "unchanged", 1, v.ID)
+		}
+		var typeErr *json.UnmarshalTypeError
+		dec = json.NewLineDecoder[lineRecord](strings.NewReader(`{"id":"x"}`))
+		_, err = dec.Decode(&v)
+		if !errors.As(err, &typeErr) {
+			t.Fatalf("expected UnmarshalTypeError but got %v", err)
+		}
+		if !strings.HasPrefix(err.Error(), "json: line 1: ") {
+			t.Fatalf("unexpected error message: %s", err)
+		}
+
+		line, err = json.NewLineDecoder[lineRecord](strings.NewReader("\n\n{\"id\":7}")).Decode(&v)
+		assertErr(t, err)
+		assertEq(t, "last line", 3, line)
+		assertEq(t, "id", 7, v.ID)
+	})
+	t.Run("read error", func(t *testing.T) {
+		readErr := errors.New("read error")
+		dec := json.NewLineDecoder[lineRecord](&errReader{r: strings.NewReader("{\"id\":1}\n{\"id\":2}\n{\"id\":"), err: readErr})
+		for i := 1; i <= 2; i++ {
+			var v lineRecord
+			_, err := dec.Decode(&v)
+			assertErr(t, err)
+			assertEq(t, "id", i, v.ID)
+		}
+		var v lineRecord
+		_, err := dec.Decode(&v)
+		assertEq(t, "error", readErr, err)
+	})
+	t.Run("option", func(t *testing.T) {
+		dec := json.NewLineDecoder[map[string]interface{}](strings.NewReader("{a: 'b', /* c */}\n"), json.AllowJSON5())
+		var v map[string]interface{}
+		_, err := dec.Decode(&v)
+		assertErr(t, err)
+		assertEq(t, "a", "b", v["a"])
+	})
+	t.Run("close", func(t *testing.T) {
+		dec := json.NewLineDecoder[lineRecord](strings.NewReader(src.String()))
+		dec.SetWorkers(2)
+		var v lineRecord
+		_, err := dec.Decode(&v)
+		assertErr(t, err)
+		assertErr(t, dec.Close())
+		_, err = dec.Decode(&v)
+		if err == nil || err == io.EOF {
+			t.Fatalf("expected error after Close but got %v", err)
+		}
+	})
+}
+
+type lineMarshaler struct {
+	err error
+}
+
+func (m lineMarshaler) MarshalJSON() ([]byte, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return []byte("{\n  \"a\": [1,\n 2]\n}"), nil
+}
+
+func TestLineEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	enc := json.NewLineEncoder(&buf)
+	assertErr(t, enc.Encode(lineRecord{ID: 1, Name: "a\nb"}))
+	assertErr(t, enc.Encode(lineMarshaler{}))
+
+	err := enc.Encode(lineMarshaler{err: errors.New("marshal error")})
+	var lineErr *json.LineError
+	if !errors.As(err, &lineErr) {
+		t.Fatalf("expected LineError but got %v", err)
+	}
+	assertEq(t, "line", 3, lineErr.Line)
+	var marshalerErr *json.MarshalerError
+	if !errors.As(err, &marshalerErr) {
+		t.Fatalf("expected MarshalerError but got %v", err)
+	}
+
+	err = enc.EncodeWithOption([]int{1}, json.Colorize(&json.ColorScheme{Int: json.ColorFormat{Header: "\n", Footer: ""}}))
+	if !errors.As(err, &lineErr) {
+		t.Fatalf("expected LineError but got %v", err)
+	}
+	assertEq(t, "line", 3, lineErr.Line)
+
+	enc.SetEscapeHTML(false)
+	assertErr(t, enc.Encode("<&>"))
+	assertEq(t, "lines", "{\"id\":1,\"name\":\"a\\nb\"}\n{\"a\":[1,2]}\n\"<&>\"\n", buf.String())
+
+	dec := json.NewLineDecoder[interface{}](&buf)
+	var lines []int
+	for {
+		var v interface{}
+		line, err := dec.Decode(&v)
+		if err == io.EOF {
+			break
+		}
+		assertErr(t, err)
+		lines = append(lines, line)
+	}
+	assertEq(t, "records", 3, len(lines))
+}
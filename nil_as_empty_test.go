@@ -0,0 +1,134 @@
+package json_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+type nilAsEmptyRecord struct {
+	Slice    []int             `json:"slice"`
+	Map      map[string]int    `json:"map"`
+	SlicePtr *[]int            `json:"slice_ptr"`
+	MapPtr   *map[string]int   `json:"map_ptr"`
+	Nested   [][]string        `json:"nested"`
+	Values   map[string][]int  `json:"values"`
+	Omit     []int             `json:"omit,omitempty"`
+	Items    []struct{ A int } `json:"items"`
+}
+
+type nonNilTagRecord struct {
+	Tagged      []int          `json:"tagged,nonnil"`
+	TaggedMap   map[string]int `json:"tagged_map,nonnil"`
+	TaggedPtr   *[]int         `json:"tagged_ptr,nonnil"`
+	Untagged    []int          `json:"untagged"`
+	UntaggedMap map[string]int `json:"untagged_map"`
+	Omit        []int          `json:"omit,omitempty,nonnil"`
+}
+
+func TestNilAsEmpty(t *testing.T) {
+	t.Run("option", func(t *testing.T) {
+		var nilSlice []int
+		var nilMap map[string]int
+		v := nilAsEmptyRecord{
+			SlicePtr: &nilSlice,
+			MapPtr:   &nilMap,
+			Nested:   [][]string{nil, {"a"}},
+			Values:   map[string][]int{"a": nil},
+		}
+		expected := `{"slice":[],"map":{},"slice_ptr":[],"map_ptr":{},"nested":[[],["a"]],"values":{"a":[]},"items":[]}`
+		got, err := json.MarshalWithOption(v, json.NilAsEmpty())
+		assertErr(t, err)
+		assertEq(t, "record", expected, string(got))
+
+		got, err = json.MarshalWithOption(&v, json.NilAsEmpty())
+		assertErr(t, err)
+		assertEq(t, "record pointer", expected, string(got))
+
+		got, err = json.MarshalWithOption(nilAsEmptyRecord{}, json.NilAsEmpty())
+		assertErr(t, err)
+		assertEq(t, "nil pointers", `{"slice":[],"map":{},"slice_ptr":null,"map_ptr":null,"nested":[],"values":{},"items":[]}`, string(got))
+
+		got, err = json.MarshalWithOption(nilSlice, json.NilAsEmpty())
+		assertErr(t, err)
+		assertEq(t, "slice", `[]`, string(got))
+
+		got, err = json.MarshalWithOption(nilMap, json.NilAsEmpty())
+		assertErr(t, err)
+		assertEq(t, "map", `{}`, string(got))
+
+		got, err = json.MarshalWithOption([]interface{}{nilSlice, nilMap}, json.NilAsEmpty())
+		assertErr(t, err)
+		assertEq(t, "interface", `[[],{}]`, string(got))
+	})
+	t.Run("indent", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		assertErr(t, enc.EncodeWithOption(struct {
+			A []int          `json:"a"`
+			B map[string]int `json:"b"`
+		}{}, json.NilAsEmpty()))
+		assertEq(t, "indent", "{\n  \"a\": [],\n  \"b\": {}\n}\n", buf.String())
+	})
+	t.Run("colorize", func(t *testing.T) {
+		got, err := json.MarshalWithOption(struct {
+			A []int `json:"a"`
+		}{}, json.NilAsEmpty(), json.Colorize(&json.ColorScheme{}))
+		assertErr(t, err)
+		assertEq(t, "colorize", `{"a":[]}`, string(got))
+	})
+	t.Run("tag", func(t *testing.T) {
+		got, err := json.Marshal(nonNilTagRecord{})
+		assertErr(t, err)
+		assertEq(t, "tag", `{"tagged":[],"tagged_map":{},"tagged_ptr":null,"untagged":null,"untagged_map":null}`, string(got))
+
+		var nilSlice []int
+		got, err = json.Marshal(&nonNilTagRecord{TaggedPtr: &nilSlice})
+		assertErr(t, err)
+		assertEq(t, "tag pointer", `{"tagged":[],"tagged_map":{},"tagged_ptr":[],"untagged":null,"untagged_map":null}`, string(got))
+	})
+	t.Run("bytes", func(t *testing.T) {
+		type bytesRecord struct {
+			Head     []byte  `json:"head,nonnil"`
+			Ptr      *[]byte `json:"ptr,nonnil"`
+			Hex      []byte  `json:"hex,nonnil,format:hex"`
+			Array    []byte  `json:"array,nonnil,format:array"`
+			Untagged []byte  `json:"untagged"`
+			End      []byte  `json:"end,nonnil"`
+		}
+		var nilBytes []byte
+		got, err := json.Marshal(bytesRecord{Ptr: &nilBytes})
+		assertErr(t, err)
+		assertEq(t, "tag", `{"head":"","ptr":"","hex":"","array":[],"untagged":null,"end":""}`, string(got))
+
+		got, err = json.Marshal(&bytesRecord{})
+		assertErr(t, err)
+		assertEq(t, "nil pointer", `{"head":"","ptr":null,"hex":"","array":[],"untagged":null,"end":""}`, string(got))
+
+		got, err = json.MarshalIndent(struct {
+			A []byte `json:"a,nonnil"`
+		}{}, "", " ")
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n \"a\": \"\"\n}", string(got))
+
+		got, err = json.MarshalWithOption([]interface{}{nilBytes, map[string][]byte{"a": nil}, struct {
+			A []byte `json:"a"`
+			B []byte `json:"b,format:base64url"`
+		}{}}, json.NilAsEmpty())
+		assertErr(t, err)
+		assertEq(t, "option", `["",{"a":""},{"a":"","b":""}]`, string(got))
+
+		got, err = json.Marshal([]interface{}{nilBytes, struct {
+			A []byte `json:"a"`
+		}{}})
+		assertErr(t, err)
+		assertEq(t, "disabled", `[null,{"a":null}]`, string(got))
+	})
+	t.Run("disabled", func(t *testing.T) {
+		got, err := json.Marshal(nilAsEmptyRecord{})
+		assertErr(t, err)
+		assertEq(t, "record", `{"slice":null,"map":null,"slice_ptr":null,"map_ptr":null,"nested":null,"values":null,"items":null}`, string(got))
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/goccy/go-json/internal/decoder"
 	"github.com/goccy/go-json/internal/encoder"
+	"github.com/goccy/go-json/internal/runtime"
 )
 
 type EncodeOption = encoder.Option
@@ -34,6 +35,28 @@ func DisableNormalizeUTF8() EncodeOptionFunc {
 	}
 }
 
+// ASCIIOnly escapes all the non-ASCII characters when encoding string, so that the encoded result consists only of ASCII characters.
+// The characters are written as \uXXXX, and the characters outside the Basic Multilingual Plane as the surrogate pairs ( e.g. \ud83d\ude00 ).
+// Invalid UTF-8 bytes are replaced with \ufffd even if DisableNormalizeUTF8 is applied.
+// The strings in the results of MarshalJSON and the custom encoders are escaped as well.
+func ASCIIOnly() EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.Flag |= encoder.ASCIIOnlyOption
+	}
+}
+
+// EscapeChars escapes the ASCII characters in chars when encoding string, in addition to the characters escaped by default.
+// '/' is written as \/ and the others as \u00XX. The non-ASCII characters in chars are ignored; use ASCIIOnly to escape them.
+// If this option is applied more than once, the last one is used.
+// The strings in the results of MarshalJSON and the custom encoders are escaped as well.
+func EscapeChars(chars string) EncodeOptionFunc {
+	set := encoder.NewEscapeSet(chars)
+	return func(opt *EncodeOption) {
+		opt.Flag |= encoder.EscapeCharsOption
+		opt.EscapeSet = set
+	}
+}
+
 // Debug outputs debug information when panic occurs during encoding.
 func Debug() EncodeOptionFunc {
 	return func(opt *EncodeOption) {
@@ -63,6 +86,118 @@ func Colorize(scheme *ColorScheme) EncodeOptionFunc {
 	}
 }
 
+// TagKey specifies the struct tag keys used to read field names and options instead of "json".
+// If multiple keys are given, they are consulted in order and the first key present on a field is used,
+// e.g. TagKey("api", "json") reads the "api" tag and falls back to the "json" tag.
+// Compiled opcodes are cached separately for each set of keys.
+func TagKey(keys ...string) EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.StructTag = opt.StructTag.WithTagKeys(keys)
+	}
+}
+
+// FieldNamingStrategy converts Go field names into object keys for fields without a tag name.
+type FieldNamingStrategy = runtime.FieldNaming
+
+var (
+	// SnakeCase names fields like "user_id" from "UserID".
+	SnakeCase = runtime.SnakeCaseNaming
+	// CamelCase names fields like "userID" from "UserID".
+	CamelCase = runtime.CamelCaseNaming
+	// KebabCase names fields like "user-id" from "UserID".
+	KebabCase = runtime.KebabCaseNaming
+)
+
+// NewFieldNamingStrategy creates a custom FieldNamingStrategy from fn.
+// Compiled opcodes are cached per strategy, so create it once and reuse it.
+// Strategies created for each call are compiled each time and evict other cached opcodes.
+func NewFieldNamingStrategy(name string, fn func(string) string) *FieldNamingStrategy {
+	return runtime.NewFieldNaming(name, fn)
+}
+
+// FieldNaming applies naming to struct fields that have no name in their tag.
+// Explicitly tagged names are used as is.
+func FieldNaming(naming *FieldNamingStrategy) EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.StructTag = opt.StructTag.WithNaming(naming)
+	}
+}
+
+// CustomEncoder encodes values of type T with fn for this call, in the same way as RegisterEncoder.
+// It takes precedence over encoders registered by RegisterEncoder.
+// Compiled opcodes are cached by the types of the encoders and fn is looked up for each call,
+// so the option can also be created for each call.
+func CustomEncoder[T any](fn func(b []byte, v T) ([]byte, error)) EncodeOptionFunc {
+	enc := newCustomEncoder(fn)
+	return func(opt *EncodeOption) {
+		opt.Encoders = opt.Encoders.With(enc)
+	}
+}
+
+// Int64String encodes 64-bit integers ( int64, uint64, and int, uint and uintptr on 64-bit platforms ) as JSON strings,
+// in the same way as the `,string` tag option.
+// This keeps large IDs intact in clients such as JavaScript that represent numbers as float64.
+func Int64String() EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.Flag |= encoder.Int64StringOption
+		opt.Flag &= ^encoder.UnsafeInt64StringOption
+	}
+}
+
+// UnsafeInt64String encodes 64-bit integers as JSON strings only if they are out of the safe integer range ( ±(2^53-1) ),
+// which JavaScript numbers represent exactly. Integers within the range are encoded as numbers.
+func UnsafeInt64String() EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.Flag |= encoder.UnsafeInt64StringOption
+		opt.Flag &= ^encoder.Int64StringOption
+	}
+}
+
+// NilAsEmpty encodes nil slices as [] and nil maps as {} instead of null.
+// Nil byte slices are encoded as an empty string, or as the empty value of the format option ( e.g. [] for `format:array` ).
+// Nil pointers are still encoded as null.
+// To apply this to specific struct fields only, use the `nonnil` tag option ( e.g. `json:"items,nonnil"` ).
+func NilAsEmpty() EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		opt.Flag |= encoder.NilAsEmptyOption
+	}
+}
+
+// Redact replaces the values of the struct fields tagged with the redact option (e.g. `json:"ssn,redact"`)
+// and the struct fields and the entries of the maps with string keys whose keys are in keys with mask encoded as a JSON string,
+// to hide sensitive data in outputs like logs. Nil pointers, maps, slices and interfaces are encoded as null,
+// and omitempty is applied to the values before they are replaced. Without this option, the redact tag option has no effect.
+func Redact(mask string, keys ...string) EncodeOptionFunc {
+	return RedactFunc(func(string, interface{}) string {
+		return mask
+	}, keys...)
+}
+
+// RedactFunc is like Redact but replaces the values with the result of fn called with the key and the value of each field or map entry,
+// for example to show the hash of the value instead of the value itself. fn is not called for nil values.
+func RedactFunc(fn func(key string, v interface{}) string, keys ...string) EncodeOptionFunc {
+	redaction := encoder.NewRedaction(fn, keys)
+	return func(opt *EncodeOption) {
+		opt.Flag |= encoder.RedactOption
+		opt.Redaction = redaction
+	}
+}
+
+// HTML renders the encoded result as HTML by scheme instead of JSON text, in all the functions and methods which take EncodeOption,
+// such as MarshalWithOption, MarshalIndentWithOption, MarshalContext and Encoder.EncodeWithOption.
+// See IndentHTML for the markup. The strings are escaped for HTML instead of escaping HTML characters with \u,
+// and Colorize is ignored. If scheme is nil, DefaultHTMLScheme is used.
+func HTML(scheme *HTMLScheme) EncodeOptionFunc {
+	return func(opt *EncodeOption) {
+		if scheme == nil {
+			scheme = DefaultHTMLScheme
+		}
+		opt.Flag |= encoder.HTMLOption
+		opt.Flag &= ^encoder.HTMLEscapeOption
+		opt.HTMLScheme = scheme
+	}
+}
+
 type DecodeOption = decoder.Option
 type DecodeOptionFunc func(*DecodeOption)
 
@@ -77,3 +212,44 @@ func DecodeFieldPriorityFirstWin() DecodeOptionFunc {
 		opt.Flags |= decoder.FirstWinOption
 	}
 }
+
+// DecodeTagKey specifies the struct tag keys used to match object keys to fields instead of "json".
+// The keys are consulted in the same way as TagKey.
+func DecodeTagKey(keys ...string) DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.StructTag = opt.StructTag.WithTagKeys(keys)
+	}
+}
+
+// DecodeFieldNaming matches object keys to struct fields that have no name in their tag using naming.
+// Explicitly tagged names are used as is.
+func DecodeFieldNaming(naming *FieldNamingStrategy) DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.StructTag = opt.StructTag.WithNaming(naming)
+	}
+}
+
+// AllowJSON5 accepts JSON5 ( https://spec.json5.org ) in addition to JSON: comments, trailing commas,
+// single-quoted and multi-line strings, unquoted object keys, hexadecimal numbers, numbers with a leading '+'
+// or a leading or trailing decimal point, and Infinity, -Infinity and NaN, which can be decoded into floating-point numbers,
+// Number and interface{}.
+// The source is translated into JSON before decoding, so UnmarshalJSON receives JSON, and the offsets of the errors
+// other than the JSON5 syntax errors and InputOffset of Decoder are the offsets in the translated JSON.
+// Infinity, -Infinity and NaN have no JSON representation and are left as they are in the translated JSON,
+// so RawMessage and UnmarshalJSON receive them as is, e.g. [NaN] for a RawMessage.
+// With Decoder, the input is translated from the first call of DecodeWithOption with this option.
+// Decoder.Token does not support JSON5: before such a call, the tokens are read from the untranslated input,
+// so {a: 1} results in an error like "invalid character a as token".
+func AllowJSON5() DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.Flags |= decoder.JSON5Option
+	}
+}
+
+// DecodeInt64String accepts both numbers and numbers enclosed in JSON strings for 64-bit integers,
+// so that output of Int64String and UnsafeInt64String can be decoded.
+func DecodeInt64String() DecodeOptionFunc {
+	return func(opt *DecodeOption) {
+		opt.Flags |= decoder.Int64StringOption
+	}
+}
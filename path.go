@@ -1,9 +1,11 @@
 package json
 
 import (
+	"bytes"
 	"reflect"
 
 	"github.com/goccy/go-json/internal/decoder"
+	"github.com/goccy/go-json/internal/runtime"
 )
 
 // CreatePath creates JSON Path.
@@ -64,10 +66,13 @@ func (p *Path) Unmarshal(data []byte, v interface{}, optFuncs ...DecodeOptionFun
 	if err != nil {
 		return err
 	}
+	if ok, err := unmarshalPathWithCustomDecoder(contents, v, optFuncs...); ok {
+		return err
+	}
 	results := make([]interface{}, 0, len(contents))
 	for _, content := range contents {
 		var result interface{}
-		if err := Unmarshal(content, &result); err != nil {
+		if err := UnmarshalWithOption(content, &result, optFuncs...); err != nil {
 			return err
 		}
 		results = append(results, result)
@@ -78,6 +83,45 @@ func (p *Path) Unmarshal(data []byte, v interface{}, optFuncs ...DecodeOptionFun
 	return nil
 }
 
+// unmarshalPathWithCustomDecoder decodes contents directly into v with the decoder compiled for its type
+// when v or its slice element type uses a registered decoder, which cannot be assigned from interface{} values.
+func unmarshalPathWithCustomDecoder(contents [][]byte, v interface{}, optFuncs ...DecodeOptionFunc) (bool, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return false, nil
+	}
+	typ := rv.Elem().Type()
+	switch {
+	case typ.Kind() == reflect.Slice && decoder.UsesCustomDecoder(runtime.Type2RType(typ.Elem())):
+		if len(contents) == 1 && isJSONArray(contents[0]) {
+			// the path selects the array itself, such as $.items
+			return true, UnmarshalWithOption(contents[0], v, optFuncs...)
+		}
+		results := reflect.MakeSlice(typ, 0, len(contents))
+		for _, content := range contents {
+			elem := reflect.New(typ.Elem())
+			if err := UnmarshalWithOption(content, elem.Interface(), optFuncs...); err != nil {
+				return true, err
+			}
+			results = reflect.Append(results, elem.Elem())
+		}
+		rv.Elem().Set(results)
+		return true, nil
+	case decoder.UsesCustomDecoder(runtime.Type2RType(typ)):
+		if len(contents) == 0 {
+			return true, nil
+		}
+		return true, UnmarshalWithOption(contents[0], v, optFuncs...)
+	}
+	return false, nil
+}
+
+// isJSONArray reports whether the JSON value content is an array.
+func isJSONArray(content []byte) bool {
+	trimmed := bytes.TrimLeft(content, " \t\r\n")
+	return len(trimmed) > 0 && trimmed[0] == '['
+}
+
 // Get extract and substitute the value of the part corresponding to JSON Path from the input value.
 func (p *Path) Get(src, dst interface{}) error {
 	return p.path.Get(reflect.ValueOf(src), reflect.ValueOf(dst))
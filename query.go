@@ -24,6 +24,9 @@ var (
 // If the field you want to keep is a structure type, by creating a sub field query using BuildSubFieldQuery,
 // you can select the fields you want to keep in the structure.
 // This description can be written recursively.
+//
+// The field name "*" keeps all fields, and a dot-separated path such as "meta.*" or "user.name"
+// selects the fields of a nested structure. Fields can be removed by ExcludeField.
 func BuildFieldQuery(fields ...FieldQueryString) (*FieldQuery, error) {
 	query, err := Marshal(fields)
 	if err != nil {
@@ -32,6 +35,24 @@ func BuildFieldQuery(fields ...FieldQueryString) (*FieldQuery, error) {
 	return FieldQueryString(query).Build()
 }
 
+// ParseFieldQuery parses the compact field selector used for partial responses of REST APIs,
+// such as the value of "?fields=id,name,owner(id,email),items/price".
+// "owner(id,email)" selects the fields of owner, "items/price" is the path to the field of items,
+// "*" selects all fields and "-password" removes the field. An empty selector returns the nil query, which keeps all fields.
+// Use (*FieldQuery).Validate with the EncodeOptionFuncs used for encoding, such as TagKey and FieldNaming,
+// to report the fields that do not exist in the type to encode.
+func ParseFieldQuery(s string) (*FieldQuery, error) {
+	return encoder.ParseFieldQuery(s)
+}
+
+// ExcludeField builds a field query that removes the field from the structure.
+// If the query has only exclusions, all the other fields are kept.
+// name can be a dot-separated path such as "user.password" to remove the field of a nested structure.
+func ExcludeField(name string) FieldQueryString {
+	query, _ := Marshal(map[string]string{encoder.FieldQueryExcludeKey: name})
+	return FieldQueryString(query)
+}
+
 // BuildSubFieldQuery builds sub field query.
 func BuildSubFieldQuery(name string) *SubFieldQuery {
 	return &SubFieldQuery{name: name}
@@ -2,6 +2,8 @@ package json_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 
@@ -119,3 +121,206 @@ func TestFieldQuery(t *testing.T) {
 		t.Fatalf("failed to encode with field query: expected %q but got %q", expected, got)
 	}
 }
+
+type queryTestUser struct {
+	Name     string
+	Password string
+	Meta     *queryTestMeta
+	Tags     []*queryTestMeta
+}
+
+type queryTestMeta struct {
+	Created string
+	Secret  string
+}
+
+type queryTestAccount struct {
+	queryTestMeta
+	ID   int
+	User queryTestUser
+}
+
+func TestFieldQueryExclusion(t *testing.T) {
+	user := &queryTestUser{
+		Name:     "go",
+		Password: "pass",
+		Meta:     &queryTestMeta{Created: "now", Secret: "s"},
+		Tags:     []*queryTestMeta{{Created: "a", Secret: "b"}},
+	}
+	marshal := func(t *testing.T, v interface{}, fields ...json.FieldQueryString) string {
+		t.Helper()
+		query, err := json.BuildFieldQuery(fields...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := json.MarshalContext(json.SetFieldQueryToContext(context.Background(), query), v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	t.Run("exclude", func(t *testing.T) {
+		assertEq(t, "exclude", `{"Name":"go","Meta":{"Created":"now","Secret":"s"},"Tags":[{"Created":"a","Secret":"b"}]}`,
+			marshal(t, user, json.ExcludeField("Password")))
+	})
+	t.Run("dash prefixed name", func(t *testing.T) {
+		v := struct {
+			Dash string `json:"-x"`
+			X    string `json:"x"`
+		}{Dash: "a", X: "b"}
+		assertEq(t, "select", `{"-x":"a"}`, marshal(t, v, "-x"))
+		assertEq(t, "exclude", `{"-x":"a"}`, marshal(t, v, json.ExcludeField("x")))
+	})
+	t.Run("exclude nested path", func(t *testing.T) {
+		assertEq(t, "path", `{"Name":"go","Meta":{"Created":"now"},"Tags":[{"Created":"a"}]}`,
+			marshal(t, user, json.ExcludeField("Password"), json.ExcludeField("Meta.Secret"), json.ExcludeField("Tags.Secret")))
+	})
+	t.Run("exclude in sub field query", func(t *testing.T) {
+		assertEq(t, "sub", `{"Name":"go","Meta":{"Created":"now"}}`,
+			marshal(t, user, "Name", json.BuildSubFieldQuery("Meta").Fields(json.ExcludeField("Secret"))))
+	})
+	t.Run("wildcard", func(t *testing.T) {
+		assertEq(t, "wildcard", `{"Name":"go","Meta":{"Created":"now"},"Tags":[{"Created":"a","Secret":"b"}]}`,
+			marshal(t, user, "*", json.ExcludeField("Password"), json.ExcludeField("Meta.Secret")))
+		assertEq(t, "path wildcard", `{"Meta":{"Created":"now","Secret":"s"}}`, marshal(t, user, "Meta.*"))
+		assertEq(t, "path", `{"Meta":{"Created":"now"}}`, marshal(t, user, "Meta.Created"))
+	})
+	t.Run("embedded", func(t *testing.T) {
+		account := &queryTestAccount{
+			queryTestMeta: queryTestMeta{Created: "now", Secret: "s"},
+			ID:            1,
+			User:          queryTestUser{Name: "go", Password: "pass"},
+		}
+		assertEq(t, "embedded", `{"Created":"now","ID":1,"User":{"Name":"go","Meta":null,"Tags":null}}`,
+			marshal(t, account, json.ExcludeField("Secret"), json.ExcludeField("User.Password")))
+	})
+	t.Run("query string", func(t *testing.T) {
+		query, err := json.BuildFieldQuery("Name", json.ExcludeField("Meta.Secret"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		queryStr, err := query.QueryString()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertEq(t, "query string", json.FieldQueryString(`["Name",{"-":"Meta.Secret"}]`), queryStr)
+		rebuilt, err := queryStr.Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(rebuilt.Fields, query.Fields) {
+			t.Fatal("failed to rebuild field query")
+		}
+	})
+}
+
+type queryTestOrder struct {
+	ID    int             `json:"id"`
+	Name  string          `json:"name"`
+	Owner *queryTestOwner `json:"owner"`
+	Items []queryTestItem `json:"items"`
+	Note  string          `json:"note"`
+}
+
+type queryTestOwner struct {
+	ID    int    `json:"id"`
+	Email string `json:"email"`
+	Phone string `json:"phone"`
+}
+
+type queryTestItem struct {
+	Price int    `json:"price"`
+	Code  string `json:"code"`
+}
+
+func TestParseFieldQuery(t *testing.T) {
+	order := &queryTestOrder{
+		ID:    1,
+		Name:  "order",
+		Owner: &queryTestOwner{ID: 2, Email: "a@example.com", Phone: "000"},
+		Items: []queryTestItem{{Price: 100, Code: "x"}, {Price: 200, Code: "y"}},
+		Note:  "note",
+	}
+	t.Run("select", func(t *testing.T) {
+		query, err := json.ParseFieldQuery("id,name,owner(id,email),items/price")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertErr(t, query.Validate(order))
+		b, err := json.MarshalContext(json.SetFieldQueryToContext(context.Background(), query), order)
+		assertErr(t, err)
+		assertEq(t, "select", `{"id":1,"name":"order","owner":{"id":2,"email":"a@example.com"},"items":[{"price":100},{"price":200}]}`, string(b))
+	})
+	t.Run("exclude", func(t *testing.T) {
+		query, err := json.ParseFieldQuery(" -note , -owner/phone, -items/code ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertErr(t, query.Validate(order))
+		b, err := json.MarshalContext(json.SetFieldQueryToContext(context.Background(), query), order)
+		assertErr(t, err)
+		assertEq(t, "exclude", `{"id":1,"name":"order","owner":{"id":2,"email":"a@example.com"},"items":[{"price":100},{"price":200}]}`, string(b))
+	})
+	t.Run("query string", func(t *testing.T) {
+		query, err := json.ParseFieldQuery("id,owner(*),items/price")
+		if err != nil {
+			t.Fatal(err)
+		}
+		queryStr, err := query.QueryString()
+		assertErr(t, err)
+		assertEq(t, "query string", json.FieldQueryString(`["id",{"owner":["*"]},"items.price"]`), queryStr)
+	})
+	t.Run("unknown fields", func(t *testing.T) {
+		query, err := json.ParseFieldQuery("id,title,owner(emial),items/price/value")
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = query.Validate(order)
+		var queryErr *json.FieldQueryError
+		if !errors.As(err, &queryErr) {
+			t.Fatalf("expected FieldQueryError but got %v", err)
+		}
+		assertEq(t, "paths", "[title owner.emial items.price.value]", fmt.Sprint(queryErr.Paths))
+	})
+	t.Run("empty", func(t *testing.T) {
+		for _, src := range []string{"", "  "} {
+			query, err := json.ParseFieldQuery(src)
+			assertErr(t, err)
+			if query != nil {
+				t.Fatalf("expected nil query for %q", src)
+			}
+			assertErr(t, query.Validate(order))
+			b, err := json.MarshalContext(json.SetFieldQueryToContext(context.Background(), query), order)
+			assertErr(t, err)
+			expected, err := json.Marshal(order)
+			assertErr(t, err)
+			assertEq(t, "all fields", string(expected), string(b))
+		}
+	})
+	t.Run("validate with options", func(t *testing.T) {
+		type T struct {
+			UserID int    `api:"id"`
+			Name   string `api:"-"`
+			Email  string
+		}
+		query, err := json.ParseFieldQuery("id,email")
+		assertErr(t, err)
+		assertErr(t, query.Validate(T{}, json.TagKey("api"), json.FieldNaming(json.SnakeCase)))
+
+		query, err = json.ParseFieldQuery("id,name,Email")
+		assertErr(t, err)
+		err = query.Validate(T{}, json.TagKey("api"), json.FieldNaming(json.SnakeCase))
+		var queryErr *json.FieldQueryError
+		if !errors.As(err, &queryErr) {
+			t.Fatalf("expected FieldQueryError but got %v", err)
+		}
+		assertEq(t, "paths", "[name Email]", fmt.Sprint(queryErr.Paths))
+	})
+	t.Run("syntax error", func(t *testing.T) {
+		for _, src := range []string{"id,", "owner(id", "owner(id))", "-owner(id)", "a//b"} {
+			if _, err := json.ParseFieldQuery(src); err == nil {
+				t.Errorf("expected error for %q", src)
+			}
+		}
+	})
+}
@@ -0,0 +1,7 @@
+//go:build race
+// +build race
+
+package json_test
+
+// raceEnabled reports whether the tests run with the race detector, which allocates in instrumented code.
+const raceEnabled = true
@@ -0,0 +1,136 @@
+package json_test
+
+import (
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+type redactAccount struct {
+	Name     string            `json:"name"`
+	SSN      string            `json:"ssn,redact"`
+	Token    string            `json:"token"`
+	PIN      int               `json:"pin,redact"`
+	Password *string           `json:"password,omitempty,redact"`
+	Secret   interface{}       `json:"secret,redact"`
+	Labels   map[string]string `json:"labels,omitempty,redact"`
+}
+
+func TestRedact(t *testing.T) {
+	password := "p@ss"
+	v := redactAccount{
+		Name:     "alice",
+		SSN:      "123-45-6789",
+		Token:    "abc",
+		PIN:      1234,
+		Password: &password,
+		Secret:   []int{1, 2},
+		Labels:   map[string]string{"a": "b"},
+	}
+
+	t.Run("tag", func(t *testing.T) {
+		got, err := json.MarshalWithOption(v, json.Redact("***"))
+		assertErr(t, err)
+		assertEq(t, "redacted", `{"name":"alice","ssn":"***","token":"abc","pin":"***","password":"***","secret":"***","labels":"***"}`, string(got))
+
+		got, err = json.MarshalWithOption(&v, json.Redact("***"))
+		assertErr(t, err)
+		assertEq(t, "pointer", `{"name":"alice","ssn":"***","token":"abc","pin":"***","password":"***","secret":"***","labels":"***"}`, string(got))
+
+		got, err = json.Marshal(v)
+		assertErr(t, err)
+		assertEq(t, "without option", `{"name":"alice","ssn":"123-45-6789","token":"abc","pin":1234,"password":"p@ss","secret":[1,2],"labels":{"a":"b"}}`, string(got))
+	})
+	t.Run("keys", func(t *testing.T) {
+		got, err := json.MarshalWithOption(v, json.Redact("-", "token", "name"))
+		assertErr(t, err)
+		assertEq(t, "redacted", `{"name":"-","ssn":"-","token":"-","pin":"-","password":"-","secret":"-","labels":"-"}`, string(got))
+
+		got, err = json.MarshalWithOption(v, json.Redact("-", "token"))
+		assertErr(t, err)
+		assertEq(t, "other keys", `{"name":"alice","ssn":"-","token":"-","pin":"-","password":"-","secret":"-","labels":"-"}`, string(got))
+
+		got, err = json.MarshalWithOption(map[string]string{"token": "abc", "user": "bob"}, json.Redact("-", "token"))
+		assertErr(t, err)
+		assertEq(t, "map", `{"token":"-","user":"bob"}`, string(got))
+
+		got, err = json.MarshalWithOption(map[string]string{"token": "abc", "user": "bob"}, json.Redact("-", "token"), json.UnorderedMap())
+		assertErr(t, err)
+		if string(got) != `{"token":"-","user":"bob"}` && string(got) != `{"user":"bob","token":"-"}` {
+			t.Fatalf("unexpected unordered map: %s", got)
+		}
+
+		got, err = json.MarshalWithOption(map[string]interface{}{
+			"auth":  map[string]interface{}{"token": []int{1}, "id": 1},
+			"token": nil,
+		}, json.Redact("-", "token"))
+		assertErr(t, err)
+		assertEq(t, "nested map", `{"auth":{"id":1,"token":"-"},"token":null}`, string(got))
+
+		got, err = json.MarshalIndentWithOption(struct {
+			Header map[string]string `json:"header"`
+		}{Header: map[string]string{"token": "abc", "accept": "*/*"}}, "", " ", json.Redact("-", "token"))
+		assertErr(t, err)
+		assertEq(t, "map field", "{\n \"header\": {\n  \"accept\": \"*/*\",\n  \"token\": \"-\"\n }\n}", string(got))
+	})
+	t.Run("func", func(t *testing.T) {
+		var keys []string
+		got, err := json.MarshalWithOption(struct {
+			A string `json:"a,redact"`
+			B int    `json:"b"`
+		}{A: "secret", B: 1}, json.RedactFunc(func(key string, v interface{}) string {
+			keys = append(keys, key)
+			return key + ":" + v.(string)
+		}))
+		assertErr(t, err)
+		assertEq(t, "redacted", `{"a":"a:secret","b":1}`, string(got))
+		assertEq(t, "keys", 1, len(keys))
+	})
+	t.Run("nil", func(t *testing.T) {
+		got, err := json.MarshalWithOption(redactAccount{}, json.Redact("***"))
+		assertErr(t, err)
+		assertEq(t, "redacted", `{"name":"","ssn":"***","token":"","pin":"***","secret":null}`, string(got))
+	})
+	t.Run("omitempty", func(t *testing.T) {
+		type user struct {
+			Name  string   `json:"name,redact,omitempty"`
+			Age   int      `json:"age,redact,omitempty"`
+			Admin bool     `json:"admin,omitempty"`
+			Tags  []string `json:"tags,omitempty"`
+		}
+		got, err := json.MarshalWithOption(user{Tags: []string{}}, json.Redact("***", "admin", "tags"))
+		assertErr(t, err)
+		assertEq(t, "empty", `{}`, string(got))
+
+		got, err = json.MarshalWithOption(&user{Age: 30, Admin: true}, json.Redact("***", "admin", "tags"))
+		assertErr(t, err)
+		assertEq(t, "not empty", `{"age":"***","admin":"***"}`, string(got))
+	})
+	t.Run("colorize", func(t *testing.T) {
+		scheme := &json.ColorScheme{
+			String: json.ColorFormat{Header: "<s>", Footer: "</s>"},
+			Null:   json.ColorFormat{Header: "<n>", Footer: "</n>"},
+		}
+		type account struct {
+			SSN    string            `json:"ssn,redact"`
+			Secret *string           `json:"secret,redact"`
+			Labels map[string]string `json:"labels"`
+		}
+		v := account{SSN: "123", Labels: map[string]string{"token": "abc"}}
+		got, err := json.MarshalWithOption(v, json.Redact("***", "token"), json.Colorize(scheme))
+		assertErr(t, err)
+		assertEq(t, "compact", `{"ssn":<s>"***"</s>,"secret":<n>null</n>,"labels":{"token":<s>"***"</s>}}`, string(got))
+
+		got, err = json.MarshalIndentWithOption(v, "", " ", json.Redact("***", "token"), json.Colorize(scheme))
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n \"ssn\": <s>\"***\"</s>,\n \"secret\": <n>null</n>,\n \"labels\": {\n  \"token\": <s>\"***\"</s>\n }\n}", string(got))
+	})
+	t.Run("indent", func(t *testing.T) {
+		got, err := json.MarshalIndentWithOption(struct {
+			A string `json:"a,redact"`
+			B int    `json:"b"`
+		}{A: "secret", B: 1}, "", "  ", json.Redact("<hidden>"))
+		assertErr(t, err)
+		assertEq(t, "redacted", "{\n  \"a\": \"\\u003chidden\\u003e\",\n  \"b\": 1\n}", string(got))
+	})
+}
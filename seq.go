@@ -0,0 +1,73 @@
+package json
+
+import (
+	"bytes"
+
+	"github.com/goccy/go-json/internal/decoder"
+	"github.com/goccy/go-json/internal/errors"
+)
+
+var recordSeparator = []byte{decoder.RecordSeparator}
+
+// UseJSONSeq causes the Decoder to read the input as a JSON text sequence ( RFC 7464, application/json-seq ),
+// in which each JSON text is preceded by the record separator ( 0x1E ).
+//
+// Each call to Decode decodes the next element of the sequence. If an element is not a valid JSON text,
+// for example because it is truncated, Decode returns a *SeqError with the index of the element,
+// and the next call to Decode continues from the next record separator.
+// A top-level number, true, false or null which is not followed by white space is reported as truncated.
+// Token and More do not handle the record separators.
+func (d *Decoder) UseJSONSeq() {
+	d.seq = true
+}
+
+func (d *Decoder) decodeSeq(v interface{}, optFuncs ...DecodeOptionFunc) error {
+	elem, err := d.s.ReadSeqElement()
+	if err != nil {
+		return err
+	}
+	d.seqIndex++
+	if err := d.decodeSeqElement(elem, v, optFuncs...); err != nil {
+		return &SeqError{Index: d.seqIndex, Err: err}
+	}
+	return nil
+}
+
+func (d *Decoder) decodeSeqElement(elem []byte, v interface{}, optFuncs ...DecodeOptionFunc) error {
+	if isTruncatedSeqElement(elem) {
+		return errors.ErrUnexpectedEndOfJSON("value", int64(len(elem)))
+	}
+	s := decoder.NewStream(bytes.NewReader(elem))
+	s.UseNumber = d.s.UseNumber
+	s.DisallowUnknownFields = d.s.DisallowUnknownFields
+	s.Option = d.s.Option
+	if err := (&Decoder{s: s}).DecodeWithOption(v, optFuncs...); err != nil {
+		return err
+	}
+	return s.ValidateEnd()
+}
+
+// isTruncatedSeqElement reports whether elem may be a truncated top-level number, true, false or null,
+// which must be followed by white space in a JSON text sequence.
+func isTruncatedSeqElement(elem []byte) bool {
+	text := bytes.TrimLeft(elem, " \t\r\n")
+	if len(text) == 0 {
+		return false
+	}
+	switch text[0] {
+	case '-', '0', '1', '2', '3', '4', '5', '6', '7', '8', '9', 't', 'f', 'n':
+		switch elem[len(elem)-1] {
+		case ' ', '\t', '\r', '\n':
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+// SetJSONSeq specifies whether the encoder writes a JSON text sequence ( RFC 7464, application/json-seq ),
+// in which each top-level value is preceded by the record separator ( 0x1E ) and followed by a newline character.
+// It applies to the values written by Encode and by the token-level methods.
+func (e *Encoder) SetJSONSeq(on bool) {
+	e.enabledJSONSeq = on
+}
@@ -0,0 +1,184 @@
+package json_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/goccy/go-json"
+)
+
+func TestDecoderJSONSeq(t *testing.T) {
+	t.Run("decode", func(t *testing.T) {
+		src := "\x1e{\"a\":1}\n\x1e\x1e[1,\n 2]\n\x1e\"s\"\n\x1e \n\x1e12\n"
+		dec := json.NewDecoder(strings.NewReader(src))
+		dec.UseJSONSeq()
+		var m map[string]int
+		assertErr(t, dec.Decode(&m))
+		assertEq(t, "object", 1, m["a"])
+		var a []int
+		assertErr(t, dec.Decode(&a))
+		assertEq(t, "array", 2, len(a))
+		var s string
+		assertErr(t, dec.Decode(&s))
+		assertEq(t, "string", "s", s)
+		var n int
+		assertErr(t, dec.Decode(&n))
+		assertEq(t, "number", 12, n)
+		assertEq(t, "eof", io.EOF, dec.Decode(&n))
+		assertEq(t, "eof again", io.EOF, dec.Decode(&n))
+	})
+	t.Run("recover", func(t *testing.T) {
+		src := "\x1e{\"a\":1\x1e{\"a\":2}\n\x1e123\x1e{\"a\":3} {}\n\x1etrue\n\x1e{\"a\":\"x\"}\n\x1e{\"a\":4}"
+		dec := json.NewDecoder(strings.NewReader(src))
+		dec.UseJSONSeq()
+		var results []int
+		var failed []int
+		for {
+			var v struct {
+				A int `json:"a"`
+			}
+			err := dec.Decode(&v)
+			if err == io.EOF {
+				break
+			}
+			var seqErr *json.SeqError
+			if errors.As(err, &seqErr) {
+				failed = append(failed, seqErr.Index)
+				continue
+			}
+			assertErr(t, err)
+			results = append(results, v.A)
+		}
+		assertEq(t, "results", 2, len(results))
+		assertEq(t, "first", 2, results[0])
+		assertEq(t, "last", 4, results[1])
+		assertEq(t, "failed", 5, len(failed))
+		for i, index := range []int{1, 3, 4, 5, 6} {
+			assertEq(t, "index", index, failed[i])
+		}
+	})
+	t.Run("truncated", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader("\x1e123\x1enul\x1e-1 \x1e\"a\""))
+		dec.UseJSONSeq()
+		var v interface{}
+		err := dec.Decode(&v)
+		var syntaxErr *json.SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Fatalf("expected SyntaxError but got %v", err)
+		}
+		if !strings.HasPrefix(err.Error(), "json: sequence element 1: ") {
+			t.Fatalf("unexpected error message: %s", err)
+		}
+		var seqErr *json.SeqError
+		if !errors.As(dec.Decode(&v), &seqErr) {
+			t.Fatal("expected SeqError")
+		}
+		assertEq(t, "index", 2, seqErr.Index)
+		assertErr(t, dec.Decode(&v))
+		assertEq(t, "number", float64(-1), v)
+		assertErr(t, dec.Decode(&v))
+		assertEq(t, "string", "a", v)
+	})
+	t.Run("read error", func(t *testing.T) {
+		errRead := errors.New("read error")
+		dec := json.NewDecoder(io.MultiReader(
+			strings.NewReader("\x1e{\"a\":1}\n\x1e{\"a\":"),
+			iotest.ErrReader(errRead),
+		))
+		dec.UseJSONSeq()
+		var v map[string]int
+		assertErr(t, dec.Decode(&v))
+		assertEq(t, "first", 1, v["a"])
+		if err := dec.Decode(&v); err != errRead {
+			t.Fatalf("expected the error of the reader but got %v", err)
+		}
+	})
+	t.Run("leading bytes", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader("\n{\"a\":1}\n\x1e{\"a\":2}\n"))
+		dec.UseJSONSeq()
+		var v map[string]int
+		assertErr(t, dec.Decode(&v))
+		assertEq(t, "first", 1, v["a"])
+		v = nil
+		assertErr(t, dec.Decode(&v))
+		assertEq(t, "second", 2, v["a"])
+	})
+	t.Run("decoder options", func(t *testing.T) {
+		dec := json.NewDecoder(strings.NewReader("\x1e{\"a\":1}\n\x1e{\"a\":1,\"b\":2}\n"))
+		dec.UseJSONSeq()
+		dec.UseNumber()
+		dec.DisallowUnknownFields()
+		var v interface{}
+		assertErr(t, dec.Decode(&v))
+		assertEq(t, "number", json.Number("1"), v.(map[string]interface{})["a"])
+		var s struct {
+			A int `json:"a"`
+		}
+		if err := dec.Decode(&s); err == nil {
+			t.Fatal("expected error for unknown field")
+		}
+	})
+	t.Run("large", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetJSONSeq(true)
+		long := strings.Repeat("x", 4096)
+		for i := 0; i < 10; i++ {
+			assertErr(t, enc.Encode([]string{long, long}))
+		}
+		dec := json.NewDecoder(&buf)
+		dec.UseJSONSeq()
+		for i := 0; i < 10; i++ {
+			var v []string
+			assertErr(t, dec.Decode(&v))
+			assertEq(t, "len", 2, len(v))
+			assertEq(t, "value", long, v[1])
+		}
+		var v []string
+		assertEq(t, "eof", io.EOF, dec.Decode(&v))
+	})
+}
+
+func TestEncoderJSONSeq(t *testing.T) {
+	t.Run("encode", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetJSONSeq(true)
+		assertErr(t, enc.Encode(map[string]int{"a": 1}))
+		assertErr(t, enc.Encode(1))
+		if err := enc.Encode(func() {}); err == nil {
+			t.Fatal("expected error")
+		}
+		enc.SetIndent("", " ")
+		assertErr(t, enc.Encode([]int{1}))
+		enc.SetJSONSeq(false)
+		assertErr(t, enc.Encode(true))
+		assertEq(t, "seq", "\x1e{\"a\":1}\n\x1e1\n\x1e[\n 1\n]\ntrue\n", buf.String())
+	})
+	t.Run("flush", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetJSONSeq(true)
+		enc.SetFlushThreshold(8)
+		assertErr(t, enc.Encode([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}))
+		assertEq(t, "seq", "\x1e[1,2,3,4,5,6,7,8,9,10]\n", buf.String())
+	})
+	t.Run("token", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetJSONSeq(true)
+		assertErr(t, enc.WriteToken(json.Delim('[')))
+		assertErr(t, enc.WriteToken(1))
+		assertErr(t, enc.WriteToken(json.Delim('{')))
+		assertErr(t, enc.WriteToken("a"))
+		assertErr(t, enc.WriteToken("b"))
+		assertErr(t, enc.WriteToken(json.Delim('}')))
+		assertErr(t, enc.WriteToken(json.Delim(']')))
+		assertErr(t, enc.WriteToken("c"))
+		assertEq(t, "seq", "\x1e[1,{\"a\":\"b\"}]\n\x1e\"c\"\n", buf.String())
+	})
+}
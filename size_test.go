@@ -13,6 +13,8 @@ func TestOpcodeSize(t *testing.T) {
 		size := unsafe.Sizeof(encoder.Opcode{})
 		// Size is 152 bytes after adding IsZeroMethodFunc (interface{} = 16 bytes)
 		// and IsZeroMethodNeedsPtr (bool = 1 byte, padded) to Opcode
+		// Size stays 152 bytes after adding InlineKeys (map = 8 bytes)
+		// since HasIsZeroMethod and IsZeroMethodNeedsPtr are placed next to each other.
 		if size != 152 {
 			t.Fatalf("unexpected opcode size: expected 152bytes but got %dbytes", size)
 		}
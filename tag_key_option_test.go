@@ -0,0 +1,104 @@
+package json_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/goccy/go-json"
+)
+
+type tagKeyAPIUser struct {
+	ID       int64  `json:"id" api:"user_id"`
+	Name     string `json:"name" api:"display_name,omitempty"`
+	Password string `json:"password" api:"-"`
+	Email    string `json:"email"`
+}
+
+type tagKeyAPIGroup struct {
+	Owner   *tagKeyAPIUser  `api:"owner"`
+	Members []tagKeyAPIUser `api:"members"`
+}
+
+func TestTagKeyOption(t *testing.T) {
+	user := tagKeyAPIUser{ID: 1, Name: "alice", Password: "secret", Email: "a@example.com"}
+
+	t.Run("encode", func(t *testing.T) {
+		got, err := json.MarshalWithOption(user, json.TagKey("api"))
+		assertErr(t, err)
+		assertEq(t, "api tag", `{"user_id":1,"display_name":"alice","Email":"a@example.com"}`, string(got))
+
+		got, err = json.Marshal(user)
+		assertErr(t, err)
+		assertEq(t, "default tag", `{"id":1,"name":"alice","password":"secret","email":"a@example.com"}`, string(got))
+	})
+	t.Run("encode with fallback", func(t *testing.T) {
+		got, err := json.MarshalWithOption(user, json.TagKey("api", "json"))
+		assertErr(t, err)
+		assertEq(t, "fallback", `{"user_id":1,"display_name":"alice","email":"a@example.com"}`, string(got))
+	})
+	t.Run("encode omitempty from custom tag", func(t *testing.T) {
+		got, err := json.MarshalWithOption(tagKeyAPIUser{ID: 2}, json.TagKey("api"))
+		assertErr(t, err)
+		assertEq(t, "omitempty", `{"user_id":2,"Email":""}`, string(got))
+	})
+	t.Run("encode nested and indent", func(t *testing.T) {
+		group := tagKeyAPIGroup{Owner: &user, Members: []tagKeyAPIUser{{ID: 3}}}
+		got, err := json.MarshalWithOption(group, json.TagKey("api", "json"))
+		assertErr(t, err)
+		assertEq(t, "nested", `{"owner":{"user_id":1,"display_name":"alice","email":"a@example.com"},"members":[{"user_id":3,"email":""}]}`, string(got))
+
+		got, err = json.MarshalIndentWithOption(tagKeyAPIUser{ID: 4}, "", " ", json.TagKey("api"))
+		assertErr(t, err)
+		assertEq(t, "indent", "{\n \"user_id\": 4,\n \"Email\": \"\"\n}", string(got))
+	})
+	t.Run("encode interface value", func(t *testing.T) {
+		got, err := json.MarshalWithOption(map[string]interface{}{"u": user}, json.TagKey("api"))
+		assertErr(t, err)
+		assertEq(t, "interface", `{"u":{"user_id":1,"display_name":"alice","Email":"a@example.com"}}`, string(got))
+	})
+	t.Run("encoder", func(t *testing.T) {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		assertErr(t, enc.EncodeWithOption(user, json.TagKey("api")))
+		assertErr(t, enc.Encode(user))
+		assertEq(t, "encoder", "{\"user_id\":1,\"display_name\":\"alice\",\"Email\":\"a@example.com\"}\n{\"id\":1,\"name\":\"alice\",\"password\":\"secret\",\"email\":\"a@example.com\"}\n", buf.String())
+	})
+	t.Run("decode", func(t *testing.T) {
+		src := []byte(`{"user_id":10,"display_name":"bob","password":"x","id":99}`)
+		var v tagKeyAPIUser
+		assertErr(t, json.UnmarshalWithOption(src, &v, json.DecodeTagKey("api")))
+		assertEq(t, "id", int64(10), v.ID)
+		assertEq(t, "name", "bob", v.Name)
+		assertEq(t, "password", "", v.Password)
+
+		var def tagKeyAPIUser
+		assertErr(t, json.Unmarshal(src, &def))
+		assertEq(t, "default id", int64(99), def.ID)
+		assertEq(t, "default password", "x", def.Password)
+	})
+	t.Run("decode with fallback", func(t *testing.T) {
+		var v tagKeyAPIGroup
+		src := []byte(`{"owner":{"user_id":5,"email":"o@example.com"},"members":[{"user_id":6}]}`)
+		assertErr(t, json.UnmarshalWithOption(src, &v, json.DecodeTagKey("api", "json")))
+		assertEq(t, "owner id", int64(5), v.Owner.ID)
+		assertEq(t, "owner email", "o@example.com", v.Owner.Email)
+		assertEq(t, "members", 1, len(v.Members))
+		assertEq(t, "member id", int64(6), v.Members[0].ID)
+	})
+	t.Run("decoder", func(t *testing.T) {
+		dec := json.NewDecoder(bytes.NewBufferString(`{"user_id":7} {"id":8}`))
+		var v tagKeyAPIUser
+		assertErr(t, dec.DecodeWithOption(&v, json.DecodeTagKey("api")))
+		assertEq(t, "id", int64(7), v.ID)
+
+		// the option applies only to the call.
+		v = tagKeyAPIUser{}
+		assertErr(t, dec.Decode(&v))
+		assertEq(t, "next id", int64(8), v.ID)
+	})
+	t.Run("default key is the fast path", func(t *testing.T) {
+		got, err := json.MarshalWithOption(user, json.TagKey("json"))
+		assertErr(t, err)
+		assertEq(t, "json key", `{"id":1,"name":"alice","password":"secret","email":"a@example.com"}`, string(got))
+	})
+}